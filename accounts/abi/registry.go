@@ -0,0 +1,290 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxRevertNesting is the maximum depth of revert data nested in the bytes
+// arguments of custom errors which is decoded.
+const maxRevertNesting = 8
+
+// registeredError is a custom error known to an ErrorRegistry.
+type registeredError struct {
+	Error
+	named bool // Whether the argument names are known
+}
+
+// ErrorRegistry is a collection of Solidity custom errors, used to decode the
+// revert data of failed calls beyond the built-in Error(string) and Panic(uint256)
+// errors. Errors are registered from contract ABIs or by their signature, e.g.
+// from a 4byte selector database. A registry is read-only once loaded and safe
+// for concurrent use.
+type ErrorRegistry struct {
+	errors     map[[4]byte][]registeredError
+	signatures map[[4]byte][]string // Unparsed signatures, decoded on demand
+}
+
+// NewErrorRegistry creates an empty error registry.
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{
+		errors:     make(map[[4]byte][]registeredError),
+		signatures: make(map[[4]byte][]string),
+	}
+}
+
+// LoadErrorRegistry creates an error registry from a JSON file, or from all the
+// JSON files of a directory. Each file holds either a contract ABI, a compiler
+// artifact with an "abi" field, or a selector database mapping 4byte selectors
+// to signatures.
+func LoadErrorRegistry(path string) (*ErrorRegistry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return nil, err
+		}
+	}
+	r := NewErrorRegistry()
+	for _, file := range files {
+		blob, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.load(blob); err != nil {
+			return nil, fmt.Errorf("failed to load %s: %v", file, err)
+		}
+	}
+	return r, nil
+}
+
+// load registers the errors defined in a JSON file.
+func (r *ErrorRegistry) load(blob []byte) error {
+	blob = bytes.TrimSpace(blob)
+	if len(blob) > 0 && blob[0] == '[' {
+		parsed, err := JSON(bytes.NewReader(blob))
+		if err != nil {
+			return err
+		}
+		r.AddABI(parsed)
+		return nil
+	}
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if err := json.Unmarshal(blob, &artifact); err == nil && len(artifact.ABI) > 0 && artifact.ABI[0] == '[' {
+		parsed, err := JSON(bytes.NewReader(artifact.ABI))
+		if err != nil {
+			return err
+		}
+		r.AddABI(parsed)
+		return nil
+	}
+	var selectors map[string]string
+	if err := json.Unmarshal(blob, &selectors); err != nil {
+		return errors.New("neither an ABI nor a selector database")
+	}
+	for selector, signature := range selectors {
+		id, err := hex.DecodeString(strings.TrimPrefix(selector, "0x"))
+		if err != nil || len(id) != 4 {
+			return fmt.Errorf("invalid selector %q", selector)
+		}
+		r.signatures[[4]byte(id)] = append(r.signatures[[4]byte(id)], signature)
+	}
+	return nil
+}
+
+// AddABI registers all custom errors of a contract ABI.
+func (r *ErrorRegistry) AddABI(abi ABI) {
+	for _, e := range abi.Errors {
+		r.add(registeredError{Error: e, named: true})
+	}
+}
+
+// AddSignature registers a custom error by its signature, for example
+// "InsufficientBalance(uint256,uint256)".
+func (r *ErrorRegistry) AddSignature(signature string) error {
+	e, err := parseErrorSignature(signature)
+	if err != nil {
+		return err
+	}
+	r.add(registeredError{Error: e})
+	return nil
+}
+
+// add registers an error unless an error with the same signature is known.
+func (r *ErrorRegistry) add(e registeredError) {
+	id := [4]byte(e.ID[:4])
+	for _, known := range r.errors[id] {
+		if known.Sig == e.Sig {
+			return
+		}
+	}
+	r.errors[id] = append(r.errors[id], e)
+}
+
+// parseErrorSignature assembles an error from its signature.
+func parseErrorSignature(signature string) (Error, error) {
+	selector, err := ParseSelector(signature)
+	if err != nil {
+		return Error{}, err
+	}
+	inputs := make(Arguments, len(selector.Inputs))
+	for i, input := range selector.Inputs {
+		typ, err := NewType(input.Type, "", input.Components)
+		if err != nil {
+			return Error{}, err
+		}
+		inputs[i] = Argument{Type: typ}
+	}
+	return NewError(selector.Name, inputs), nil
+}
+
+// Len returns the number of registered errors and signatures.
+func (r *ErrorRegistry) Len() int {
+	var n int
+	for _, errs := range r.errors {
+		n += len(errs)
+	}
+	for _, sigs := range r.signatures {
+		n += len(sigs)
+	}
+	return n
+}
+
+// Fingerprint returns a hash identifying the contents of the registry, which
+// changes whenever an error is registered that could alter a decoded revert
+// reason. A nil registry has an empty fingerprint.
+func (r *ErrorRegistry) Fingerprint() common.Hash {
+	if r == nil {
+		return common.Hash{}
+	}
+	var entries []string
+	for _, errs := range r.errors {
+		for _, e := range errs {
+			if e.named {
+				entries = append(entries, "abi:"+e.String())
+			} else {
+				entries = append(entries, "sig:"+e.Sig)
+			}
+		}
+	}
+	for id, sigs := range r.signatures {
+		for _, sig := range sigs {
+			entries = append(entries, fmt.Sprintf("4byte:%x:%s", id, sig))
+		}
+	}
+	sort.Strings(entries)
+	return crypto.Keccak256Hash([]byte(strings.Join(entries, "\n")))
+}
+
+// UnpackRevert decodes the revert data of a failed call. Error(string) and
+// Panic(uint256) are decoded the same way as by the UnpackRevert function,
+// custom errors are rendered with their arguments, e.g.
+// "InsufficientBalance(available: 1, required: 2)". Revert data nested in
+// bytes arguments, as returned by proxies and multicall contracts, is decoded
+// in place. The registry may be nil, in which case only the built-in errors
+// are decoded.
+func (r *ErrorRegistry) UnpackRevert(data []byte) (string, error) {
+	return r.unpackRevert(data, 0)
+}
+
+func (r *ErrorRegistry) unpackRevert(data []byte, depth int) (string, error) {
+	if reason, err := UnpackRevert(data); err == nil {
+		if depth > 0 {
+			return strconv.Quote(reason), nil
+		}
+		return reason, nil
+	}
+	if r == nil || len(data) < 4 {
+		return "", errors.New("invalid data for unpacking")
+	}
+	id := [4]byte(data[:4])
+	for _, e := range r.errors[id] {
+		if reason, ok := r.format(e, data, depth); ok {
+			return reason, nil
+		}
+	}
+	for _, signature := range r.signatures[id] {
+		e, err := parseErrorSignature(signature)
+		if err != nil || !bytes.Equal(e.ID[:4], id[:]) {
+			continue
+		}
+		if reason, ok := r.format(registeredError{Error: e}, data, depth); ok {
+			return reason, nil
+		}
+	}
+	return "", fmt.Errorf("unknown error selector %#x", id)
+}
+
+// format renders a custom error with the decoded arguments.
+func (r *ErrorRegistry) format(e registeredError, data []byte, depth int) (string, bool) {
+	values, err := e.Unpack(data)
+	if err != nil {
+		return "", false
+	}
+	args := make([]string, len(e.Inputs))
+	for i, value := range values.([]interface{}) {
+		args[i] = r.formatValue(value, depth)
+		if e.named {
+			args[i] = e.Inputs[i].Name + ": " + args[i]
+		}
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", ")), true
+}
+
+// formatValue renders an error argument, decoding nested revert data.
+func (r *ErrorRegistry) formatValue(value interface{}, depth int) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		if depth < maxRevertNesting {
+			if reason, err := r.unpackRevert(v, depth+1); err == nil {
+				return reason
+			}
+		}
+		return hexutil.Encode(v)
+	}
+	// Fixed size byte arrays are rendered as hex
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		blob := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(blob), rv)
+		return hexutil.Encode(blob)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const registryTestABI = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"error","name":"CallFailed","inputs":[{"name":"target","type":"address"},{"name":"reason","type":"bytes"}]},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"}],"outputs":[]}
+]`
+
+// packError assembles the revert data of an error from its signature.
+func packError(t *testing.T, signature string, args ...interface{}) []byte {
+	t.Helper()
+
+	e, err := parseErrorSignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := e.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(crypto.Keccak256([]byte(signature))[:4], data...)
+}
+
+func TestErrorRegistry(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token.json"), []byte(`{"contractName":"Token","abi":`+registryTestABI+`}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	selectors := `{"0x82b42900":"Unauthorized()","ca08c80d":"Expired(uint64,bytes32)"}`
+	if err := os.WriteFile(filepath.Join(dir, "selectors.json"), []byte(selectors), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadErrorRegistry(dir)
+	if err != nil {
+		t.Fatalf("failed to load registry: %v", err)
+	}
+	if r.Len() != 4 {
+		t.Fatalf("registry size mismatch: have %d, want 4", r.Len())
+	}
+	var (
+		target       = common.HexToAddress("0x1234")
+		errorString  = packError(t, "Error(string)", "boom")
+		unauthorized = packError(t, "Unauthorized()")
+	)
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{errorString, "boom"},
+		{packError(t, "Panic(uint256)", big.NewInt(0x11)), "arithmetic underflow or overflow"},
+		{packError(t, "InsufficientBalance(uint256,uint256)", big.NewInt(1), big.NewInt(2)), "InsufficientBalance(available: 1, required: 2)"},
+		{unauthorized, "Unauthorized()"},
+		{packError(t, "Expired(uint64,bytes32)", uint64(7), [32]byte{0xff}), "Expired(7, 0xff00000000000000000000000000000000000000000000000000000000000000)"},
+		{packError(t, "CallFailed(address,bytes)", target, unauthorized), "CallFailed(target: " + target.Hex() + ", reason: Unauthorized())"},
+		{packError(t, "CallFailed(address,bytes)", target, errorString), "CallFailed(target: " + target.Hex() + `, reason: "boom")`},
+		{packError(t, "CallFailed(address,bytes)", target, []byte{1, 2}), "CallFailed(target: " + target.Hex() + ", reason: 0x0102)"},
+	}
+	for i, tt := range tests {
+		have, err := r.UnpackRevert(tt.data)
+		if err != nil {
+			t.Fatalf("test %d: failed to unpack: %v", i, err)
+		}
+		if have != tt.want {
+			t.Errorf("test %d: reason mismatch, have %q, want %q", i, have, tt.want)
+		}
+	}
+	if _, err := r.UnpackRevert(packError(t, "Unknown(uint256)", big.NewInt(1))); err == nil {
+		t.Error("expected error for unknown selector")
+	}
+	// A nil registry only decodes the built-in errors
+	var empty *ErrorRegistry
+	if have, err := empty.UnpackRevert(errorString); err != nil || have != "boom" {
+		t.Errorf("unexpected result from nil registry: %q, %v", have, err)
+	}
+	if _, err := empty.UnpackRevert(unauthorized); err == nil {
+		t.Error("expected error for custom error on nil registry")
+	}
+}
+
+func TestErrorRegistryFingerprint(t *testing.T) {
+	t.Parallel()
+
+	var empty *ErrorRegistry
+	if empty.Fingerprint() != (common.Hash{}) {
+		t.Fatal("nil registry has a fingerprint")
+	}
+	a, b := NewErrorRegistry(), NewErrorRegistry()
+	for _, sig := range []string{"Unauthorized()", "Expired(uint64,bytes32)"} {
+		if err := a.AddSignature(sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, sig := range []string{"Expired(uint64,bytes32)", "Unauthorized()"} {
+		if err := b.AddSignature(sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatal("registration order changes the fingerprint")
+	}
+	if err := b.AddSignature("InsufficientBalance(uint256,uint256)"); err != nil {
+		t.Fatal(err)
+	}
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Fatal("fingerprint unchanged by a new error")
+	}
+}
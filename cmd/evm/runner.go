@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -61,6 +62,7 @@ var runCommand = &cli.Command{
 		InputFlag,
 		InputFileFlag,
 		PriceFlag,
+		ProfileFlag,
 		ReceiverFlag,
 		SenderFlag,
 		ValueFlag,
@@ -107,6 +109,11 @@ var (
 		Value:    new(big.Int),
 		Category: flags.VMCategory,
 	}
+	ProfileFlag = &cli.StringFlag{
+		Name:     "profile",
+		Usage:    "Profile the gas usage and write it as folded stacks to the given file",
+		Category: flags.VMCategory,
+	}
 	ReceiverFlag = &cli.StringFlag{
 		Name:     "receiver",
 		Usage:    "The transaction receiver (execution context)",
@@ -294,6 +301,20 @@ func runCmd(ctx *cli.Context) error {
 		runtimeConfig.ChainConfig = params.AllEthashProtocolChanges
 	}
 
+	var profiler *tracers.Tracer
+	if ctx.IsSet(ProfileFlag.Name) {
+		if tracer != nil {
+			fmt.Println("gas profiling cannot be combined with tracing")
+			os.Exit(1)
+		}
+		var err error
+		if profiler, err = tracers.DefaultDirectory.New("gasProfiler", &tracers.Context{}, nil, runtimeConfig.ChainConfig); err != nil {
+			fmt.Printf("could not create gas profiler: %v\n", err)
+			os.Exit(1)
+		}
+		runtimeConfig.EVMConfig.Tracer = profiler.Hooks
+	}
+
 	var hexInput []byte
 	if inputFileFlag := ctx.String(InputFileFlag.Name); inputFileFlag != "" {
 		var err error
@@ -363,6 +384,12 @@ allocations:     %d
 allocated bytes: %d
 `, stats.GasUsed, stats.Time, stats.Allocs, stats.BytesAllocated)
 	}
+	if profiler != nil {
+		if err := writeProfile(ctx.String(ProfileFlag.Name), profiler); err != nil {
+			fmt.Printf("could not write gas profile: %v\n", err)
+			os.Exit(1)
+		}
+	}
 	if tracer == nil {
 		fmt.Printf("%#x\n", output)
 		if err != nil {
@@ -373,6 +400,39 @@ allocated bytes: %d
 	return nil
 }
 
+// writeProfile writes the folded stacks collected by the gas profiler to the
+// given file and a summary of the most expensive instructions to stderr.
+func writeProfile(path string, profiler *tracers.Tracer) error {
+	blob, err := profiler.GetResult()
+	if err != nil {
+		return err
+	}
+	var profile struct {
+		Opcodes []struct {
+			Address common.Address `json:"address"`
+			PC      uint64         `json:"pc"`
+			Op      string         `json:"op"`
+			Count   uint64         `json:"count"`
+			Gas     uint64         `json:"gas"`
+		} `json:"opcodes"`
+		Folded string `json:"folded"`
+	}
+	if err := json.Unmarshal(blob, &profile); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(profile.Folded), 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "### GAS PROFILE")
+	for i, op := range profile.Opcodes {
+		if i == 10 {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "%s pc=%-6d %-14s count=%-8d gas=%d\n", op.Address.Hex(), op.PC, op.Op, op.Count, op.Gas)
+	}
+	return nil
+}
+
 // writeLogs writes vm logs in a readable format to the given writer
 func writeLogs(writer io.Writer, logs []*types.Log) {
 	for _, log := range logs {
@@ -17,14 +17,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
@@ -35,7 +40,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/debug"
 	"github.com/ethereum/go-ethereum/internal/era"
@@ -45,6 +54,40 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	replayTracerFlag = &cli.StringFlag{
+		Name:  "tracer",
+		Usage: "Name of the tracer to replay the block with (default = struct logger)",
+	}
+	replayTracerConfigFlag = &cli.StringFlag{
+		Name:  "tracerconfig",
+		Usage: "Tracer configuration (JSON)",
+	}
+	traceRangeFromFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "First block to trace",
+	}
+	traceRangeToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block to trace (default = head block)",
+	}
+	traceRangeTracerFlag = &cli.StringFlag{
+		Name:  "tracer",
+		Usage: "Name of the tracer to trace the blocks with",
+		Value: "callTracer",
+	}
+	traceRangeOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "Directory to write the block traces to",
+		Value: "traces",
+	}
+	traceRangeReexecFlag = &cli.Uint64Flag{
+		Name:  "reexec",
+		Usage: "Number of blocks to re-execute to regenerate a missing historical state (hash scheme only)",
+		Value: 128,
+	}
+)
+
 var (
 	initCommand = &cli.Command{
 		Action:    initGenesis,
@@ -190,6 +233,54 @@ This command dumps out the state for a given block (or latest, if none provided)
 `,
 	}
 
+	replayBadBlockCommand = &cli.Command{
+		Action:    replayBadBlock,
+		Name:      "replay-bad-block",
+		Usage:     "Re-execute a rejected block with a tracer",
+		ArgsUsage: "<blockHash>",
+		Flags: slices.Concat([]cli.Flag{
+			utils.CacheFlag,
+			replayTracerFlag,
+			replayTracerConfigFlag,
+		}, utils.DatabaseFlags),
+		Description: `
+The replay-bad-block command looks up a block previously rejected by the engine
+API (or, failing that, by block import) and re-executes its transactions on top
+of the parent state. The output of the selected tracer is printed to stdout as
+one JSON object per transaction. If no tracer is specified, the struct logger
+is used. The parent state must be available in the database.
+
+The command fails if a transaction fails, or if the gas used or the state root of
+the replayed block differ from the ones of the block.`,
+	}
+
+	traceRangeCommand = &cli.Command{
+		Action: traceRange,
+		Name:   "trace-range",
+		Usage:  "Trace a range of blocks into per-block files",
+		Flags: slices.Concat([]cli.Flag{
+			utils.CacheFlag,
+			traceRangeFromFlag,
+			traceRangeToFlag,
+			traceRangeTracerFlag,
+			replayTracerConfigFlag,
+			traceRangeOutFlag,
+			traceRangeReexecFlag,
+		}, utils.DatabaseFlags),
+		Description: `
+The trace-range command opens the database read-only and traces the transactions
+of the given block range with the selected tracer, using a worker per CPU. The
+results of every block containing transactions are written to a separate file
+in the output directory, in the same format as debug_traceChain.
+
+The state of the block preceding the range must be available. With the hash
+scheme, missing states are regenerated by re-executing up to --reexec blocks,
+the path scheme only holds the states of the most recent blocks.
+
+The number of the last completed block is recorded in the output directory, an
+interrupted run continues from there when restarted with the same directory.`,
+	}
+
 	pruneCommand = &cli.Command{
 		Action:    pruneHistory,
 		Name:      "prune-history",
@@ -203,6 +294,79 @@ helps reduce storage requirements for nodes that don't need full historical data
 	}
 )
 
+// traceRangeProgress is the file in the output directory of trace-range holding
+// the number of the last completed block.
+const traceRangeProgress = "progress"
+
+// traceRange traces a range of blocks into per-block files.
+func traceRange(ctx *cli.Context) error {
+	out := ctx.String(traceRangeOutFlag.Name)
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+
+	var (
+		from   = ctx.Uint64(traceRangeFromFlag.Name)
+		to     = chain.CurrentBlock().Number.Uint64()
+		tracer = ctx.String(traceRangeTracerFlag.Name)
+		reexec = ctx.Uint64(traceRangeReexecFlag.Name)
+		config = &tracers.TraceConfig{Tracer: &tracer, Reexec: &reexec}
+	)
+	if ctx.IsSet(traceRangeToFlag.Name) {
+		to = ctx.Uint64(traceRangeToFlag.Name)
+	}
+	if ctx.IsSet(replayTracerConfigFlag.Name) {
+		config.TracerConfig = json.RawMessage(ctx.String(replayTracerConfigFlag.Name))
+	}
+	// Continue after the last completed block of an interrupted run
+	progress := filepath.Join(out, traceRangeProgress)
+	if blob, err := os.ReadFile(progress); err == nil {
+		done, err := strconv.ParseUint(strings.TrimSpace(string(blob)), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid trace progress file: %v", err)
+		}
+		if done >= to {
+			log.Info("Block range already traced", "to", to)
+			return nil
+		}
+		if done >= from {
+			log.Info("Resuming block range tracing", "done", done)
+			from = done + 1
+		}
+	}
+	sigctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var (
+		api   = tracers.NewAPI(eth.NewChainTraceBackend(chain, db))
+		start = time.Now()
+		count int
+	)
+	log.Info("Tracing block range", "from", from, "to", to, "tracer", tracer, "out", out)
+	err := api.TraceRange(sigctx, from, to, config, func(number uint64, hash common.Hash, result []byte) error {
+		// Write atomically, so an interruption never leaves partial files behind
+		name := filepath.Join(out, fmt.Sprintf("%010d.json", number))
+		if err := os.WriteFile(name+".tmp", result, 0644); err != nil {
+			return err
+		}
+		if err := os.Rename(name+".tmp", name); err != nil {
+			return err
+		}
+		count++
+		return os.WriteFile(progress, []byte(strconv.FormatUint(number, 10)), 0644)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Traced block range", "from", from, "to", to, "files", count, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 // initGenesis will initialise the given JSON format genesis file and writes it as
 // the zero'd block (i.e. genesis) or will fail hard if it can't succeed.
 func initGenesis(ctx *cli.Context) error {
@@ -665,3 +829,126 @@ func pruneHistory(ctx *cli.Context) error {
 
 	return nil
 }
+
+// replayBadTxResult is the output emitted for each transaction re-executed by
+// the replay-bad-block command.
+type replayBadTxResult struct {
+	TxHash common.Hash     `json:"txHash"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// replayBadBlock re-executes a block rejected by the engine API or by the block
+// importer on top of its parent state, tracing each transaction.
+func replayBadBlock(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires the hash of the bad block as argument.")
+	}
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(ctx.Args().First())); err != nil {
+		return fmt.Errorf("invalid block hash: %v", err)
+	}
+	var tracerConfig json.RawMessage
+	if ctx.IsSet(replayTracerConfigFlag.Name) {
+		tracerConfig = json.RawMessage(ctx.String(replayTracerConfigFlag.Name))
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+	defer chain.Stop()
+
+	var block *types.Block
+	if payload := rawdb.ReadBadPayload(db, hash); payload != nil {
+		block = payload.Block()
+		log.Info("Found rejected payload", "number", block.Number(), "hash", hash,
+			"parent", block.ParentHash(), "rejected", time.Unix(int64(payload.Time), 0), "reason", payload.Reason)
+	} else if block = rawdb.ReadBadBlock(db, hash); block != nil {
+		log.Info("Found rejected block", "number", block.Number(), "hash", hash, "parent", block.ParentHash())
+	} else {
+		return fmt.Errorf("bad block %#x not found", hash)
+	}
+	if block.NumberU64() == 0 {
+		return errors.New("genesis is not replayable")
+	}
+	parent := chain.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return fmt.Errorf("parent block %#x not found", block.ParentHash())
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return fmt.Errorf("parent state %x not available: %v", parent.Root, err)
+	}
+	var (
+		config  = chain.Config()
+		header  = block.Header()
+		signer  = types.MakeSigner(config, block.Number(), block.Time())
+		rules   = config.Rules(block.Number(), false, block.Time())
+		vmctx   = core.NewEVMBlockContext(header, chain, nil, config, statedb)
+		gp      = new(core.GasPool).AddGas(block.GasLimit())
+		usedGas uint64
+		out     = json.NewEncoder(os.Stdout)
+	)
+	evm := vm.NewEVM(vmctx, statedb, config, vm.Config{})
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+	}
+	if config.IsPrague(block.Number(), block.Time()) {
+		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	}
+	for i, tx := range block.Transactions() {
+		var tracer *tracers.Tracer
+		if name := ctx.String(replayTracerFlag.Name); name != "" {
+			txctx := &tracers.Context{
+				BlockHash:   hash,
+				BlockNumber: block.Number(),
+				TxIndex:     i,
+				TxHash:      tx.Hash(),
+			}
+			if tracer, err = tracers.DefaultDirectory.New(name, txctx, tracerConfig, config); err != nil {
+				return err
+			}
+		} else {
+			structLogger := logger.NewStructLogger(nil)
+			tracer = &tracers.Tracer{
+				Hooks:     structLogger.Hooks(),
+				GetResult: structLogger.GetResult,
+				Stop:      structLogger.Stop,
+			}
+		}
+		res := &replayBadTxResult{TxHash: tx.Hash()}
+
+		msg, err := core.TransactionToMessage(tx, signer, block.BaseFee(), &rules)
+		if err == nil {
+			evm := vm.NewEVM(vmctx, state.NewHookedState(statedb, tracer.Hooks), config, vm.Config{Tracer: tracer.Hooks})
+			statedb.SetTxContext(tx.Hash(), i)
+			_, err = core.ApplyTransactionWithEVM(msg, gp, statedb, block.Number(), hash, tx, &usedGas, evm)
+		}
+		if err != nil {
+			// The state is unusable after a failed transaction, stop replaying
+			res.Error = err.Error()
+			if err := out.Encode(res); err != nil {
+				return err
+			}
+			return fmt.Errorf("transaction %d (%#x) failed: %v", i, tx.Hash(), err)
+		}
+		if res.Result, err = tracer.GetResult(); err != nil {
+			res.Error = err.Error()
+		}
+		if err := out.Encode(res); err != nil {
+			return err
+		}
+	}
+	chain.Engine().Finalize(chain, header, statedb, block.Body())
+	root := statedb.IntermediateRoot(config.IsEIP158(block.Number()))
+	log.Info("Replayed bad block", "number", block.Number(), "hash", hash, "txs", len(block.Transactions()),
+		"gasused", usedGas, "expgas", block.GasUsed(), "root", root, "exproot", block.Root())
+	if usedGas != block.GasUsed() {
+		return fmt.Errorf("gas used mismatch: have %d, want %d", usedGas, block.GasUsed())
+	}
+	if root != block.Root() {
+		return fmt.Errorf("state root mismatch: have %x, want %x", root, block.Root())
+	}
+	return nil
+}
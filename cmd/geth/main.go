@@ -84,6 +84,11 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolSenderRateLimitFlag,
+		utils.TxPoolSenderBurstFlag,
+		utils.TxPoolToRateLimitFlag,
+		utils.TxPoolToBurstFlag,
+		utils.TxPoolDenylistFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
@@ -98,6 +103,8 @@ var (
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
 		utils.LogExportCheckpointsFlag,
+		utils.AddressIndexFlag,
+		utils.AddressHistoryFlag,
 		utils.StateHistoryFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
@@ -208,9 +215,17 @@ var (
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.TraceCacheFlag,
+		utils.RPCErrorABIsFlag,
+		utils.RPCReceiptRevertReasonFlag,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateLimitCostFlag,
+		utils.RPCRateLimitHeaderFlag,
+		utils.RPCSlowQueryFlag,
+		utils.RPCAPIKeysFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -248,6 +263,8 @@ func init() {
 		dumpCommand,
 		dumpGenesisCommand,
 		pruneCommand,
+		replayBadBlockCommand,
+		traceRangeCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -269,6 +286,8 @@ func init() {
 		snapshotCommand,
 		// See verkle.go
 		verkleCommand,
+		// See txpoolcmd.go
+		txpoolCommand,
 	}
 	if logTestCommand != nil {
 		app.Commands = append(app.Commands, logTestCommand)
@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	txpoolCommand = &cli.Command{
+		Name:  "txpool",
+		Usage: "A set of commands for transaction pool snapshots",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "dump",
+				Usage:     "Print the contents of a transaction pool snapshot",
+				ArgsUsage: "<file>",
+				Action:    dumpTxPool,
+				Description: `
+geth txpool dump <file>
+This command prints the transactions of a snapshot produced by txpool_export,
+one JSON object per line. If the file ends with .gz, it is decompressed.
+ `,
+			},
+		},
+	}
+)
+
+// txpoolDumpEntry is the JSON representation of a transaction in a pool snapshot.
+type txpoolDumpEntry struct {
+	Hash    common.Hash        `json:"hash"`
+	From    common.Address     `json:"from"`
+	Nonce   hexutil.Uint64     `json:"nonce"`
+	Pending bool               `json:"pending"`
+	Time    uint64             `json:"time,omitempty"`
+	Preconf core.PreconfStatus `json:"preconf,omitempty"`
+	Raw     hexutil.Bytes      `json:"raw"`
+}
+
+func dumpTxPool(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("expected exactly one argument: <file>")
+	}
+	file := ctx.Args().First()
+	in, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	if strings.HasSuffix(file, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return err
+		}
+	}
+	var (
+		stream = rlp.NewStream(reader, 0)
+		enc    = json.NewEncoder(os.Stdout)
+	)
+	for {
+		entry := new(txpool.ExportedTx)
+		if err := stream.Decode(entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var signer types.Signer = types.HomesteadSigner{}
+		if entry.Tx.Protected() {
+			signer = types.LatestSignerForChainID(entry.Tx.ChainId())
+		}
+		from, err := types.Sender(signer, entry.Tx)
+		if err != nil {
+			return fmt.Errorf("invalid sender for transaction %x: %v", entry.Tx.Hash(), err)
+		}
+		raw, err := entry.Tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		err = enc.Encode(&txpoolDumpEntry{
+			Hash:    entry.Tx.Hash(),
+			From:    from,
+			Nonce:   hexutil.Uint64(entry.Tx.Nonce()),
+			Pending: entry.Pending,
+			Time:    entry.Time,
+			Preconf: entry.Preconf,
+			Raw:     raw,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
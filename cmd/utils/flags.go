@@ -316,6 +316,17 @@ var (
 		Category: flags.StateCategory,
 		Value:    "",
 	}
+	AddressIndexFlag = &cli.BoolFlag{
+		Name:     "history.addresses.index",
+		Usage:    "Maintain an index of the transactions each address took part in",
+		Category: flags.StateCategory,
+	}
+	AddressHistoryFlag = &cli.Uint64Flag{
+		Name:     "history.addresses",
+		Usage:    "Number of recent blocks to maintain the address transaction index for (default = 0 = entire chain)",
+		Value:    ethconfig.Defaults.AddressHistory,
+		Category: flags.StateCategory,
+	}
 	// Beacon client light sync settings
 	BeaconApiFlag = &cli.StringSliceFlag{
 		Name:     "beacon.api",
@@ -465,6 +476,35 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSenderRateLimitFlag = &cli.Float64Flag{
+		Name:     "txpool.senderratelimit",
+		Usage:    "Maximum sustained number of transactions per second admitted from a single sender (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.SenderRateLimit,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSenderBurstFlag = &cli.Uint64Flag{
+		Name:     "txpool.senderburst",
+		Usage:    "Maximum number of transactions admitted from a single sender in a burst",
+		Value:    ethconfig.Defaults.TxPool.SenderBurst,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolToRateLimitFlag = &cli.Float64Flag{
+		Name:     "txpool.toratelimit",
+		Usage:    "Maximum sustained number of transactions per second admitted to a single recipient (0 = unlimited)",
+		Value:    ethconfig.Defaults.TxPool.ToRateLimit,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolToBurstFlag = &cli.Uint64Flag{
+		Name:     "txpool.toburst",
+		Usage:    "Maximum number of transactions admitted to a single recipient in a burst",
+		Value:    ethconfig.Defaults.TxPool.ToBurst,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolDenylistFlag = &cli.StringFlag{
+		Name:     "txpool.denylist",
+		Usage:    "File of addresses and function selectors whose transactions are rejected by the pool and the miner (reloaded on change)",
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -658,6 +698,22 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	TraceCacheFlag = &cli.IntFlag{
+		Name:     "trace.cache",
+		Usage:    "Megabytes of disk used to cache block trace results (0 = disabled)",
+		Value:    ethconfig.Defaults.TraceCache,
+		Category: flags.APICategory,
+	}
+	RPCErrorABIsFlag = &cli.StringFlag{
+		Name:     "rpc.errorabis",
+		Usage:    "Contract ABI file, or directory of ABI files and selector databases, used to decode custom revert errors",
+		Category: flags.APICategory,
+	}
+	RPCReceiptRevertReasonFlag = &cli.BoolFlag{
+		Name:     "rpc.receiptrevertreason",
+		Usage:    "Record the revert data of failed transactions when processing blocks and add their revert reasons to receipts",
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -834,6 +890,31 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCRateLimitFlag = &cli.StringSliceFlag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Per-client limit on the HTTP and WebSocket calls of matching methods in \"pattern:rate[:burst[:concurrent]]\" format, e.g. \"debug_trace*:1:2:1\". This flag can be given multiple times.",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitCostFlag = &cli.StringSliceFlag{
+		Name:     "rpc.ratelimit.cost",
+		Usage:    "Tokens taken by the calls of matching methods in \"pattern:cost\" format, e.g. \"eth_getLogs:10\". This flag can be given multiple times.",
+		Category: flags.APICategory,
+	}
+	RPCAPIKeysFlag = &flags.DirectoryFlag{
+		Name:     "rpc.apikeys",
+		Usage:    "Path to the JSON file of the API keys required by the HTTP and WebSocket calls (reloaded on SIGHUP)",
+		Category: flags.APICategory,
+	}
+	RPCSlowQueryFlag = &cli.DurationFlag{
+		Name:     "rpc.slowquery",
+		Usage:    "Log the HTTP and WebSocket calls taking at least this duration (0 = disabled)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitHeaderFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit.header",
+		Usage:    "HTTP header identifying the clients for rate limiting, e.g. an API key header, limited per IP address too as it is not verified (default = JWT subject or IP address)",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -1319,6 +1400,59 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+	setRPCRateLimits(ctx, cfg)
+
+	if ctx.IsSet(RPCAPIKeysFlag.Name) {
+		cfg.RPCAPIKeys = ctx.String(RPCAPIKeysFlag.Name)
+	}
+	if ctx.IsSet(RPCSlowQueryFlag.Name) {
+		cfg.RPCSlowQueryThreshold = ctx.Duration(RPCSlowQueryFlag.Name)
+	}
+}
+
+// setRPCRateLimits creates the per-client RPC limits from the set command line
+// flags, appending them to the configured ones.
+func setRPCRateLimits(ctx *cli.Context, cfg *node.Config) {
+	for _, s := range ctx.StringSlice(RPCRateLimitFlag.Name) {
+		parts := strings.Split(s, ":")
+		if len(parts) < 2 || len(parts) > 4 || parts[0] == "" {
+			Fatalf("Invalid RPC rate limit entry: %s", s)
+		}
+		limit := rpc.RateLimit{Methods: parts[0]}
+		rate, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || rate < 0 {
+			Fatalf("Invalid RPC rate limit entry: %s", s)
+		}
+		limit.Rate = rate
+		if len(parts) > 2 {
+			if limit.Burst, err = strconv.Atoi(parts[2]); err != nil || limit.Burst < 0 {
+				Fatalf("Invalid RPC rate limit burst: %s", s)
+			}
+		}
+		if len(parts) > 3 {
+			if limit.MaxConcurrent, err = strconv.Atoi(parts[3]); err != nil || limit.MaxConcurrent < 0 {
+				Fatalf("Invalid RPC rate limit concurrency: %s", s)
+			}
+		}
+		cfg.RPCRateLimits.Limits = append(cfg.RPCRateLimits.Limits, limit)
+	}
+	for _, s := range ctx.StringSlice(RPCRateLimitCostFlag.Name) {
+		parts := strings.Split(s, ":")
+		if len(parts) != 2 || parts[0] == "" {
+			Fatalf("Invalid RPC rate limit cost entry: %s", s)
+		}
+		cost, err := strconv.Atoi(parts[1])
+		if err != nil || cost < 0 {
+			Fatalf("Invalid RPC rate limit cost entry: %s", s)
+		}
+		if cfg.RPCRateLimits.Costs == nil {
+			cfg.RPCRateLimits.Costs = make(map[string]int)
+		}
+		cfg.RPCRateLimits.Costs[parts[0]] = cost
+	}
+	if ctx.IsSet(RPCRateLimitHeaderFlag.Name) {
+		cfg.RPCRateLimits.ClientHeader = ctx.String(RPCRateLimitHeaderFlag.Name)
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1639,6 +1773,21 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSenderRateLimitFlag.Name) {
+		cfg.SenderRateLimit = ctx.Float64(TxPoolSenderRateLimitFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderBurstFlag.Name) {
+		cfg.SenderBurst = ctx.Uint64(TxPoolSenderBurstFlag.Name)
+	}
+	if ctx.IsSet(TxPoolToRateLimitFlag.Name) {
+		cfg.ToRateLimit = ctx.Float64(TxPoolToRateLimitFlag.Name)
+	}
+	if ctx.IsSet(TxPoolToBurstFlag.Name) {
+		cfg.ToBurst = ctx.Uint64(TxPoolToBurstFlag.Name)
+	}
+	if ctx.IsSet(TxPoolDenylistFlag.Name) {
+		cfg.Denylist = ctx.String(TxPoolDenylistFlag.Name)
+	}
 	if ctx.IsSet(MinerEffectiveGasLimitFlag.Name) {
 		// While technically this is a miner config parameter, we also want the txpool to enforce
 		// it to avoid accepting transactions that can never be included in a block.
@@ -1873,6 +2022,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogExportCheckpointsFlag.Name) {
 		cfg.LogExportCheckpoints = ctx.String(LogExportCheckpointsFlag.Name)
 	}
+	if ctx.IsSet(AddressIndexFlag.Name) {
+		cfg.AddressIndex = ctx.Bool(AddressIndexFlag.Name)
+	}
+	if ctx.IsSet(AddressHistoryFlag.Name) {
+		cfg.AddressHistory = ctx.Uint64(AddressHistoryFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
 	}
@@ -1919,6 +2074,15 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(TraceCacheFlag.Name) {
+		cfg.TraceCache = ctx.Int(TraceCacheFlag.Name)
+	}
+	if ctx.IsSet(RPCErrorABIsFlag.Name) {
+		cfg.ErrorABIs = ctx.String(RPCErrorABIsFlag.Name)
+	}
+	if ctx.IsSet(RPCReceiptRevertReasonFlag.Name) {
+		cfg.ReceiptRevertReasons = ctx.Bool(RPCReceiptRevertReasonFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
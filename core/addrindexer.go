@@ -0,0 +1,443 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// callParticipantsRetention is the number of blocks below the address index head
+// whose call participants are retained, so the executed blocks reorged into the
+// chain are indexed with them.
+const callParticipantsRetention = 128
+
+// addrIndexer is the module responsible for maintaining the index of the
+// transactions each address took part in, according to the configured
+// indexing range.
+//
+// An address takes part in a transaction if it is the sender, the recipient,
+// the created contract, or the caller or callee of any internal call. The
+// internal calls are recorded while blocks are executed by processBlock. This
+// covers the blocks built by the local miner too, as their payloads are handed
+// back through engine_newPayload and executed like any other block. Blocks
+// imported without execution, e.g. by snap sync, are indexed by their top-level
+// participants only.
+//
+// The internal call participants are stored along with every executed block,
+// as a block may become canonical later on without being executed again. Once
+// the canonical block of a height is indexed, its participants are moved to a
+// record of the index, which is needed to unindex the block again on reorgs or
+// when the tail moves. The participants of the executed blocks more than
+// callParticipantsRetention blocks below the index head are dropped, whether
+// they were indexed or not. Blocks becoming canonical after that, or indexed
+// again after the index is purged, are indexed by their top-level participants
+// only.
+type addrIndexer struct {
+	// limit is the maximum number of blocks from head whose transactions
+	// are indexed:
+	//  * 0: means the entire chain should be indexed
+	//  * N: means the latest N blocks [HEAD-N+1, HEAD] should be indexed
+	//       and all others shouldn't.
+	limit uint64
+
+	// cutoff denotes the block number before which the chain segment should
+	// be pruned and not available locally.
+	cutoff     uint64
+	hashScheme bool
+	config     *params.ChainConfig
+	db         ethdb.Database
+	term       chan chan struct{}
+	closed     chan struct{}
+}
+
+// newAddrIndexer initializes the address indexer.
+func newAddrIndexer(limit uint64, chain *BlockChain) *addrIndexer {
+	cutoff, _ := chain.HistoryPruningCutoff()
+	indexer := &addrIndexer{
+		limit:      limit,
+		cutoff:     cutoff,
+		hashScheme: chain.triedb.Scheme() == rawdb.HashScheme,
+		config:     chain.chainConfig,
+		db:         chain.db,
+		term:       make(chan chan struct{}),
+		closed:     make(chan struct{}),
+	}
+	go indexer.loop(chain)
+
+	var msg string
+	if limit == 0 {
+		if indexer.cutoff == 0 {
+			msg = "entire chain"
+		} else {
+			msg = fmt.Sprintf("blocks since #%d", indexer.cutoff)
+		}
+	} else {
+		msg = fmt.Sprintf("last %d blocks", limit)
+	}
+	log.Info("Initialized address indexer", "range", msg)
+
+	return indexer
+}
+
+// run brings the address index in line with the given chain head in a separate
+// thread. If the stop channel is closed, the task should terminate as soon as
+// possible. The done channel will be closed once the task is complete.
+//
+// The index always covers a contiguous range of canonical blocks [tail, head].
+// The head is tracked by hash too, so that the blocks reorged out of the chain
+// can be unindexed before the new canonical ones are indexed.
+func (indexer *addrIndexer) run(head uint64, stop chan struct{}, done chan struct{}) {
+	defer func() { close(done) }()
+
+	// Short circuit if the chain is entirely below the cutoff point.
+	if head < indexer.cutoff {
+		return
+	}
+	from := indexer.cutoff
+	if indexer.limit != 0 && head >= indexer.limit {
+		from = max(from, head-indexer.limit+1)
+	}
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		tail   = rawdb.ReadAddressIndexTail(indexer.db)
+
+		number, hash, ok = rawdb.ReadAddressIndexHead(indexer.db)
+	)
+	// Roll back the blocks no longer part of the canonical chain. If any of them
+	// is not available anymore, the stale entries can't be located, purge the
+	// entire index and start over.
+	if tail != nil && ok {
+		batch := indexer.db.NewBatch()
+		for number >= *tail && rawdb.ReadCanonicalHash(indexer.db, number) != hash {
+			block := rawdb.ReadBlock(indexer.db, hash, number)
+			if block == nil {
+				tail = nil
+				break
+			}
+			indexer.indexBlock(batch, block, false)
+			if number == 0 {
+				tail = nil
+				break
+			}
+			number, hash = number-1, block.ParentHash()
+		}
+		if tail != nil {
+			rawdb.WriteAddressIndexHead(batch, number, hash)
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+		}
+	}
+	// Start from scratch if the index is missing, broken or too far behind
+	// the chain to be moved forward contiguously.
+	if tail == nil || !ok || number+1 < from {
+		stopCallback := func(bool) bool {
+			select {
+			case <-stop:
+				return true
+			default:
+				return false
+			}
+		}
+		if err := rawdb.DeleteAddressIndexDb(indexer.db, indexer.hashScheme, stopCallback); err != nil {
+			if !errors.Is(err, rawdb.ErrDeleteRangeInterrupted) {
+				log.Error("Failed to purge address index", "err", err)
+			}
+			return
+		}
+		rawdb.WriteAddressIndexTail(indexer.db, from)
+		tail, number = &from, from-1
+	}
+	// Index the new canonical blocks up to the head
+	batch := indexer.db.NewBatch()
+	for n := number + 1; n <= head; n++ {
+		select {
+		case <-stop:
+			head = n - 1
+		default:
+		}
+		if n > head {
+			break
+		}
+		hash := rawdb.ReadCanonicalHash(indexer.db, n)
+		block := rawdb.ReadBlock(indexer.db, hash, n)
+		if block == nil {
+			log.Warn("Missing block for address indexing", "number", n, "hash", hash)
+			break
+		}
+		indexer.indexBlock(batch, block, true)
+		rawdb.WriteAddressIndexHead(batch, n, hash)
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing address transactions", "block", n, "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed writing batch to db", "err", err)
+	}
+	indexer.pruneParticipants()
+	// Move the tail according to the configured limit, either backfilling the
+	// missing blocks or dropping the stale ones.
+	batch.Reset()
+	for *tail > from {
+		select {
+		case <-stop:
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+			return
+		default:
+		}
+		n := *tail - 1
+		block := rawdb.ReadBlock(indexer.db, rawdb.ReadCanonicalHash(indexer.db, n), n)
+		if block == nil {
+			log.Warn("Missing block for address indexing", "number", n)
+			break
+		}
+		indexer.indexBlock(batch, block, true)
+		rawdb.WriteAddressIndexTail(batch, n)
+		tail = &n
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	for *tail < from {
+		select {
+		case <-stop:
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+			return
+		default:
+		}
+		n := *tail
+		block := rawdb.ReadBlock(indexer.db, rawdb.ReadCanonicalHash(indexer.db, n), n)
+		if block != nil {
+			indexer.indexBlock(batch, block, false)
+		}
+		n++
+		rawdb.WriteAddressIndexTail(batch, n)
+		tail = &n
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed writing batch to db", "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed writing batch to db", "err", err)
+	}
+}
+
+// indexBlock writes or deletes the address index entries of all transactions
+// in the given block, along with the participants of its internal calls.
+func (indexer *addrIndexer) indexBlock(batch ethdb.Batch, block *types.Block, write bool) {
+	var (
+		signer       = types.MakeSigner(indexer.config, block.Number(), block.Time())
+		internal     = make(map[common.Hash][]common.Address)
+		participants []rawdb.CallParticipants
+	)
+	if write {
+		participants = rawdb.ReadCallParticipants(indexer.db, block.Hash(), block.NumberU64())
+		if len(participants) > 0 {
+			rawdb.WriteIndexedCallParticipants(batch, block.NumberU64(), participants)
+		}
+	} else {
+		participants = rawdb.ReadIndexedCallParticipants(indexer.db, block.NumberU64())
+		rawdb.DeleteIndexedCallParticipants(batch, block.NumberU64())
+	}
+	for _, p := range participants {
+		internal[p.TxHash] = p.Addresses
+	}
+	for i, tx := range block.Transactions() {
+		participants := make(map[common.Address]struct{})
+		if from, err := types.Sender(signer, tx); err == nil {
+			participants[from] = struct{}{}
+			if tx.To() == nil && !tx.IsDepositTx() {
+				participants[crypto.CreateAddress(from, tx.Nonce())] = struct{}{}
+			}
+		}
+		if to := tx.To(); to != nil {
+			participants[*to] = struct{}{}
+		}
+		for _, addr := range internal[tx.Hash()] {
+			participants[addr] = struct{}{}
+		}
+		for addr := range participants {
+			if write {
+				rawdb.WriteAddressIndexEntry(batch, addr, rawdb.AddressIndexEntry{
+					BlockNumber: block.NumberU64(),
+					BlockHash:   block.Hash(),
+					TxIndex:     uint32(i),
+					TxHash:      tx.Hash(),
+				})
+			} else {
+				rawdb.DeleteAddressIndexEntry(batch, addr, block.NumberU64(), uint32(i))
+			}
+		}
+	}
+}
+
+// pruneParticipants drops the call participants recorded for the executed blocks
+// more than callParticipantsRetention blocks below the index head.
+func (indexer *addrIndexer) pruneParticipants() {
+	head, _, ok := rawdb.ReadAddressIndexHead(indexer.db)
+	if !ok || head <= callParticipantsRetention {
+		return
+	}
+	err := rawdb.DeleteCallParticipantsBelow(indexer.db, head-callParticipantsRetention, indexer.hashScheme, func(bool) bool { return false })
+	if err != nil {
+		log.Error("Failed to prune call participants", "err", err)
+	}
+}
+
+// loop is the scheduler of the indexer, assigning indexing tasks depending on
+// the received chain event.
+func (indexer *addrIndexer) loop(chain *BlockChain) {
+	defer close(indexer.closed)
+
+	var (
+		stop   chan struct{} // Non-nil if background routine is active
+		done   chan struct{} // Non-nil if background routine is active
+		next   *uint64       // Head to index once the running task is complete
+		headCh = make(chan ChainHeadEvent)
+		sub    = chain.SubscribeChainHeadEvent(headCh)
+	)
+	defer sub.Unsubscribe()
+
+	if head := chain.CurrentBlock(); head != nil && head.Number.Uint64() != 0 {
+		stop = make(chan struct{})
+		done = make(chan struct{})
+		go indexer.run(head.Number.Uint64(), stop, done)
+	}
+	for {
+		select {
+		case h := <-headCh:
+			number := h.Header.Number.Uint64()
+			if done == nil {
+				stop = make(chan struct{})
+				done = make(chan struct{})
+				go indexer.run(number, stop, done)
+			} else {
+				next = &number
+			}
+
+		case <-done:
+			stop = nil
+			done = nil
+			if next != nil {
+				stop = make(chan struct{})
+				done = make(chan struct{})
+				go indexer.run(*next, stop, done)
+				next = nil
+			}
+
+		case ch := <-indexer.term:
+			if stop != nil {
+				close(stop)
+			}
+			if done != nil {
+				log.Info("Waiting background address indexer to exit")
+				<-done
+			}
+			close(ch)
+			return
+		}
+	}
+}
+
+// close shutdown the indexer. Safe to be called for multiple times.
+func (indexer *addrIndexer) close() {
+	ch := make(chan struct{})
+	select {
+	case indexer.term <- ch:
+		<-ch
+	case <-indexer.closed:
+	}
+}
+
+// callParticipants collects the callers and callees of all calls made by the
+// transactions of a block, to be indexed along with their top-level participants.
+type callParticipants struct {
+	txs  []rawdb.CallParticipants
+	seen map[common.Address]struct{}
+}
+
+// hooks returns the tracing hooks collecting the call participants, chained
+// after the given ones if any.
+func (c *callParticipants) hooks(base *tracing.Hooks) *tracing.Hooks {
+	var hooks tracing.Hooks
+	if base != nil {
+		hooks = *base
+	}
+	hooks.OnTxStart = func(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+		if base != nil && base.OnTxStart != nil {
+			base.OnTxStart(vm, tx, from)
+		}
+		c.txs = append(c.txs, rawdb.CallParticipants{TxHash: tx.Hash()})
+		c.seen = make(map[common.Address]struct{})
+	}
+	hooks.OnEnter = func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+		if base != nil && base.OnEnter != nil {
+			base.OnEnter(depth, typ, from, to, input, gas, value)
+		}
+		// Calls outside of transactions (system calls) are not indexed
+		if c.seen == nil {
+			return
+		}
+		current := &c.txs[len(c.txs)-1]
+		for _, addr := range []common.Address{from, to} {
+			if _, ok := c.seen[addr]; !ok {
+				c.seen[addr] = struct{}{}
+				current.Addresses = append(current.Addresses, addr)
+			}
+		}
+	}
+	hooks.OnTxEnd = func(receipt *types.Receipt, err error) {
+		if base != nil && base.OnTxEnd != nil {
+			base.OnTxEnd(receipt, err)
+		}
+		c.seen = nil
+	}
+	return &hooks
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// waitAddressIndex waits until the address index covers [tail, head] of the
+// canonical chain.
+func waitAddressIndex(t *testing.T, chain *BlockChain, tail, head uint64) {
+	t.Helper()
+
+	for i := 0; i < 500; i++ {
+		from, to, ok, err := chain.AddressIndexRange()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, hash, _ := rawdb.ReadAddressIndexHead(chain.db); ok && from == tail && to == head && hash == chain.CurrentBlock().Hash() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("address index not ready, want [%d, %d]", tail, head)
+}
+
+func verifyAddressTransactions(t *testing.T, chain *BlockChain, addr common.Address, want []uint64) {
+	t.Helper()
+
+	entries, _, err := chain.AddressTransactions(addr, 0, 0, chain.CurrentBlock().Number.Uint64(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("%x: transaction count mismatch, have %d, want %d", addr, len(entries), len(want))
+	}
+	for i, entry := range entries {
+		if entry.BlockNumber != want[i] {
+			t.Fatalf("%x: transaction %d block mismatch, have %d, want %d", addr, i, entry.BlockNumber, want[i])
+		}
+		block := chain.GetBlockByNumber(entry.BlockNumber)
+		if tx := block.Transactions()[entry.TxIndex]; tx.Hash() != entry.TxHash {
+			t.Fatalf("%x: transaction %d hash mismatch, have %x, want %x", addr, i, entry.TxHash, tx.Hash())
+		}
+	}
+}
+
+// TestAddressIndexer tests indexing the transactions by the addresses taking
+// part in them, including internal calls, reorgs and the index tail.
+func TestAddressIndexer(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		proxy    = common.HexToAddress("0x1000")
+		callee   = common.HexToAddress("0x2000")
+		receiver = common.HexToAddress("0xdeadbeef")
+		signer   = types.LatestSigner(params.TestChainConfig)
+
+		// CALL(gas, callee, 0, 0, 0, 0, 0)
+		code = append(append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, callee.Bytes()...), 0x5a, 0xf1, 0x00)
+
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				proxy:  {Code: code},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 8, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), proxy, new(big.Int), 100000, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+		if i == 2 {
+			tx, _ = types.SignTx(types.NewTransaction(gen.TxNonce(sender), receiver, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+			gen.AddTx(tx)
+		}
+	})
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.AddressHistory = new(uint64)
+
+	chain, err := NewBlockChain(db, cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	waitAddressIndex(t, chain, 0, 8)
+
+	verifyAddressTransactions(t, chain, sender, []uint64{1, 2, 3, 3, 4, 5, 6, 7, 8})
+	verifyAddressTransactions(t, chain, proxy, []uint64{1, 2, 3, 4, 5, 6, 7, 8})
+	verifyAddressTransactions(t, chain, callee, []uint64{1, 2, 3, 4, 5, 6, 7, 8})
+	verifyAddressTransactions(t, chain, receiver, []uint64{3})
+
+	// Queries are resumable at any transaction
+	entries, _, _ := chain.AddressTransactions(sender, 3, 1, 5, 100)
+	if len(entries) != 3 || entries[0].BlockNumber != 3 || entries[0].TxIndex != 1 {
+		t.Fatalf("unexpected resumed entries %v", entries)
+	}
+	// Limited scans report where the next one resumes
+	entries, next, _ := chain.AddressTransactions(sender, 1, 0, 8, 2)
+	if len(entries) != 2 || next == nil || next.BlockNumber != 3 || next.TxIndex != 0 {
+		t.Fatalf("unexpected limited scan %v, next %v", entries, next)
+	}
+	if _, next, _ = chain.AddressTransactions(sender, 7, 0, 8, 2); next != nil {
+		t.Fatalf("unexpected next entry at end of range: %v", next)
+	}
+	chain.Stop()
+
+	// Restart with a limited history, the stale blocks are unindexed
+	limit := uint64(4)
+	cacheConfig.AddressHistory = &limit
+	chain, err = NewBlockChain(db, cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+	waitAddressIndex(t, chain, 5, 8)
+	verifyAddressTransactions(t, chain, callee, []uint64{5, 6, 7, 8})
+	verifyAddressTransactions(t, chain, receiver, nil)
+
+	// Reorg to a heavier chain without transactions, the reorged blocks are unindexed
+	_, fork, _ := GenerateChainWithGenesis(gspec, engine, 10, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(common.Address{0x01})
+	})
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatal(err)
+	}
+	waitAddressIndex(t, chain, 7, 10)
+	verifyAddressTransactions(t, chain, sender, nil)
+	verifyAddressTransactions(t, chain, callee, nil)
+	if entries := rawdb.ReadAddressIndexEntries(db, callee, 0, 0, 10, 100); len(entries) != 0 {
+		t.Fatalf("stale entries left in the index: %v", entries)
+	}
+	// The participants of the unindexed blocks are dropped along with them
+	for _, block := range blocks {
+		if participants := rawdb.ReadIndexedCallParticipants(db, block.NumberU64()); participants != nil {
+			t.Fatalf("stale participants left for block %d: %v", block.NumberU64(), participants)
+		}
+	}
+}
+
+// TestAddressIndexerParticipants tests that the call participants recorded for
+// executed blocks are moved to the index for canonical blocks and dropped for
+// the others.
+func TestAddressIndexerParticipants(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		proxy  = common.HexToAddress("0x1000")
+		callee = common.HexToAddress("0x2000")
+		signer = types.LatestSigner(params.TestChainConfig)
+
+		// CALL(gas, callee, 0, 0, 0, 0, 0)
+		code = append(append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, callee.Bytes()...), 0x5a, 0xf1, 0x00)
+
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				proxy:  {Code: code},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+		head   = uint64(callParticipantsRetention + 4)
+	)
+	// Blocks differ from the canonical ones starting at the given fork index
+	generate := func(n int, fork int) []*types.Block {
+		_, blocks, _ := GenerateChainWithGenesis(gspec, engine, n, func(i int, gen *BlockGen) {
+			if i >= fork {
+				gen.SetCoinbase(common.Address{0x01})
+			}
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), proxy, new(big.Int), 100000, gen.BaseFee(), nil), signer, key)
+			gen.AddTx(tx)
+		})
+		return blocks
+	}
+	blocks, side := generate(int(head), int(head)), generate(3, 2)[2]
+
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.AddressHistory = new(uint64)
+
+	chain, err := NewBlockChain(db, cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+
+	// Execute a side block next to the canonical block 3
+	if _, err := chain.InsertChain(blocks[:3]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chain.InsertBlockWithoutSetHead(side, false); err != nil {
+		t.Fatal(err)
+	}
+	if rawdb.ReadCallParticipants(db, side.Hash(), 3) == nil {
+		t.Fatal("missing participants of the executed side block")
+	}
+	if _, err := chain.InsertChain(blocks[3:]); err != nil {
+		t.Fatal(err)
+	}
+	waitAddressIndex(t, chain, 0, head)
+	entries, _, err := chain.AddressTransactions(callee, 0, 0, head, int(head)+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != int(head) {
+		t.Fatalf("internal call count mismatch, have %d, want %d", len(entries), head)
+	}
+
+	// The participants of the canonical blocks are moved to the index, those of
+	// the blocks too far below the head are dropped
+	for i := 0; ; i++ {
+		if rawdb.ReadCallParticipants(db, side.Hash(), 3) == nil && rawdb.ReadCallParticipants(db, blocks[2].Hash(), 3) == nil {
+			break
+		}
+		if i == 500 {
+			t.Fatal("participants of blocks below the retention not dropped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, block := range blocks {
+		if rawdb.ReadIndexedCallParticipants(db, block.NumberU64()) == nil {
+			t.Fatalf("missing indexed participants of block %d", block.NumberU64())
+		}
+	}
+	if rawdb.ReadCallParticipants(db, blocks[head-1].Hash(), head) == nil {
+		t.Fatal("missing participants of the head block")
+	}
+}
@@ -161,6 +161,15 @@ type CacheConfig struct {
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 
+	// AddressHistory is the number of blocks from head whose transactions are
+	// indexed by the addresses taking part in them. Nil disables the index,
+	// zero means the entire chain.
+	AddressHistory *uint64
+
+	// RevertData stores the data the transactions of the processed blocks
+	// reverted with, to serve the revert reasons of their receipts.
+	RevertData bool
+
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 
@@ -243,6 +252,7 @@ type BlockChain struct {
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	addrIndexer   *addrIndexer                     // Address transaction indexer, might be nil if not enabled
 
 	hc               *HeaderChain
 	rmLogsFeed       event.Feed
@@ -503,6 +513,10 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	if txLookupLimit != nil {
 		bc.txIndexer = newTxIndexer(*txLookupLimit, bc)
 	}
+	// Start address indexer if it's enabled.
+	if cacheConfig.AddressHistory != nil {
+		bc.addrIndexer = newAddrIndexer(*cacheConfig.AddressHistory, bc)
+	}
 	return bc, nil
 }
 
@@ -1193,6 +1207,10 @@ func (bc *BlockChain) stopWithoutSaving() {
 	if bc.txIndexer != nil {
 		bc.txIndexer.close()
 	}
+	// Signal shutdown address indexer.
+	if bc.addrIndexer != nil {
+		bc.addrIndexer.close()
+	}
 	// Unsubscribe all subscriptions registered from blockchain.
 	bc.scope.Close()
 
@@ -1514,8 +1532,8 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 }
 
 // writeBlockWithState writes block, metadata and corresponding state data to the
-// database.
-func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
+// database, along with the records collected while executing the block, if any.
+func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, records *blockRecords, statedb *state.StateDB) error {
 	if !bc.HasHeader(block.ParentHash(), block.NumberU64()-1) {
 		return consensus.ErrUnknownAncestor
 	}
@@ -1527,6 +1545,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	rawdb.WritePreimages(blockBatch, statedb.Preimages())
+	if records != nil {
+		records.write(blockBatch, block)
+	}
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -1597,8 +1618,8 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 // writeBlockAndSetHead is the internal implementation of WriteBlockAndSetHead.
 // This function expects the chain mutex to be held.
-func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
-	if err := bc.writeBlockWithState(block, receipts, state); err != nil {
+func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, records *blockRecords, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
+	if err := bc.writeBlockWithState(block, receipts, records, state); err != nil {
 		return NonStatTy, err
 	}
 	currentBlock := bc.CurrentBlock()
@@ -1965,9 +1986,23 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 		}()
 	}
 
+	// Collect the participants of the internal calls for the address index and
+	// the revert data of the transactions
+	var (
+		vmConfig = bc.vmConfig
+		records  = new(blockRecords)
+	)
+	if bc.addrIndexer != nil && len(block.Transactions()) > 0 {
+		records.participants = new(callParticipants)
+		vmConfig.Tracer = records.participants.hooks(vmConfig.Tracer)
+	}
+	if bc.cacheConfig.RevertData && len(block.Transactions()) > 0 {
+		records.reverts = new(revertRecorder)
+		vmConfig.Tracer = records.reverts.hooks(vmConfig.Tracer)
+	}
 	// Process block using the parent state as reference point
 	pstart := time.Now()
-	res, err := bc.processor.Process(block, statedb, bc.vmConfig)
+	res, err := bc.processor.Process(block, statedb, vmConfig)
 	if err != nil {
 		bc.reportBlock(block, res, err)
 		return nil, err
@@ -2037,9 +2072,9 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	)
 	if !setHead {
 		// Don't set the head, only insert the block
-		err = bc.writeBlockWithState(block, res.Receipts, statedb)
+		err = bc.writeBlockWithState(block, res.Receipts, records, statedb)
 	} else {
-		status, err = bc.writeBlockAndSetHead(block, res.Receipts, res.Logs, statedb, false)
+		status, err = bc.writeBlockAndSetHead(block, res.Receipts, records, res.Logs, statedb, false)
 	}
 	if err != nil {
 		return nil, err
@@ -409,6 +409,47 @@ func (bc *BlockChain) TxIndexProgress() (TxIndexProgress, error) {
 	return bc.txIndexer.txIndexProgress(), nil
 }
 
+// AddressIndexRange returns the range of blocks whose transactions are indexed
+// by the addresses taking part in them. False is returned if nothing has been
+// indexed yet.
+func (bc *BlockChain) AddressIndexRange() (uint64, uint64, bool, error) {
+	if bc.addrIndexer == nil {
+		return 0, 0, false, errors.New("address indexer is not enabled")
+	}
+	tail := rawdb.ReadAddressIndexTail(bc.db)
+	head, _, ok := rawdb.ReadAddressIndexHead(bc.db)
+	if tail == nil || !ok || head < *tail {
+		return 0, 0, false, nil
+	}
+	return *tail, head, true, nil
+}
+
+// AddressTransactions scans at most limit index entries of the transactions the
+// address took part in, in chain order, starting at transaction index in block
+// number and ending with block to. The canonical transactions among them are
+// returned, along with the entry the next scan resumes at, nil if the scan
+// reached the end of the range.
+func (bc *BlockChain) AddressTransactions(address common.Address, number uint64, index uint32, to uint64, limit int) ([]rawdb.AddressIndexEntry, *rawdb.AddressIndexEntry, error) {
+	if bc.addrIndexer == nil {
+		return nil, nil, errors.New("address indexer is not enabled")
+	}
+	scanned := rawdb.ReadAddressIndexEntries(bc.db, address, number, index, to, limit+1)
+
+	var next *rawdb.AddressIndexEntry
+	if len(scanned) > limit {
+		next, scanned = &scanned[limit], scanned[:limit]
+	}
+	// Entries of blocks reorged out of the chain may linger until the
+	// indexer catches up, skip them.
+	var entries []rawdb.AddressIndexEntry
+	for _, entry := range scanned {
+		if bc.GetCanonicalHash(entry.BlockNumber) == entry.BlockHash {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, next, nil
+}
+
 // HistoryPruningCutoff returns the configured history pruning point.
 // Blocks before this might not be available in the database.
 func (bc *BlockChain) HistoryPruningCutoff() (uint64, common.Hash) {
@@ -628,6 +628,39 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// ReadRevertData retrieves the data the transactions of a block reverted with,
+// empty for those which didn't, if it was recorded when the block was processed.
+func ReadRevertData(db ethdb.KeyValueReader, hash common.Hash, number uint64) [][]byte {
+	data, _ := db.Get(revertDataKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var reverts [][]byte
+	if err := rlp.DecodeBytes(data, &reverts); err != nil {
+		log.Error("Invalid revert data RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return reverts
+}
+
+// WriteRevertData stores the data the transactions of a block reverted with.
+func WriteRevertData(db ethdb.KeyValueWriter, hash common.Hash, number uint64, reverts [][]byte) {
+	data, err := rlp.EncodeToBytes(reverts)
+	if err != nil {
+		log.Crit("Failed to RLP encode revert data", "err", err)
+	}
+	if err := db.Put(revertDataKey(number, hash), data); err != nil {
+		log.Crit("Failed to store revert data", "err", err)
+	}
+}
+
+// DeleteRevertData removes the revert data of the transactions of a block.
+func DeleteRevertData(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(revertDataKey(number, hash)); err != nil {
+		log.Crit("Failed to delete revert data", "err", err)
+	}
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 // Re-definition in core/types/receipt.go.
 // TODO: Re-use the existing definition.
@@ -808,12 +841,14 @@ func WriteAncientHeaderChain(db ethdb.AncientWriter, headers []*types.Header) (i
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteRevertData(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 }
 
 // DeleteBlockWithoutNumber removes all block data associated with a hash, except
-// the hash to number mapping.
+// the hash to number mapping. The revert data is retained, as it is not moved to
+// the ancient store along with the block.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
@@ -917,6 +952,133 @@ func DeleteBadBlocks(db ethdb.KeyValueWriter) {
 	}
 }
 
+const badPayloadToKeep = 32
+
+// BadPayload is a block rejected by the engine API, stored together with the
+// reason of the rejection so it can be inspected after the fact.
+type BadPayload struct {
+	Header *types.Header
+	Body   *types.Body
+	Reason string // Error returned when the payload was rejected
+	Time   uint64 // Unix timestamp of the rejection
+}
+
+// Block reassembles the rejected block from the stored header and body.
+func (p *BadPayload) Block() *types.Block {
+	block := types.NewBlockWithHeader(p.Header)
+	if p.Body != nil {
+		block = block.WithBody(*p.Body)
+	}
+	return block
+}
+
+// iterateBadPayloads calls fn with the database key of every persisted bad
+// payload, in increasing order of rejection time, until it returns false.
+func iterateBadPayloads(db ethdb.Iteratee, fn func(key []byte, hash common.Hash, it ethdb.Iterator) bool) {
+	it := db.NewIterator(badPayloadPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(badPayloadPrefix)+8+common.HashLength {
+			continue
+		}
+		if !fn(key, common.BytesToHash(key[len(badPayloadPrefix)+8:]), it) {
+			return
+		}
+	}
+}
+
+// decodeBadPayload decodes the bad payload stored in the given blob.
+func decodeBadPayload(blob []byte) *BadPayload {
+	payload := new(BadPayload)
+	if err := rlp.DecodeBytes(blob, payload); err != nil {
+		log.Error("Failed to decode bad payload", "err", err)
+		return nil
+	}
+	return payload
+}
+
+// ReadBadPayload retrieves the bad payload with the corresponding block hash.
+func ReadBadPayload(db ethdb.Iteratee, hash common.Hash) *BadPayload {
+	var payload *BadPayload
+	iterateBadPayloads(db, func(key []byte, have common.Hash, it ethdb.Iterator) bool {
+		if have != hash {
+			return true
+		}
+		payload = decodeBadPayload(it.Value())
+		return false
+	})
+	return payload
+}
+
+// ReadAllBadPayloads retrieves all the bad payloads in the database.
+// All returned payloads are sorted in reverse order of rejection time.
+func ReadAllBadPayloads(db ethdb.Iteratee) []*BadPayload {
+	var payloads []*BadPayload
+	iterateBadPayloads(db, func(key []byte, hash common.Hash, it ethdb.Iterator) bool {
+		if payload := decodeBadPayload(it.Value()); payload != nil {
+			payloads = append(payloads, payload)
+		}
+		return true
+	})
+	slices.Reverse(payloads)
+	return payloads
+}
+
+// WriteBadPayload serializes a payload rejected by the engine API into the
+// database along with the rejection reason. If the cumulated bad payloads
+// exceed the limitation, the oldest rejections will be dropped. Rejecting an
+// already stored payload again only refreshes its reason and timestamp.
+//
+// Every payload is stored under its own key, failures are only logged as the
+// payloads are kept for inspection only.
+func WriteBadPayload(db ethdb.KeyValueStore, block *types.Block, reason error, timestamp uint64) {
+	var (
+		batch = db.NewBatch()
+		keys  [][]byte
+	)
+	iterateBadPayloads(db, func(key []byte, hash common.Hash, it ethdb.Iterator) bool {
+		if hash == block.Hash() {
+			batch.Delete(common.CopyBytes(key))
+		} else {
+			keys = append(keys, common.CopyBytes(key))
+		}
+		return true
+	})
+	// Drop the oldest rejections, making room for the new one
+	for len(keys) >= badPayloadToKeep {
+		batch.Delete(keys[0])
+		keys = keys[1:]
+	}
+	data, err := rlp.EncodeToBytes(&BadPayload{
+		Header: block.Header(),
+		Body:   block.Body(),
+		Reason: reason.Error(),
+		Time:   timestamp,
+	})
+	if err != nil {
+		log.Warn("Failed to encode bad payload", "hash", block.Hash(), "err", err)
+		return
+	}
+	batch.Put(badPayloadKey(timestamp, block.Hash()), data)
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to write bad payload", "hash", block.Hash(), "err", err)
+	}
+}
+
+// DeleteBadPayloads deletes all the bad payloads from the database.
+func DeleteBadPayloads(db ethdb.KeyValueStore) {
+	batch := db.NewBatch()
+	iterateBadPayloads(db, func(key []byte, hash common.Hash, it ethdb.Iterator) bool {
+		batch.Delete(common.CopyBytes(key))
+		return true
+	})
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete bad payloads", "err", err)
+	}
+}
+
 // FindCommonAncestor returns the last common ancestor of two block headers
 func FindCommonAncestor(db ethdb.Reader, a, b *types.Header) *types.Header {
 	for bn := b.Number.Uint64(); a.Number.Uint64() > bn; {
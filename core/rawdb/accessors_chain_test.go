@@ -19,6 +19,7 @@ package rawdb
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -260,6 +261,70 @@ func TestBadBlockStorage(t *testing.T) {
 	}
 }
 
+// Tests that rejected payloads are stored with their reason and bounded in count.
+func TestBadPayloadStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	newBlock := func(n int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{
+			Number:      big.NewInt(n),
+			ParentHash:  common.Hash{byte(n)},
+			Extra:       []byte("bad payload"),
+			UncleHash:   types.EmptyUncleHash,
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+		})
+	}
+	block := newBlock(1)
+	if entry := ReadBadPayload(db, block.Hash()); entry != nil {
+		t.Fatalf("Non existent payload returned: %v", entry)
+	}
+	// Write and verify the payload in the database
+	WriteBadPayload(db, block, errors.New("invalid state root"), 100)
+	entry := ReadBadPayload(db, block.Hash())
+	if entry == nil {
+		t.Fatalf("Stored payload not found")
+	}
+	if entry.Block().Hash() != block.Hash() {
+		t.Fatalf("Retrieved payload mismatch: have %v, want %v", entry.Block().Hash(), block.Hash())
+	}
+	if entry.Reason != "invalid state root" || entry.Time != 100 {
+		t.Fatalf("Retrieved payload metadata mismatch: reason %q, time %d", entry.Reason, entry.Time)
+	}
+	if entry.Header.ParentHash != block.ParentHash() {
+		t.Fatalf("Retrieved payload parent mismatch: have %v, want %v", entry.Header.ParentHash, block.ParentHash())
+	}
+	// Rejecting the same payload again should refresh rather than duplicate it
+	WriteBadPayload(db, block, errors.New("gas used mismatch"), 200)
+	if payloads := ReadAllBadPayloads(db); len(payloads) != 1 {
+		t.Fatalf("Duplicate payload stored, have %d entries", len(payloads))
+	} else if payloads[0].Reason != "gas used mismatch" || payloads[0].Time != 200 {
+		t.Fatalf("Duplicate payload not refreshed: reason %q, time %d", payloads[0].Reason, payloads[0].Time)
+	}
+	// Write a bunch of bad payloads, they should be sorted by rejection time
+	// and the oldest ones should be truncated.
+	for _, n := range rand.Perm(100) {
+		WriteBadPayload(db, newBlock(int64(n)+2), errors.New("bad"), uint64(n)+1000)
+	}
+	payloads := ReadAllBadPayloads(db)
+	if len(payloads) != badPayloadToKeep {
+		t.Fatalf("The number of persisted bad payloads is incorrect %d", len(payloads))
+	}
+	for i := 0; i < len(payloads)-1; i++ {
+		if payloads[i].Time < payloads[i+1].Time {
+			t.Fatalf("The bad payloads are not sorted #[%d](%d) < #[%d](%d)", i, payloads[i].Time, i+1, payloads[i+1].Time)
+		}
+	}
+	if payloads[0].Time != 1099 {
+		t.Fatalf("Newest bad payload dropped, have time %d", payloads[0].Time)
+	}
+	// Delete all bad payloads
+	DeleteBadPayloads(db)
+	if payloads := ReadAllBadPayloads(db); len(payloads) != 0 {
+		t.Fatalf("Failed to delete bad payloads")
+	}
+}
+
 // Tests that canonical numbers can be mapped to hashes and retrieved.
 func TestCanonicalMappingStorage(t *testing.T) {
 	db := NewMemoryDatabase()
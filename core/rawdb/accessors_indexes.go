@@ -499,3 +499,184 @@ func DeleteBloomBitsDb(db ethdb.KeyValueStore, hashScheme bool, stopCallback fun
 	}
 	return deletePrefixRange(db, bloomBitsMetaPrefix, hashScheme, stopCallback)
 }
+
+// AddressIndexEntry references a transaction an address took part in.
+type AddressIndexEntry struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxIndex     uint32
+	TxHash      common.Hash
+}
+
+// ReadAddressIndexTail retrieves the number of the oldest block whose transactions
+// are indexed by address.
+func ReadAddressIndexTail(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(addressIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteAddressIndexTail stores the number of the oldest block whose transactions
+// are indexed by address.
+func WriteAddressIndexTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(addressIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the address index tail", "err", err)
+	}
+}
+
+// ReadAddressIndexHead retrieves the number and hash of the latest block whose
+// transactions are indexed by address.
+func ReadAddressIndexHead(db ethdb.KeyValueReader) (uint64, common.Hash, bool) {
+	data, _ := db.Get(addressIndexHeadKey)
+	if len(data) != 8+common.HashLength {
+		return 0, common.Hash{}, false
+	}
+	return binary.BigEndian.Uint64(data), common.BytesToHash(data[8:]), true
+}
+
+// WriteAddressIndexHead stores the number and hash of the latest block whose
+// transactions are indexed by address.
+func WriteAddressIndexHead(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Put(addressIndexHeadKey, append(encodeBlockNumber(number), hash.Bytes()...)); err != nil {
+		log.Crit("Failed to store the address index head", "err", err)
+	}
+}
+
+// WriteAddressIndexEntry stores a reference to a transaction the address took
+// part in.
+func WriteAddressIndexEntry(db ethdb.KeyValueWriter, address common.Address, entry AddressIndexEntry) {
+	value := append(entry.BlockHash.Bytes(), entry.TxHash.Bytes()...)
+	if err := db.Put(addressIndexKey(address, entry.BlockNumber, entry.TxIndex), value); err != nil {
+		log.Crit("Failed to store address index entry", "err", err)
+	}
+}
+
+// DeleteAddressIndexEntry removes a reference to a transaction the address took
+// part in.
+func DeleteAddressIndexEntry(db ethdb.KeyValueWriter, address common.Address, number uint64, index uint32) {
+	if err := db.Delete(addressIndexKey(address, number, index)); err != nil {
+		log.Crit("Failed to delete address index entry", "err", err)
+	}
+}
+
+// ReadAddressIndexEntries retrieves at most limit references to the transactions
+// the address took part in, in chain order, starting at transaction index in block
+// number and ending with block to.
+func ReadAddressIndexEntries(db ethdb.Iteratee, address common.Address, number uint64, index uint32, to uint64, limit int) []AddressIndexEntry {
+	prefix := append(bytes.Clone(addressIndexPrefix), address.Bytes()...)
+	start := addressIndexKey(address, number, index)[len(prefix):]
+
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []AddressIndexEntry
+	for len(entries) < limit && it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(key) != len(prefix)+12 || len(value) != 2*common.HashLength {
+			continue
+		}
+		entry := AddressIndexEntry{
+			BlockNumber: binary.BigEndian.Uint64(key[len(prefix):]),
+			BlockHash:   common.BytesToHash(value[:common.HashLength]),
+			TxIndex:     binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			TxHash:      common.BytesToHash(value[common.HashLength:]),
+		}
+		if entry.BlockNumber > to {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// DeleteAddressIndexDb removes the entire address index, including its markers
+// and the call participants of the indexed blocks.
+func DeleteAddressIndexDb(db ethdb.KeyValueStore, hashScheme bool, stopCallback func(bool) bool) error {
+	if err := deletePrefixRange(db, addressIndexPrefix, hashScheme, stopCallback); err != nil {
+		return err
+	}
+	if err := deletePrefixRange(db, indexedParticipantsPrefix, hashScheme, stopCallback); err != nil {
+		return err
+	}
+	if err := db.Delete(addressIndexTailKey); err != nil {
+		return err
+	}
+	return db.Delete(addressIndexHeadKey)
+}
+
+// CallParticipants lists the addresses taking part in the internal calls of a
+// transaction.
+type CallParticipants struct {
+	TxHash    common.Hash
+	Addresses []common.Address
+}
+
+// readCallParticipants retrieves and decodes the call participants stored under
+// the given key.
+func readCallParticipants(db ethdb.KeyValueReader, key []byte) []CallParticipants {
+	data, _ := db.Get(key)
+	if len(data) == 0 {
+		return nil
+	}
+	var participants []CallParticipants
+	if err := rlp.DecodeBytes(data, &participants); err != nil {
+		log.Error("Invalid call participants RLP", "key", common.Bytes2Hex(key), "err", err)
+		return nil
+	}
+	return participants
+}
+
+// writeCallParticipants encodes and stores call participants under the given key.
+func writeCallParticipants(db ethdb.KeyValueWriter, key []byte, participants []CallParticipants) {
+	data, err := rlp.EncodeToBytes(participants)
+	if err != nil {
+		log.Crit("Failed to RLP encode call participants", "err", err)
+	}
+	if err := db.Put(key, data); err != nil {
+		log.Crit("Failed to store call participants", "err", err)
+	}
+}
+
+// ReadCallParticipants retrieves the participants of the internal calls of the
+// transactions of a block, if they were recorded when the block was executed
+// and the block was not indexed yet.
+func ReadCallParticipants(db ethdb.KeyValueReader, hash common.Hash, number uint64) []CallParticipants {
+	return readCallParticipants(db, callParticipantsKey(number, hash))
+}
+
+// WriteCallParticipants stores the participants of the internal calls of the
+// transactions of an executed block, until the block is indexed.
+func WriteCallParticipants(db ethdb.KeyValueWriter, hash common.Hash, number uint64, participants []CallParticipants) {
+	writeCallParticipants(db, callParticipantsKey(number, hash), participants)
+}
+
+// DeleteCallParticipantsBelow removes the call participants recorded for all
+// executed blocks below the given number.
+func DeleteCallParticipantsBelow(db ethdb.KeyValueStore, number uint64, hashScheme bool, stopCallback func(bool) bool) error {
+	end := append(bytes.Clone(callParticipantsPrefix), encodeBlockNumber(number)...)
+	return SafeDeleteRange(db, callParticipantsPrefix, end, hashScheme, stopCallback)
+}
+
+// ReadIndexedCallParticipants retrieves the participants of the internal calls
+// of the canonical block with the given number, which were added to the address
+// index.
+func ReadIndexedCallParticipants(db ethdb.KeyValueReader, number uint64) []CallParticipants {
+	return readCallParticipants(db, indexedParticipantsKey(number))
+}
+
+// WriteIndexedCallParticipants stores the participants of the internal calls
+// added to the address index for the canonical block with the given number.
+func WriteIndexedCallParticipants(db ethdb.KeyValueWriter, number uint64, participants []CallParticipants) {
+	writeCallParticipants(db, indexedParticipantsKey(number), participants)
+}
+
+// DeleteIndexedCallParticipants removes the participants of the internal calls
+// added to the address index for the block with the given number.
+func DeleteIndexedCallParticipants(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(indexedParticipantsKey(number)); err != nil {
+		log.Crit("Failed to delete call participants", "err", err)
+	}
+}
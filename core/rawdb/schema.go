@@ -79,6 +79,14 @@ var (
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
+	// addressIndexTailKey tracks the oldest block whose transactions have been
+	// indexed by the addresses taking part in them.
+	addressIndexTailKey = []byte("AddressIndexTail")
+
+	// addressIndexHeadKey tracks the number and hash of the latest block whose
+	// transactions have been indexed by the addresses taking part in them.
+	addressIndexHeadKey = []byte("AddressIndexHead")
+
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	// This flag is deprecated, it's kept to avoid reporting errors when inspect
 	// database.
@@ -144,6 +152,15 @@ var (
 	// old log index
 	bloomBitsMetaPrefix = []byte("iB")
 
+	// address to transaction index
+	addressIndexPrefix        = []byte("iA") // addressIndexPrefix + address + num (uint64 big endian) + tx index (uint32 big endian) -> block hash + tx hash
+	callParticipantsPrefix    = []byte("iP") // callParticipantsPrefix + num (uint64 big endian) + hash -> internal call participants of an executed block
+	indexedParticipantsPrefix = []byte("iI") // indexedParticipantsPrefix + num (uint64 big endian) -> internal call participants of the indexed block
+
+	revertDataPrefix = []byte("revert-") // revertDataPrefix + num (uint64 big endian) + hash -> revert data of the transactions of a block
+
+	badPayloadPrefix = []byte("InvalidPayload-") // badPayloadPrefix + rejection time (uint64 big endian) + hash -> payload rejected by the engine API
+
 	preimageCounter     = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitsCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 	preimageMissCounter = metrics.NewRegisteredCounter("db/preimage/miss", nil)
@@ -194,6 +211,36 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// addressIndexKey = addressIndexPrefix + address + num (uint64 big endian) + tx index (uint32 big endian)
+func addressIndexKey(address common.Address, number uint64, index uint32) []byte {
+	key := make([]byte, len(addressIndexPrefix)+common.AddressLength+12)
+	copy(key, addressIndexPrefix)
+	copy(key[len(addressIndexPrefix):], address.Bytes())
+	binary.BigEndian.PutUint64(key[len(addressIndexPrefix)+common.AddressLength:], number)
+	binary.BigEndian.PutUint32(key[len(addressIndexPrefix)+common.AddressLength+8:], index)
+	return key
+}
+
+// callParticipantsKey = callParticipantsPrefix + num (uint64 big endian) + hash
+func callParticipantsKey(number uint64, hash common.Hash) []byte {
+	return append(append(callParticipantsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// revertDataKey = revertDataPrefix + num (uint64 big endian) + hash
+func revertDataKey(number uint64, hash common.Hash) []byte {
+	return append(append(revertDataPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// indexedParticipantsKey = indexedParticipantsPrefix + num (uint64 big endian)
+func indexedParticipantsKey(number uint64) []byte {
+	return append(indexedParticipantsPrefix, encodeBlockNumber(number)...)
+}
+
+// badPayloadKey = badPayloadPrefix + rejection time (uint64 big endian) + hash
+func badPayloadKey(time uint64, hash common.Hash) []byte {
+	return append(append(badPayloadPrefix, encodeBlockNumber(time)...), hash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// blockRecords holds the data recorded while executing a block, which is stored
+// along with it.
+type blockRecords struct {
+	participants *callParticipants // Internal call participants for the address index
+	reverts      *revertRecorder   // Revert data of the transactions
+}
+
+// write stores the records of a block. The participants are stored for blocks
+// which are not canonical too, as they may become so without being executed
+// again, the address indexer drops them otherwise.
+func (r *blockRecords) write(db ethdb.KeyValueWriter, block *types.Block) {
+	if r.participants != nil {
+		rawdb.WriteCallParticipants(db, block.Hash(), block.NumberU64(), r.participants.txs)
+	}
+	if r.reverts != nil && r.reverts.reverted {
+		rawdb.WriteRevertData(db, block.Hash(), block.NumberU64(), r.reverts.reverts)
+	}
+}
+
+// revertRecorder collects the data the transactions of a block revert with, so
+// the revert reasons of their receipts can be served without re-executing the
+// block.
+type revertRecorder struct {
+	reverts  [][]byte // Revert data of each transaction, nil if it didn't revert
+	reverted bool     // Whether any transaction reverted
+	inTx     bool
+}
+
+// hooks returns the tracing hooks collecting the revert data, chained after the
+// given ones if any.
+func (r *revertRecorder) hooks(base *tracing.Hooks) *tracing.Hooks {
+	var hooks tracing.Hooks
+	if base != nil {
+		hooks = *base
+	}
+	hooks.OnTxStart = func(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+		if base != nil && base.OnTxStart != nil {
+			base.OnTxStart(env, tx, from)
+		}
+		r.reverts = append(r.reverts, nil)
+		r.inTx = true
+	}
+	hooks.OnExit = func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+		if base != nil && base.OnExit != nil {
+			base.OnExit(depth, output, gasUsed, err, reverted)
+		}
+		// Only the outcome of the top-level call of transactions is recorded,
+		// not that of system calls
+		if depth != 0 || !r.inTx || !errors.Is(err, vm.ErrExecutionReverted) {
+			return
+		}
+		r.reverts[len(r.reverts)-1] = common.CopyBytes(output)
+		r.reverted = true
+	}
+	hooks.OnTxEnd = func(receipt *types.Receipt, err error) {
+		if base != nil && base.OnTxEnd != nil {
+			base.OnTxEnd(receipt, err)
+		}
+		r.inTx = false
+	}
+	return &hooks
+}
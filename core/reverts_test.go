@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the revert data of the transactions is recorded when processing
+// blocks, along with the participants of the address index.
+func TestRevertData(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		sender   = crypto.PubkeyToAddress(key.PublicKey)
+		reverter = common.HexToAddress("0x1000")
+		signer   = types.LatestSigner(params.TestChainConfig)
+
+		// MSTORE(0, 0xdeadbeef) REVERT(28, 4)
+		code = []byte{0x63, 0xde, 0xad, 0xbe, 0xef, 0x60, 0x00, 0x52, 0x60, 0x04, 0x60, 0x1c, 0xfd}
+
+		gspec = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:   {Balance: big.NewInt(params.Ether)},
+				reverter: {Code: code},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), common.Address{0x01}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+		if i == 0 {
+			tx, _ = types.SignTx(types.NewTransaction(gen.TxNonce(sender), reverter, new(big.Int), 100000, gen.BaseFee(), nil), signer, key)
+			gen.AddTx(tx)
+		}
+	})
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.AddressHistory = new(uint64)
+	cacheConfig.RevertData = true
+
+	chain, err := NewBlockChain(db, cacheConfig, gspec, nil, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	reverts := rawdb.ReadRevertData(db, blocks[0].Hash(), 1)
+	if len(reverts) != 2 || len(reverts[0]) != 0 || !bytes.Equal(reverts[1], []byte{0xde, 0xad, 0xbe, 0xef}) {
+		t.Fatalf("unexpected revert data %x", reverts)
+	}
+	if reverts := rawdb.ReadRevertData(db, blocks[1].Hash(), 2); reverts != nil {
+		t.Fatalf("unexpected revert data for block without reverts: %x", reverts)
+	}
+	waitAddressIndex(t, chain, 0, 2)
+	verifyAddressTransactions(t, chain, reverter, []uint64{1})
+}
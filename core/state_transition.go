@@ -320,6 +320,10 @@ func (st *stateTransition) to() common.Address {
 	return *st.msg.To
 }
 
+// RollupSignatureOnes is the number of non-zero bytes accounted for a signature
+// when the rollup cost of a transaction is estimated before it is signed.
+const RollupSignatureOnes = 80
+
 // CalculateRollupCostDataFromMessage calculate RollupCostData from message.
 func (st *stateTransition) CalculateRollupCostDataFromMessage() {
 	tx := types.NewTx(&types.DynamicFeeTx{
@@ -335,11 +339,11 @@ func (st *stateTransition) CalculateRollupCostDataFromMessage() {
 
 	// add a constant to cover sigs(V,R,S) and other data to make sure that the gasLimit from eth_estimateGas can cover L1 cost
 	// just used for estimateGas and the actual L1 cost depends on users' tx when executing
-	st.msg.RollupCostData.Ones += 80
+	st.msg.RollupCostData.Ones += RollupSignatureOnes
 
 	// add a constant to cover meta tx sigs(V,R,S)
 	if st.msg.MetaTxParams != nil {
-		st.msg.RollupCostData.Ones += 80
+		st.msg.RollupCostData.Ones += RollupSignatureOnes
 	}
 }
 
@@ -0,0 +1,141 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AdmissionRule identifies the pool admission rule a transaction violated.
+type AdmissionRule string
+
+const (
+	RuleTxType             AdmissionRule = "tx-type"              // Transaction type not accepted by the pool or fork
+	RuleMetaTx             AdmissionRule = "meta-tx"              // Deprecated Mantle meta transaction payload
+	RuleSize               AdmissionRule = "size"                 // Encoded transaction size above the pool limit
+	RuleInitCodeSize       AdmissionRule = "init-code-size"       // Contract creation code above the EIP-3860 limit
+	RuleNegativeValue      AdmissionRule = "negative-value"       // Negative value transferred
+	RuleGasLimit           AdmissionRule = "gas-limit"            // Gas limit above the block gas limit
+	RuleFeeOverflow        AdmissionRule = "fee-overflow"         // Fee cap or tip cap above 256 bits
+	RuleTipAboveFeeCap     AdmissionRule = "tip-above-fee-cap"    // Tip cap above the fee cap
+	RuleSender             AdmissionRule = "sender"               // Invalid signature
+	RuleMinTip             AdmissionRule = "min-tip"              // Tip cap below the pool minimum
+	RuleBlobFee            AdmissionRule = "blob-fee"             // Blob fee cap below the protocol minimum
+	RuleBlobSidecar        AdmissionRule = "blob-sidecar"         // Missing or invalid blob sidecar
+	RuleSetCodeAuth        AdmissionRule = "set-code-auth"        // Set code transaction without authorizations
+	RuleNonce              AdmissionRule = "nonce"                // Nonce below the account nonce
+	RuleNonceGap           AdmissionRule = "nonce-gap"            // Nonce gap in a pool not permitting gaps
+	RuleBalance            AdmissionRule = "balance"              // Balance not covering the transaction cost
+	RuleBaseFee            AdmissionRule = "base-fee"             // Fee cap below the current base fee
+	RuleIntrinsicGas       AdmissionRule = "intrinsic-gas"        // Gas limit below the intrinsic gas
+	RuleFloorDataGas       AdmissionRule = "floor-data-gas"       // Gas limit below the EIP-7623 floor data gas
+	RuleL1Cost             AdmissionRule = "l1-cost"              // Execution gas not covering the L1 data fee
+	RuleAccountSlots       AdmissionRule = "account-slots"        // Too many transactions pooled from the account
+	RuleDelegation         AdmissionRule = "delegation"           // In-flight limits of delegated accounts
+	RuleKnown              AdmissionRule = "known"                // Transaction already pooled
+	RuleSenderRateLimit    AdmissionRule = "sender-rate-limit"    // Sender submitting transactions too fast
+	RuleRecipientRateLimit AdmissionRule = "recipient-rate-limit" // Recipient receiving transactions too fast
+	RulePolicy             AdmissionRule = "policy"               // Rejected by the installed policy filter
+	RuleOther              AdmissionRule = "other"                // Rejection not attributed to a specific rule
+)
+
+// AdmissionError is returned by the transaction validation methods when a
+// transaction violates one of the pool admission rules. Beside the original
+// error, it carries the rule id and, where meaningful, the observed value of
+// the transaction and the value the rule requires.
+type AdmissionError struct {
+	Rule     AdmissionRule
+	Observed *big.Int // Value observed on the transaction or its sender, nil if not applicable
+	Required *big.Int // Threshold enforced by the rule, nil if not applicable
+
+	err error
+}
+
+// NewAdmissionError wraps err into an admission error attributed to rule.
+func NewAdmissionError(rule AdmissionRule, observed, required *big.Int, err error) error {
+	return &AdmissionError{Rule: rule, Observed: observed, Required: required, err: err}
+}
+
+// Error implements error, returning the message of the wrapped error.
+func (e *AdmissionError) Error() string { return e.err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *AdmissionError) Unwrap() error { return e.err }
+
+// AdmissionResult is the explanation of a pool admission decision.
+type AdmissionResult struct {
+	Accepted bool          `json:"accepted"`
+	Rule     AdmissionRule `json:"rule,omitempty"`
+	Observed *hexutil.Big  `json:"observed,omitempty"`
+	Required *hexutil.Big  `json:"required,omitempty"`
+	Reason   string        `json:"reason,omitempty"`
+}
+
+// ExplainAdmission converts the outcome of a pool admission check into an
+// admission result. Errors which are not admission errors are reported under
+// the RuleOther rule.
+func ExplainAdmission(err error) *AdmissionResult {
+	if err == nil {
+		return &AdmissionResult{Accepted: true}
+	}
+	res := &AdmissionResult{Rule: RuleOther, Reason: err.Error()}
+
+	var aerr *AdmissionError
+	if errors.As(err, &aerr) {
+		res.Rule = aerr.Rule
+		res.Observed = (*hexutil.Big)(aerr.Observed)
+		res.Required = (*hexutil.Big)(aerr.Required)
+	}
+	return res
+}
+
+// explainer is implemented by subpools which are able to dry-run their full
+// admission checks against a transaction.
+type explainer interface {
+	Explain(tx *types.Transaction) error
+}
+
+// Explain dry-runs the admission checks of the subpool which would accept the
+// transaction, without adding it to the pool. Subpools not supporting a full
+// dry-run only have their stateless checks explained.
+func (p *TxPool) Explain(tx *types.Transaction) *AdmissionResult {
+	if err := p.checkTxFilter(tx); err != nil {
+		return ExplainAdmission(NewAdmissionError(RulePolicy, nil, nil, err))
+	}
+	for _, subpool := range p.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+		if pool, ok := subpool.(explainer); ok {
+			return ExplainAdmission(pool.Explain(tx))
+		}
+		return ExplainAdmission(subpool.ValidateTxBasics(tx))
+	}
+	err := fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, tx.Type())
+	return ExplainAdmission(NewAdmissionError(RuleTxType, nil, nil, err))
+}
+
+// newUint64 is a shorthand for new(big.Int).SetUint64(v).
+func newUint64(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
@@ -1782,6 +1782,32 @@ func (p *BlobPool) Content() (map[common.Address][]*types.Transaction, map[commo
 	return make(map[common.Address][]*types.Transaction), make(map[common.Address][]*types.Transaction)
 }
 
+// Export retrieves all the transactions tracked by the pool, including their
+// blob sidecars. The blob pool does not track arrival times, nor does it support
+// preconfs, so only the transactions themselves are filled in.
+func (p *BlobPool) Export() []*txpool.ExportedTx {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var txs []*txpool.ExportedTx
+	for _, metas := range p.index {
+		for _, meta := range metas {
+			data, err := p.store.Get(meta.id)
+			if err != nil {
+				log.Error("Tracked blob transaction missing from store", "hash", meta.hash, "id", meta.id, "err", err)
+				continue
+			}
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(data, tx); err != nil {
+				log.Error("Blobs corrupted for tracked transaction", "hash", meta.hash, "id", meta.id, "err", err)
+				continue
+			}
+			txs = append(txs, &txpool.ExportedTx{Tx: tx, Pending: true})
+		}
+	}
+	return txs
+}
+
 // ContentFrom retrieves the data content of the transaction pool, returning the
 // pending as well as queued transactions of this address, grouped by nonce.
 //
@@ -70,4 +70,12 @@ var (
 
 	// ErrPreconfInProcess is returned if a transaction is in process as an preconf transaction
 	ErrPreconfInProcess = errors.New("exist preconf transaction in process")
+
+	// ErrRateLimited is returned if a transaction is rejected because its sender
+	// or recipient exceeded the admission rate configured for the pool.
+	ErrRateLimited = errors.New("transaction rate limit exceeded")
+
+	// ErrTxFiltered is returned if a transaction is rejected by the policy
+	// filter installed into the pool.
+	ErrTxFiltered = errors.New("transaction rejected by policy")
 )
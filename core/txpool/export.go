@@ -0,0 +1,120 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ExportedTx is a transaction tracked by a subpool, bundled with the metadata
+// needed to restore it into another pool later on.
+type ExportedTx struct {
+	Tx      *types.Transaction // Transaction, including the blob sidecar if any
+	Pending bool               // Whether the transaction was executable at export time
+	Time    uint64             // Unix time in nanoseconds the transaction was first seen, 0 if unknown
+	Preconf core.PreconfStatus // Preconfirmation status, empty for non-preconf transactions
+}
+
+// exporter is implemented by subpools which support dumping their contents.
+type exporter interface {
+	Export() []*ExportedTx
+}
+
+// importer is implemented by subpools which admit transactions restored from
+// an export differently from newly submitted ones, restoring the preconf
+// statuses of the transactions.
+type importer interface {
+	Import(txs []*types.Transaction, preconfs map[common.Hash]core.PreconfStatus) []error
+}
+
+// Export writes all the transactions tracked by the subpools into w as a stream
+// of RLP encoded ExportedTx entries and returns the number of transactions
+// written. Transactions of the same sender are written in nonce order.
+func (p *TxPool) Export(w io.Writer) (int, error) {
+	var exported int
+	for _, subpool := range p.subpools {
+		pool, ok := subpool.(exporter)
+		if !ok {
+			continue
+		}
+		for _, tx := range pool.Export() {
+			if err := rlp.Encode(w, tx); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+	}
+	return exported, nil
+}
+
+// Import reads a stream of RLP encoded ExportedTx entries from r and injects the
+// transactions into the pool, restoring their original arrival times and
+// concluded preconf statuses. Preconf transactions still awaiting their
+// preconfirmation go through it again, same as on first arrival.
+//
+// The number of transactions read and the number of transactions rejected by
+// the pool are returned.
+func (p *TxPool) Import(r io.Reader) (int, int, error) {
+	var (
+		stream = rlp.NewStream(r, 0)
+		batch  = make([]*types.Transaction, 0, 1024)
+		status = make(map[common.Hash]core.PreconfStatus)
+
+		total, dropped int
+	)
+	flush := func() {
+		for i, err := range p.add(batch, false, status) {
+			if err != nil {
+				log.Debug("Failed to import transaction", "hash", batch[i].Hash(), "err", err)
+				dropped++
+			}
+		}
+		batch = batch[:0]
+		clear(status)
+	}
+	for {
+		entry := new(ExportedTx)
+		if err := stream.Decode(entry); err != nil {
+			if len(batch) > 0 {
+				flush()
+			}
+			if errors.Is(err, io.EOF) {
+				return total, dropped, nil
+			}
+			return total, dropped, err
+		}
+		if entry.Time != 0 {
+			entry.Tx.SetTime(time.Unix(0, int64(entry.Time)))
+		}
+		if entry.Preconf != "" {
+			status[entry.Tx.Hash()] = entry.Preconf
+		}
+		total++
+
+		if batch = append(batch, entry.Tx); len(batch) == cap(batch) {
+			flush()
+		}
+	}
+}
@@ -97,6 +97,10 @@ var (
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
 
+	// Metrics for transactions rejected by the admission rate limiters
+	senderRateLimitedMeter = metrics.NewRegisteredMeter("txpool/ratelimited/sender", nil)
+	toRateLimitedMeter     = metrics.NewRegisteredMeter("txpool/ratelimited/to", nil)
+
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
 	validTxMeter       = metrics.NewRegisteredMeter("txpool/valid", nil)
@@ -158,6 +162,13 @@ type Config struct {
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 
+	SenderRateLimit float64 // Maximum sustained number of transactions per second admitted from a single sender (0 = unlimited)
+	SenderBurst     uint64  // Maximum number of transactions admitted from a single sender in a burst
+	ToRateLimit     float64 // Maximum sustained number of transactions per second admitted to a single recipient (0 = unlimited)
+	ToBurst         uint64  // Maximum number of transactions admitted to a single recipient in a burst
+
+	Denylist string // File of addresses and selectors rejected by the policy filter, reloaded on change
+
 	EffectiveGasCeil uint64 // OP-Stack: if non-zero, a gas ceiling to enforce independent of the header's gaslimit value
 }
 
@@ -214,6 +225,24 @@ func (config *Config) sanitize() Config {
 	if conf.Preconf == nil {
 		conf.Preconf = &preconf.DefaultTxPoolConfig
 	}
+	if conf.SenderRateLimit < 0 {
+		log.Warn("Sanitizing invalid txpool sender rate limit", "provided", conf.SenderRateLimit, "updated", DefaultConfig.SenderRateLimit)
+		conf.SenderRateLimit = DefaultConfig.SenderRateLimit
+	}
+	if conf.SenderRateLimit > 0 && conf.SenderBurst < 1 {
+		burst := uint64(math.Max(1, math.Ceil(conf.SenderRateLimit)))
+		log.Warn("Sanitizing invalid txpool sender burst", "provided", conf.SenderBurst, "updated", burst)
+		conf.SenderBurst = burst
+	}
+	if conf.ToRateLimit < 0 {
+		log.Warn("Sanitizing invalid txpool recipient rate limit", "provided", conf.ToRateLimit, "updated", DefaultConfig.ToRateLimit)
+		conf.ToRateLimit = DefaultConfig.ToRateLimit
+	}
+	if conf.ToRateLimit > 0 && conf.ToBurst < 1 {
+		burst := uint64(math.Max(1, math.Ceil(conf.ToRateLimit)))
+		log.Warn("Sanitizing invalid txpool recipient burst", "provided", conf.ToBurst, "updated", burst)
+		conf.ToBurst = burst
+	}
 	return conf
 }
 
@@ -270,12 +299,17 @@ type LegacyPool struct {
 
 	l1CostFn txpool.L1CostFunc // To apply L1 costs as rollup, optional field, may be nil.
 
+	senderLimiter *rateLimiter // Admission rate limiter keyed by sender, nil if disabled
+	toLimiter     *rateLimiter // Admission rate limiter keyed by recipient, nil if disabled
+
 	// Preconf variables
 	preconfReadyCh       chan struct{}
 	preconfReadyOnce     sync.Once
 	preconfTxRequestFeed event.Feed
 	preconfTxFeed        event.Feed
 	preconfTxs           *preconf.FIFOTxSet // Set of preconf transactions
+
+	importedPreconfs map[common.Hash]core.PreconfStatus // Concluded preconf statuses of imported transactions, restored when they turn pending
 }
 
 type txpoolResetRequest struct {
@@ -306,9 +340,12 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		initDoneCh:      make(chan struct{}),
 	}
 	pool.priced = newPricedList(pool.all)
+	pool.senderLimiter = newRateLimiter(config.SenderRateLimit, config.SenderBurst)
+	pool.toLimiter = newRateLimiter(config.ToRateLimit, config.ToBurst)
 	// Initialize preconfs
 	pool.preconfReadyCh = make(chan struct{})
 	pool.preconfTxs = preconf.NewFIFOTxSet()
+	pool.importedPreconfs = make(map[common.Hash]core.PreconfStatus)
 	log.Info("preconf", "txpool.config", pool.config.Preconf.String())
 
 	pool.reset(nil, chain.CurrentBlock())
@@ -529,6 +566,43 @@ func (pool *LegacyPool) ContentFrom(addr common.Address) ([]*types.Transaction,
 	return pending, queued
 }
 
+// Export retrieves all the transactions tracked by the pool along with their
+// arrival times and preconf statuses. The transactions of an account are
+// returned in nonce order, pending ones first.
+func (pool *LegacyPool) Export() []*txpool.ExportedTx {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	txs := make([]*txpool.ExportedTx, 0, pool.all.Count())
+	export := func(list *list, pending bool) {
+		for _, tx := range list.Flatten() {
+			entry := &txpool.ExportedTx{
+				Tx:      tx,
+				Pending: pending,
+			}
+			if !tx.Time().IsZero() {
+				entry.Time = uint64(tx.Time().UnixNano())
+			}
+			if status := pool.preconfTxs.GetStatus(tx.Hash()); status != nil {
+				entry.Preconf = *status
+			}
+			txs = append(txs, entry)
+		}
+	}
+	for addr, list := range pool.pending {
+		export(list, true)
+		if queued := pool.queue[addr]; queued != nil {
+			export(queued, false)
+		}
+	}
+	for addr, list := range pool.queue {
+		if _, ok := pool.pending[addr]; !ok {
+			export(list, false)
+		}
+	}
+	return txs
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -680,7 +754,7 @@ func (pool *LegacyPool) validateAuth(tx *types.Transaction) error {
 	// Allow at most one in-flight tx for delegated accounts or those with a
 	// pending authorization.
 	if err := pool.checkDelegationLimit(tx); err != nil {
-		return err
+		return txpool.NewAdmissionError(txpool.RuleDelegation, nil, nil, err)
 	}
 	// For symmetry, allow at most one in-flight tx for any authority with a
 	// pending transaction.
@@ -694,7 +768,7 @@ func (pool *LegacyPool) validateAuth(tx *types.Transaction) error {
 				count += queue.Len()
 			}
 			if count > 1 {
-				return ErrAuthorityReserved
+				return txpool.NewAdmissionError(txpool.RuleDelegation, big.NewInt(int64(count)), big.NewInt(1), ErrAuthorityReserved)
 			}
 			// Because there is no exclusive lock held between different subpools
 			// when processing transactions, the SetCode transaction may be accepted
@@ -705,17 +779,41 @@ func (pool *LegacyPool) validateAuth(tx *types.Transaction) error {
 			// that attackers cannot easily stack a SetCode transaction when the sender
 			// is reserved by other pools.
 			if pool.reserver.Has(auth) {
-				return ErrAuthorityReserved
+				return txpool.NewAdmissionError(txpool.RuleDelegation, nil, nil, ErrAuthorityReserved)
 			}
 		}
 	}
 	return nil
 }
 
+// Explain dry-runs the admission checks of the pool against a transaction
+// without adding it, returning the error the pool would reject it with, if
+// any. Replacement and pool capacity checks depend on the contents of the pool
+// at the time of insertion and are not evaluated.
+func (pool *LegacyPool) Explain(tx *types.Transaction) error {
+	if err := pool.ValidateTxBasics(tx); err != nil {
+		return err
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(tx.Hash()) != nil {
+		return txpool.NewAdmissionError(txpool.RuleKnown, nil, nil, txpool.ErrAlreadyKnown)
+	}
+	if err := pool.validateTx(tx); err != nil {
+		return err
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	return pool.checkRateLimits(from, tx, true)
+}
+
 // add validates a transaction and inserts it into the non-executable queue for later
 // pending promotion and execution. If the transaction is a replacement for an already
 // pending or queued one, it overwrites the previous transaction if its price is higher.
-func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
+//
+// If limit is set, the transaction is subject to the admission rate limits, which
+// only account it once it's inserted.
+func (pool *LegacyPool) add(tx *types.Transaction, limit bool) (replaced bool, err error) {
 	// If the transaction is already known, discard it
 	hash := tx.Hash()
 	if pool.all.Get(hash) != nil {
@@ -733,6 +831,19 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	// already validated by this point
 	from, _ := types.Sender(pool.signer, tx)
 
+	// If the sender or recipient is submitting too fast, discard it. Otherwise
+	// account the transaction against their limits once inserted.
+	if limit {
+		if err := pool.checkRateLimits(from, tx, false); err != nil {
+			log.Trace("Discarding rate limited transaction", "hash", hash, "err", err)
+			return false, err
+		}
+		defer func() {
+			if err == nil {
+				pool.takeRateLimits(from, tx)
+			}
+		}()
+	}
 	// If the address is not yet known, request exclusivity to track the account
 	// only by this subpool until all transactions are evicted
 	var (
@@ -995,6 +1106,40 @@ func (pool *LegacyPool) addRemoteSync(tx *types.Transaction) error {
 // Note, if sync is set the method will block until all internal maintenance
 // related to the add is finished. Only use this during tests for determinism.
 func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
+	return pool.addTxs(txs, sync, true)
+}
+
+// Import adds transactions restored from a snapshot of a pool. Unlike Add, the
+// transactions are not subject to the admission rate limits, as they have been
+// admitted before.
+//
+// Preconf transactions whose preconfirmation had concluded keep their status
+// instead of being preconfirmed again, the others go through preconfirmation
+// same as on first arrival.
+func (pool *LegacyPool) Import(txs []*types.Transaction, preconfs map[common.Hash]core.PreconfStatus) []error {
+	pool.mu.Lock()
+	for _, tx := range txs {
+		if status := preconfs[tx.Hash()]; status == core.PreconfStatusSuccess || status == core.PreconfStatusFailed {
+			pool.importedPreconfs[tx.Hash()] = status
+		}
+	}
+	pool.mu.Unlock()
+
+	errs := pool.addTxs(txs, false, false)
+
+	pool.mu.Lock()
+	for i, err := range errs {
+		if err != nil {
+			delete(pool.importedPreconfs, txs[i].Hash())
+		}
+	}
+	pool.mu.Unlock()
+	return errs
+}
+
+// addTxs adds a batch of transactions into the pool, enforcing the admission
+// rate limits if limit is set.
+func (pool *LegacyPool) addTxs(txs []*types.Transaction, sync bool, limit bool) []error {
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
 		errs = make([]error, len(txs))
@@ -1041,7 +1186,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
-	newErrs, dirtyAddrs := pool.addTxsLocked(news)
+	newErrs, dirtyAddrs := pool.addTxsLocked(news, limit)
 	pool.mu.Unlock()
 
 	var nilSlot = 0
@@ -1060,13 +1205,14 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
-// addTxsLocked attempts to queue a batch of transactions if they are valid.
+// addTxsLocked attempts to queue a batch of transactions if they are valid,
+// enforcing the admission rate limits if limit is set.
 // The transaction pool lock must be held.
-func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction) ([]error, *accountSet) {
+func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, limit bool) ([]error, *accountSet) {
 	dirty := newAccountSet(pool.signer)
 	errs := make([]error, len(txs))
 	for i, tx := range txs {
-		replaced, err := pool.add(tx)
+		replaced, err := pool.add(tx, limit)
 		errs[i] = err
 		if err == nil && !replaced {
 			dirty.addTx(tx)
@@ -1182,6 +1328,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	}
 	// Remove it from the list of known transactions
 	pool.all.Remove(hash)
+	delete(pool.importedPreconfs, hash)
 	if outofbound {
 		pool.priced.Removed(1)
 	}
@@ -1513,7 +1660,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	core.SenderCacher().Recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject)
+	pool.addTxsLocked(reinject, false)
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -143,6 +143,15 @@ func (pool *LegacyPool) handlePreconfTx(from common.Address, tx *types.Transacti
 	// add tx to preconfTxs and send preconf request event should keep same order
 	pool.preconfTxs.Add(from, tx)
 
+	// If the tx was imported from a pool snapshot after its preconfirmation had
+	// concluded, restore the status instead of executing preconfirmation again.
+	if status, ok := pool.importedPreconfs[txHash]; ok {
+		delete(pool.importedPreconfs, txHash)
+		pool.preconfTxs.SetStatus(txHash, status)
+		log.Debug("handle preconf tx from pool snapshot", "tx", txHash, "status", status)
+		return
+	}
+
 	// If preconfReadyCh is not closed, it means this is a preconf tx restored from journal after system restart.
 	// In this case, we don't need to execute preconfirmation again to avoid resource contention with worker.
 	select {
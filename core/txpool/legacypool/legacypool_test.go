@@ -549,14 +549,14 @@ func TestChainFork(t *testing.T) {
 	resetState()
 
 	tx := transaction(0, 100000, key)
-	if _, err := pool.add(tx); err != nil {
+	if _, err := pool.add(tx, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	pool.removeTx(tx.Hash(), true, true)
 
 	// reset the pool's internal state
 	resetState()
-	if _, err := pool.add(tx); err != nil {
+	if _, err := pool.add(tx, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 }
@@ -583,10 +583,10 @@ func TestDoubleNonce(t *testing.T) {
 	tx3, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 1000000, big.NewInt(1), nil), signer, key)
 
 	// Add the first two transaction, ensure higher priced stays only
-	if replace, err := pool.add(tx1); err != nil || replace {
+	if replace, err := pool.add(tx1, true); err != nil || replace {
 		t.Errorf("first transaction insert failed (%v) or reported replacement (%v)", err, replace)
 	}
-	if replace, err := pool.add(tx2); err != nil || !replace {
+	if replace, err := pool.add(tx2, true); err != nil || !replace {
 		t.Errorf("second transaction insert failed (%v) or not reported replacement (%v)", err, replace)
 	}
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
@@ -598,7 +598,7 @@ func TestDoubleNonce(t *testing.T) {
 	}
 
 	// Add the third transaction and ensure it's not saved (smaller price)
-	pool.add(tx3)
+	pool.add(tx3, true)
 	<-pool.requestPromoteExecutables(newAccountSet(signer, addr))
 	if pool.pending[addr].Len() != 1 {
 		t.Error("expected 1 pending transactions, got", pool.pending[addr].Len())
@@ -621,7 +621,7 @@ func TestMissingNonce(t *testing.T) {
 	addr := crypto.PubkeyToAddress(key.PublicKey)
 	testAddBalance(pool, addr, big.NewInt(100000000000000))
 	tx := transaction(1, 100000, key)
-	if _, err := pool.add(tx); err != nil {
+	if _, err := pool.add(tx, true); err != nil {
 		t.Error("didn't expect error", err)
 	}
 	if len(pool.pending) != 0 {
@@ -1231,6 +1231,269 @@ func TestPendingGlobalLimiting(t *testing.T) {
 	}
 }
 
+// Tests that the per-sender and per-recipient admission rate limits are enforced,
+// and that preconf senders are exempt from them.
+func TestRateLimiting(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the rate limit enforcement with
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	var (
+		preconfAddr = crypto.PubkeyToAddress(keys[2].PublicKey)
+		recipient   = common.Address{0x01}
+	)
+	config := testTxPoolConfig
+	config.SenderRateLimit = 0.001 // low enough for buckets not to refill during the test
+	config.SenderBurst = 2
+	config.ToRateLimit = 0.001
+	config.ToBurst = 3
+	config.Preconf = &preconf.TxPoolConfig{FromPreconfs: []common.Address{preconfAddr}}
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	for _, key := range keys {
+		testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	transfer := func(nonce uint64, key *ecdsa.PrivateKey, to common.Address) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(100), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+		return tx
+	}
+	// The first sender may only send two transactions in a burst
+	for i := uint64(0); i < 2; i++ {
+		if err := pool.addRemoteSync(transfer(i, keys[0], common.Address{0x02})); err != nil {
+			t.Fatalf("tx %d: failed to add transaction within the sender burst: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(transfer(2, keys[0], common.Address{0x02})); !errors.Is(err, txpool.ErrRateLimited) {
+		t.Fatalf("sender rate limit not enforced: have %v, want %v", err, txpool.ErrRateLimited)
+	}
+	// The recipient may only receive three transactions in a burst, with the
+	// rejected sender transaction above not consuming its allowance
+	if err := pool.addRemoteSync(transfer(0, keys[1], recipient)); err != nil {
+		t.Fatalf("failed to add transaction within the recipient burst: %v", err)
+	}
+	if err := pool.addRemoteSync(transfer(1, keys[1], recipient)); err != nil {
+		t.Fatalf("failed to add transaction within the recipient burst: %v", err)
+	}
+	// Preconf senders are exempt from the sender limit, but still consume the
+	// allowance of the recipient
+	for i := uint64(0); i < 3; i++ {
+		err := pool.addRemoteSync(transfer(i, keys[2], common.Address{0x03}))
+		if err != nil {
+			t.Fatalf("tx %d: preconf sender rate limited: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(transfer(3, keys[2], recipient)); err != nil {
+		t.Fatalf("failed to add transaction within the recipient burst: %v", err)
+	}
+	if err := pool.addRemoteSync(transfer(4, keys[2], recipient)); !errors.Is(err, txpool.ErrRateLimited) {
+		t.Fatalf("recipient rate limit not enforced: have %v, want %v", err, txpool.ErrRateLimited)
+	}
+	pending, queued := pool.Stats()
+	if pending != 8 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 8)
+	}
+	if queued != 0 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 0)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that transactions rejected by the pool, and transactions imported from
+// a snapshot, don't consume the admission allowance of their sender.
+func TestRateLimitingRejected(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.SenderRateLimit = 0.001
+	config.SenderBurst = 2
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	// Imported transactions are exempt from the limits
+	if err := pool.Import([]*types.Transaction{pricedTransaction(0, 100000, big.NewInt(1), key)}, nil)[0]; err != nil {
+		t.Fatalf("failed to import transaction: %v", err)
+	}
+	// A replacement rejected as underpriced doesn't consume a token
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), key)); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Fatalf("unexpected error for known transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 90000, big.NewInt(1), key)); !errors.Is(err, txpool.ErrReplaceUnderpriced) {
+		t.Fatalf("unexpected error for underpriced replacement: %v", err)
+	}
+	for i := uint64(1); i < 3; i++ {
+		if err := pool.addRemoteSync(pricedTransaction(i, 100000, big.NewInt(1), key)); err != nil {
+			t.Fatalf("tx %d: failed to add transaction within the sender burst: %v", i, err)
+		}
+	}
+	if err := pool.addRemoteSync(pricedTransaction(3, 100000, big.NewInt(1), key)); !errors.Is(err, txpool.ErrRateLimited) {
+		t.Fatalf("sender rate limit not enforced: have %v, want %v", err, txpool.ErrRateLimited)
+	}
+}
+
+// Tests that importing preconf transactions restores their concluded statuses,
+// while transactions still awaiting preconfirmation are preconfirmed again.
+func TestImportPreconfStatus(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.Preconf = &preconf.TxPoolConfig{AllPreconfs: true, PreconfTimeout: time.Minute}
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+	pool.PreconfReady()
+
+	requests := make(chan *core.NewPreconfTxRequest, 2)
+	sub := pool.SubscribeNewPreconfTxRequestEvent(requests)
+	defer sub.Unsubscribe()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	txs := []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key)}
+	statuses := map[common.Hash]core.PreconfStatus{
+		txs[0].Hash(): core.PreconfStatusSuccess,
+		txs[1].Hash(): core.PreconfStatusWaiting,
+	}
+	for i, err := range pool.Import(txs, statuses) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to import transaction: %v", i, err)
+		}
+	}
+	<-pool.requestPromoteExecutables(newAccountSet(pool.signer, addr))
+
+	if status := pool.preconfTxs.GetStatus(txs[0].Hash()); status == nil || *status != core.PreconfStatusSuccess {
+		t.Fatalf("concluded preconf status not restored: %v", status)
+	}
+	select {
+	case req := <-requests:
+		if req.Tx.Hash() != txs[1].Hash() {
+			t.Fatalf("preconfirmation requested for the wrong transaction: have %x, want %x", req.Tx.Hash(), txs[1].Hash())
+		}
+		req.PreconfResult <- &core.PreconfResponse{}
+	case <-time.After(time.Second):
+		t.Fatal("waiting preconf transaction not preconfirmed again")
+	}
+	select {
+	case req := <-requests:
+		t.Fatalf("unexpected preconfirmation request for %x", req.Tx.Hash())
+	case <-time.After(50 * time.Millisecond):
+	}
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	if len(pool.importedPreconfs) != 0 {
+		t.Fatalf("imported preconf statuses leaked: %d", len(pool.importedPreconfs))
+	}
+}
+
+// Tests that exporting the pool yields all pending and queued transactions,
+// grouped by sender in nonce order and flagged with their executability.
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	txs := []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key), transaction(3, 100000, key)}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", i, err)
+		}
+	}
+	exported := pool.Export()
+	if len(exported) != len(txs) {
+		t.Fatalf("exported transaction count mismatch: have %d, want %d", len(exported), len(txs))
+	}
+	for i, entry := range exported {
+		if entry.Tx.Hash() != txs[i].Hash() {
+			t.Errorf("tx %d: hash mismatch: have %x, want %x", i, entry.Tx.Hash(), txs[i].Hash())
+		}
+		if pending := i < 2; entry.Pending != pending {
+			t.Errorf("tx %d: pending mismatch: have %v, want %v", i, entry.Pending, pending)
+		}
+		if entry.Time != uint64(txs[i].Time().UnixNano()) {
+			t.Errorf("tx %d: time mismatch: have %d, want %d", i, entry.Time, txs[i].Time().UnixNano())
+		}
+	}
+}
+
+// Tests that admission dry-runs report the violated rule along with the
+// observed and required values, without adding anything to the pool.
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000))
+	testSetNonce(pool, addr, 1)
+
+	tests := []struct {
+		tx       *types.Transaction
+		rule     txpool.AdmissionRule
+		observed *big.Int
+		required *big.Int
+	}{
+		{tx: transaction(1, 100000, key)},
+		{tx: transaction(0, 100000, key), rule: txpool.RuleNonce, observed: big.NewInt(0), required: big.NewInt(1)},
+		{tx: transaction(1, 10000001, key), rule: txpool.RuleGasLimit, observed: big.NewInt(10000001), required: big.NewInt(10000000)},
+		{tx: pricedTransaction(1, 100000, big.NewInt(100), key), rule: txpool.RuleBalance, observed: big.NewInt(1000000), required: big.NewInt(10000100)},
+		{tx: pricedTransaction(1, 100000, big.NewInt(0), key), rule: txpool.RuleBaseFee, observed: big.NewInt(0), required: pool.currentHead.Load().BaseFee},
+	}
+	for i, tt := range tests {
+		err := pool.Explain(tt.tx)
+		res := txpool.ExplainAdmission(err)
+		if res.Accepted != (tt.rule == "") || res.Rule != tt.rule {
+			t.Errorf("test %d: result mismatch: have %v/%q, want %v/%q (err %v)", i, res.Accepted, res.Rule, tt.rule == "", tt.rule, err)
+			continue
+		}
+		if have := (*big.Int)(res.Observed); fmt.Sprint(have) != fmt.Sprint(tt.observed) {
+			t.Errorf("test %d: observed mismatch: have %v, want %v", i, have, tt.observed)
+		}
+		if have := (*big.Int)(res.Required); fmt.Sprint(have) != fmt.Sprint(tt.required) {
+			t.Errorf("test %d: required mismatch: have %v, want %v", i, have, tt.required)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool modified by dry-run: pending %d, queued %d", pending, queued)
+	}
+	// Explaining an already pooled transaction should report it as known
+	tx := transaction(1, 100000, key)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if res := txpool.ExplainAdmission(pool.Explain(tx)); res.Rule != txpool.RuleKnown {
+		t.Fatalf("known transaction rule mismatch: have %q, want %q", res.Rule, txpool.RuleKnown)
+	}
+}
+
 // Test the limit on transaction size is enforced correctly.
 // This test verifies every transaction having allowed size
 // is added to the pool, and longer transactions are rejected.
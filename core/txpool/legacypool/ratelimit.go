@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterBuckets is the maximum number of addresses tracked by a single
+// rate limiter. Buckets of the least recently active addresses are dropped
+// first. An address idle for burst/rate has a full bucket anyway, but with more
+// active addresses than buckets, a dropped address starts over with a full
+// bucket, i.e. eviction resets its limit. The limits are thus only enforced
+// for up to this many concurrently throttled addresses.
+const rateLimiterBuckets = 16384
+
+// rateLimiter maintains a token bucket per address, limiting the rate at which
+// transactions associated with any single address are admitted into the pool.
+//
+// A nil rateLimiter allows everything. The limiter is not thread safe, it is
+// protected by the pool lock.
+type rateLimiter struct {
+	limit   rate.Limit
+	burst   int
+	buckets lru.BasicLRU[common.Address, *rate.Limiter]
+}
+
+// newRateLimiter creates a limiter admitting perSecond transactions per address
+// on average with bursts of up to burst transactions. If the rate is zero, the
+// limiter is disabled and nil is returned.
+func newRateLimiter(perSecond float64, burst uint64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		limit:   rate.Limit(perSecond),
+		burst:   int(burst),
+		buckets: lru.NewBasicLRU[common.Address, *rate.Limiter](rateLimiterBuckets),
+	}
+}
+
+// allowed reports whether a transaction associated with addr would be admitted
+// at the given time, without consuming any tokens.
+func (l *rateLimiter) allowed(addr common.Address, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	bucket, ok := l.buckets.Get(addr)
+	if !ok {
+		return true
+	}
+	return bucket.TokensAt(now) >= 1
+}
+
+// take consumes a token from the bucket of addr.
+func (l *rateLimiter) take(addr common.Address, now time.Time) {
+	if l == nil {
+		return
+	}
+	bucket, ok := l.buckets.Get(addr)
+	if !ok {
+		bucket = rate.NewLimiter(l.limit, l.burst)
+		l.buckets.Add(addr, bucket)
+	}
+	bucket.AllowN(now, 1)
+}
+
+// checkRateLimits verifies that neither the sender nor the recipient of the
+// transaction exceeded their admission rate. Preconf senders and recipients are
+// exempt. In dry-run mode, no rejections are metered.
+//
+// The pool lock must be held.
+func (pool *LegacyPool) checkRateLimits(from common.Address, tx *types.Transaction, dryRun bool) error {
+	var (
+		now = time.Now()
+		to  = tx.To()
+	)
+	if !pool.config.Preconf.IsPreconfTxFrom(from) && !pool.senderLimiter.allowed(from, now) {
+		if !dryRun {
+			senderRateLimitedMeter.Mark(1)
+		}
+		err := fmt.Errorf("%w: sender %v", txpool.ErrRateLimited, from)
+		return txpool.NewAdmissionError(txpool.RuleSenderRateLimit, nil, nil, err)
+	}
+	if to != nil && !pool.config.Preconf.IsPreconfTxTo(*to) && !pool.toLimiter.allowed(*to, now) {
+		if !dryRun {
+			toRateLimitedMeter.Mark(1)
+		}
+		err := fmt.Errorf("%w: recipient %v", txpool.ErrRateLimited, *to)
+		return txpool.NewAdmissionError(txpool.RuleRecipientRateLimit, nil, nil, err)
+	}
+	return nil
+}
+
+// takeRateLimits consumes a token from the buckets of the sender and the
+// recipient of an inserted transaction, unless they are exempt.
+//
+// The pool lock must be held.
+func (pool *LegacyPool) takeRateLimits(from common.Address, tx *types.Transaction) {
+	now := time.Now()
+	if !pool.config.Preconf.IsPreconfTxFrom(from) {
+		pool.senderLimiter.take(from, now)
+	}
+	if to := tx.To(); to != nil && !pool.config.Preconf.IsPreconfTxTo(*to) {
+		pool.toLimiter.take(*to, now)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that the buckets of the rate limiter refill over time.
+func TestRateLimiterRefill(t *testing.T) {
+	var (
+		limiter = newRateLimiter(2, 2) // a token every 500ms
+		addr    = common.Address{0x01}
+		now     = time.Now()
+	)
+	for i := 0; i < 2; i++ {
+		if !limiter.allowed(addr, now) {
+			t.Fatalf("tx %d: rejected within the burst", i)
+		}
+		limiter.take(addr, now)
+	}
+	if limiter.allowed(addr, now) {
+		t.Fatal("accepted beyond the burst")
+	}
+	if limiter.allowed(addr, now.Add(400*time.Millisecond)) {
+		t.Fatal("accepted before a token was refilled")
+	}
+	if !limiter.allowed(addr, now.Add(500*time.Millisecond)) {
+		t.Fatal("rejected after a token was refilled")
+	}
+	limiter.take(addr, now.Add(500*time.Millisecond))
+	if limiter.allowed(addr, now.Add(500*time.Millisecond)) {
+		t.Fatal("accepted beyond the refilled tokens")
+	}
+	// The bucket doesn't refill beyond the burst
+	later := now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if !limiter.allowed(addr, later) {
+			t.Fatalf("tx %d: rejected after a full refill", i)
+		}
+		limiter.take(addr, later)
+	}
+	if limiter.allowed(addr, later) {
+		t.Fatal("bucket refilled beyond the burst")
+	}
+}
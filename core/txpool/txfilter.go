@@ -0,0 +1,120 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	// filteredAdmissionMeter counts the transactions rejected by the policy
+	// filter when entering the pool.
+	filteredAdmissionMeter = metrics.NewRegisteredMeter("txpool/filtered/admission", nil)
+
+	// filteredInclusionMeter counts the pooled transactions rejected by the
+	// policy filter when being included into a block.
+	filteredInclusionMeter = metrics.NewRegisteredMeter("txpool/filtered/inclusion", nil)
+)
+
+// filterLogInterval is the minimum interval between two audit logs of policy
+// filter rejections. Rejections in between are only logged at debug level and
+// counted in the next audit log, so that peers can't flood the log.
+const filterLogInterval = 10 * time.Second
+
+// TxFilter is a policy hook deciding whether a transaction may enter the pool
+// and be included into a block, e.g. to enforce a sanctioned address list.
+//
+// Filters are consulted both on admission and right before inclusion, so that
+// changes to the policy apply to already pooled transactions too. Implementations
+// must be safe for concurrent use.
+type TxFilter interface {
+	// FilterTx returns a non-nil error, wrapping ErrTxFiltered, if the transaction
+	// sent by from is to be rejected.
+	FilterTx(tx *types.Transaction, from common.Address) error
+}
+
+// SetTxFilter installs the policy filter consulted on transaction admission and
+// inclusion. A nil filter removes any previously installed one.
+func (p *TxPool) SetTxFilter(filter TxFilter) {
+	if filter == nil {
+		p.filter.Store(nil)
+		return
+	}
+	p.filter.Store(&filter)
+}
+
+// FilterInclusion checks a pooled transaction against the installed policy
+// filter right before its inclusion into a block. Rejections are metered and
+// audit logged.
+func (p *TxPool) FilterInclusion(tx *types.Transaction, from common.Address) error {
+	filter := p.filter.Load()
+	if filter == nil {
+		return nil
+	}
+	if err := (*filter).FilterTx(tx, from); err != nil {
+		filteredInclusionMeter.Mark(1)
+		p.logFiltered("inclusion", tx, from, err)
+		return err
+	}
+	return nil
+}
+
+// filterAdmission checks a new transaction against the installed policy filter
+// before handing it to the subpools. Rejections are metered and audit logged.
+func (p *TxPool) filterAdmission(tx *types.Transaction) error {
+	if err := p.checkTxFilter(tx); err != nil {
+		filteredAdmissionMeter.Mark(1)
+		from, _ := types.Sender(p.signer, tx)
+		p.logFiltered("admission", tx, from, err)
+		return err
+	}
+	return nil
+}
+
+// logFiltered audit logs a transaction rejected by the policy filter, at most
+// once per filterLogInterval. The other rejections are logged at debug level.
+func (p *TxPool) logFiltered(stage string, tx *types.Transaction, from common.Address, err error) {
+	ctx := []interface{}{"stage", stage, "hash", tx.Hash(), "from", from, "to", tx.To(), "nonce", tx.Nonce(), "err", err}
+
+	now := time.Now().UnixNano()
+	last := p.filterLogged.Load()
+	if now-last < int64(filterLogInterval) || !p.filterLogged.CompareAndSwap(last, now) {
+		p.filterSuppressed.Add(1)
+		log.Debug("Policy filter rejected transaction", ctx...)
+		return
+	}
+	log.Warn("Policy filter rejected transaction", append(ctx, "suppressed", p.filterSuppressed.Swap(0))...)
+}
+
+// checkTxFilter runs the installed policy filter against the transaction.
+// Transactions with an invalid signature are left for the subpools to reject.
+func (p *TxPool) checkTxFilter(tx *types.Transaction) error {
+	filter := p.filter.Load()
+	if filter == nil {
+		return nil
+	}
+	from, err := types.Sender(p.signer, tx)
+	if err != nil {
+		return nil
+	}
+	return (*filter).FilterTx(tx, from)
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package txfilter implements transaction policy filters for the txpool.
+package txfilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// denylistReloadInterval is the interval at which the denylist file is checked
+// for modifications.
+var denylistReloadInterval = 3 * time.Second
+
+// denylistEntries is an immutable snapshot of the denylist file contents.
+type denylistEntries struct {
+	addrs     map[common.Address]struct{}
+	selectors map[[4]byte]struct{}
+}
+
+// Denylist is a transaction filter rejecting transactions sent from or to any
+// of the listed addresses, or calling any of the listed function selectors.
+//
+// The list is loaded from a text file with one entry per line, either a hex
+// encoded 20 byte address or a hex encoded 4 byte selector. Empty lines and
+// anything after a '#' are ignored. The file is reloaded whenever modified;
+// if the new contents fail to parse, the previous list is retained.
+type Denylist struct {
+	path    string
+	entries atomic.Pointer[denylistEntries]
+
+	modTime time.Time // Modification time of the loaded file
+	size    int64     // Size of the loaded file
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDenylist loads the denylist file at path and starts watching it for
+// modifications.
+func NewDenylist(path string) (*Denylist, error) {
+	d := &Denylist{
+		path: path,
+		quit: make(chan struct{}),
+	}
+	if err := d.reload(); err != nil {
+		return nil, err
+	}
+	d.wg.Add(1)
+	go d.loop()
+	return d, nil
+}
+
+// Close stops watching the denylist file.
+func (d *Denylist) Close() {
+	close(d.quit)
+	d.wg.Wait()
+}
+
+// FilterTx implements txpool.TxFilter, rejecting transactions touching any of
+// the denylisted addresses or selectors.
+func (d *Denylist) FilterTx(tx *types.Transaction, from common.Address) error {
+	entries := d.entries.Load()
+	if _, ok := entries.addrs[from]; ok {
+		return fmt.Errorf("%w: denylisted sender %v", txpool.ErrTxFiltered, from)
+	}
+	to := tx.To()
+	if to == nil {
+		return nil
+	}
+	if _, ok := entries.addrs[*to]; ok {
+		return fmt.Errorf("%w: denylisted recipient %v", txpool.ErrTxFiltered, *to)
+	}
+	if data := tx.Data(); len(data) >= 4 {
+		if _, ok := entries.selectors[[4]byte(data[:4])]; ok {
+			return fmt.Errorf("%w: denylisted selector %#x", txpool.ErrTxFiltered, data[:4])
+		}
+	}
+	return nil
+}
+
+// loop periodically checks the denylist file for modifications and reloads it.
+func (d *Denylist) loop() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(denylistReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			info, err := os.Stat(d.path)
+			if err != nil {
+				log.Warn("Failed to stat transaction denylist", "path", d.path, "err", err)
+				continue
+			}
+			if info.ModTime().Equal(d.modTime) && info.Size() == d.size {
+				continue
+			}
+			if err := d.reload(); err != nil {
+				log.Error("Failed to reload transaction denylist, keeping previous", "path", d.path, "err", err)
+			}
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+// reload parses the denylist file and swaps in its contents.
+func (d *Denylist) reload() error {
+	f, err := os.Open(d.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// Track the file version even if parsing fails, to avoid retrying the same
+	// broken contents over and over again
+	d.modTime, d.size = info.ModTime(), info.Size()
+
+	entries, err := parseDenylist(f)
+	if err != nil {
+		return err
+	}
+	d.entries.Store(entries)
+	log.Info("Loaded transaction denylist", "path", d.path, "addresses", len(entries.addrs), "selectors", len(entries.selectors))
+	return nil
+}
+
+// parseDenylist parses the denylist entries from r.
+func parseDenylist(r io.Reader) (*denylistEntries, error) {
+	entries := &denylistEntries{
+		addrs:     make(map[common.Address]struct{}),
+		selectors: make(map[[4]byte]struct{}),
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		blob, err := hexutil.Decode(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid entry %q: %v", line, text, err)
+		}
+		switch len(blob) {
+		case common.AddressLength:
+			entries.addrs[common.BytesToAddress(blob)] = struct{}{}
+		case 4:
+			entries.selectors[[4]byte(blob)] = struct{}{}
+		default:
+			return nil, fmt.Errorf("line %d: invalid entry %q: neither address nor selector", line, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txfilter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	bannedAddr  = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	allowedAddr = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+)
+
+func call(to common.Address, data []byte) *types.Transaction {
+	return types.NewTransaction(0, to, common.Big0, 100000, common.Big1, data)
+}
+
+// Tests that the denylist rejects transactions touching listed addresses and
+// selectors.
+func TestDenylistFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	contents := "# sanctioned accounts\n" + bannedAddr.Hex() + "\n\n0xa9059cbb # transfer(address,uint256)\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewDenylist(path)
+	if err != nil {
+		t.Fatalf("failed to load denylist: %v", err)
+	}
+	defer d.Close()
+
+	tests := []struct {
+		tx       *types.Transaction
+		from     common.Address
+		filtered bool
+	}{
+		{call(allowedAddr, nil), allowedAddr, false},
+		{call(allowedAddr, nil), bannedAddr, true},
+		{call(bannedAddr, nil), allowedAddr, true},
+		{call(allowedAddr, []byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}), allowedAddr, true},
+		{call(allowedAddr, []byte{0xa9, 0x05, 0x9c}), allowedAddr, false},
+		{types.NewContractCreation(0, common.Big0, 100000, common.Big1, []byte{0xa9, 0x05, 0x9c, 0xbb}), allowedAddr, false},
+	}
+	for i, tt := range tests {
+		err := d.FilterTx(tt.tx, tt.from)
+		if filtered := errors.Is(err, txpool.ErrTxFiltered); filtered != tt.filtered {
+			t.Errorf("test %d: filter mismatch: have %v, want filtered %v", i, err, tt.filtered)
+		}
+	}
+}
+
+// Tests that the denylist is reloaded when modified, and that the previous
+// list is retained if the new contents are invalid.
+func TestDenylistReload(t *testing.T) {
+	defer func(old time.Duration) { denylistReloadInterval = old }(denylistReloadInterval)
+	denylistReloadInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	if err := os.WriteFile(path, []byte(bannedAddr.Hex()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewDenylist(path)
+	if err != nil {
+		t.Fatalf("failed to load denylist: %v", err)
+	}
+	defer d.Close()
+
+	waitFiltered := func(addr common.Address, want bool) {
+		t.Helper()
+		for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+			if errors.Is(d.FilterTx(call(addr, nil), common.Address{}), txpool.ErrTxFiltered) == want {
+				return
+			}
+		}
+		t.Fatalf("address %v filter state mismatch: want filtered %v", addr, want)
+	}
+	waitFiltered(bannedAddr, true)
+
+	if err := os.WriteFile(path, []byte(allowedAddr.Hex()+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFiltered(allowedAddr, true)
+	waitFiltered(bannedAddr, false)
+
+	if err := os.WriteFile(path, []byte("not an entry\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	waitFiltered(allowedAddr, true)
+
+	if _, err := NewDenylist(path); err == nil {
+		t.Fatalf("invalid denylist loaded")
+	}
+}
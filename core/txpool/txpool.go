@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -69,6 +70,10 @@ type TxPool struct {
 	subpools []SubPool // List of subpools for specialized transaction handling
 	chain    BlockChain
 	signer   types.Signer
+	filter   atomic.Pointer[TxFilter] // Policy filter consulted on admission and inclusion
+
+	filterLogged     atomic.Int64  // Unix time in nanoseconds of the last policy filter audit log
+	filterSuppressed atomic.Uint64 // Policy filter rejections not audit logged since the last log
 
 	stateLock sync.RWMutex   // The lock for protecting state instance
 	state     *state.StateDB // Current state at the blockchain head
@@ -333,6 +338,13 @@ func (p *TxPool) GetBlobs(vhashes []common.Hash) ([]*kzg4844.Blob, []*kzg4844.Pr
 // Note, if sync is set the method will block until all internal maintenance
 // related to the add is finished. Only use this during tests for determinism.
 func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
+	return p.add(txs, sync, nil)
+}
+
+// add enqueues a batch of transactions into the subpools. Transactions imported
+// from a snapshot, along with their preconf statuses, are handed to the subpools
+// supporting imports as such.
+func (p *TxPool) add(txs []*types.Transaction, sync bool, imported map[common.Hash]core.PreconfStatus) []error {
 	// Split the input transactions between the subpools. It shouldn't really
 	// happen that we receive merged batches, but better graceful than strange
 	// errors.
@@ -341,11 +353,16 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	// so we can piece back the returned errors into the original order.
 	txsets := make([][]*types.Transaction, len(p.subpools))
 	splits := make([]int, len(txs))
+	errs := make([]error, len(txs))
 
 	for i, tx := range txs {
 		// Mark this transaction belonging to no-subpool
 		splits[i] = -1
 
+		// Reject transactions disallowed by the policy filter upfront
+		if errs[i] = p.filterAdmission(tx); errs[i] != nil {
+			continue
+		}
 		// Try to find a subpool that accepts the transaction
 		for j, subpool := range p.subpools {
 			if subpool.Filter(tx) {
@@ -359,10 +376,17 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	// back the errors into the original sort order.
 	errsets := make([][]error, len(p.subpools))
 	for i := 0; i < len(p.subpools); i++ {
-		errsets[i] = p.subpools[i].Add(txsets[i], sync)
+		if pool, ok := p.subpools[i].(importer); ok && imported != nil {
+			errsets[i] = pool.Import(txsets[i], imported)
+		} else {
+			errsets[i] = p.subpools[i].Add(txsets[i], sync)
+		}
 	}
-	errs := make([]error, len(txs))
 	for i, split := range splits {
+		// If the transaction was rejected by the policy filter, keep its error
+		if errs[i] != nil {
+			continue
+		}
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
 			errs[i] = fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, txs[i].Type())
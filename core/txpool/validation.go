@@ -84,100 +84,105 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	// This is for spam protection, not consensus,
 	// as the external engine-API user authenticates deposits.
 	if tx.Type() == types.DepositTxType {
-		return core.ErrTxTypeNotSupported
+		return NewAdmissionError(RuleTxType, nil, nil, core.ErrTxTypeNotSupported)
 	}
 
 	if opts.Config.IsOptimism() && tx.Type() == types.BlobTxType {
-		return core.ErrTxTypeNotSupported
+		return NewAdmissionError(RuleTxType, nil, nil, core.ErrTxTypeNotSupported)
 	}
 
 	if err := types.MetaTxCheck(tx.Data()); err != nil {
-		return err
+		return NewAdmissionError(RuleMetaTx, nil, nil, err)
 	}
 
 	// Ensure transactions not implemented by the calling pool are rejected
 	if opts.Accept&(1<<tx.Type()) == 0 {
-		return fmt.Errorf("%w: tx type %v not supported by this pool", core.ErrTxTypeNotSupported, tx.Type())
+		return NewAdmissionError(RuleTxType, nil, nil, fmt.Errorf("%w: tx type %v not supported by this pool", core.ErrTxTypeNotSupported, tx.Type()))
 	}
 	// Before performing any expensive validations, sanity check that the tx is
 	// smaller than the maximum limit the pool can meaningfully handle
 	if tx.Size() > opts.MaxSize {
-		return fmt.Errorf("%w: transaction size %v, limit %v", ErrOversizedData, tx.Size(), opts.MaxSize)
+		err := fmt.Errorf("%w: transaction size %v, limit %v", ErrOversizedData, tx.Size(), opts.MaxSize)
+		return NewAdmissionError(RuleSize, newUint64(tx.Size()), newUint64(opts.MaxSize), err)
 	}
 	// Ensure only transactions that have been enabled are accepted
 	rules := opts.Config.Rules(head.Number, head.Difficulty.Sign() == 0, head.Time)
 	if !rules.IsBerlin && tx.Type() != types.LegacyTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in Berlin", core.ErrTxTypeNotSupported, tx.Type())
+		return NewAdmissionError(RuleTxType, nil, nil, fmt.Errorf("%w: type %d rejected, pool not yet in Berlin", core.ErrTxTypeNotSupported, tx.Type()))
 	}
 	if !rules.IsLondon && tx.Type() == types.DynamicFeeTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in London", core.ErrTxTypeNotSupported, tx.Type())
+		return NewAdmissionError(RuleTxType, nil, nil, fmt.Errorf("%w: type %d rejected, pool not yet in London", core.ErrTxTypeNotSupported, tx.Type()))
 	}
 	if !rules.IsCancun && tx.Type() == types.BlobTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
+		return NewAdmissionError(RuleTxType, nil, nil, fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type()))
 	}
 	if !rules.IsPrague && tx.Type() == types.SetCodeTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in Prague", core.ErrTxTypeNotSupported, tx.Type())
+		return NewAdmissionError(RuleTxType, nil, nil, fmt.Errorf("%w: type %d rejected, pool not yet in Prague", core.ErrTxTypeNotSupported, tx.Type()))
 	}
 	// Check whether the init code size has been exceeded
 	if rules.IsShanghai && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
-		return fmt.Errorf("%w: code size %v, limit %v", core.ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+		err := fmt.Errorf("%w: code size %v, limit %v", core.ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+		return NewAdmissionError(RuleInitCodeSize, big.NewInt(int64(len(tx.Data()))), big.NewInt(params.MaxInitCodeSize), err)
 	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur for transactions created using the RPC.
 	if tx.Value().Sign() < 0 {
-		return ErrNegativeValue
+		return NewAdmissionError(RuleNegativeValue, tx.Value(), new(big.Int), ErrNegativeValue)
 	}
 	// Ensure the transaction doesn't exceed the current block limit gas
-	if EffectiveGasLimit(opts.Config, head.GasLimit, opts.EffectiveGasCeil) < tx.Gas() {
-		return ErrGasLimit
+	if limit := EffectiveGasLimit(opts.Config, head.GasLimit, opts.EffectiveGasCeil); limit < tx.Gas() {
+		return NewAdmissionError(RuleGasLimit, newUint64(tx.Gas()), newUint64(limit), ErrGasLimit)
 	}
 	// Sanity check for extremely large numbers (supported by RLP or RPC)
 	if tx.GasFeeCap().BitLen() > 256 {
-		return core.ErrFeeCapVeryHigh
+		return NewAdmissionError(RuleFeeOverflow, big.NewInt(int64(tx.GasFeeCap().BitLen())), big.NewInt(256), core.ErrFeeCapVeryHigh)
 	}
 	if tx.GasTipCap().BitLen() > 256 {
-		return core.ErrTipVeryHigh
+		return NewAdmissionError(RuleFeeOverflow, big.NewInt(int64(tx.GasTipCap().BitLen())), big.NewInt(256), core.ErrTipVeryHigh)
 	}
 	// Ensure gasFeeCap is greater than or equal to gasTipCap
 	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
-		return core.ErrTipAboveFeeCap
+		return NewAdmissionError(RuleTipAboveFeeCap, tx.GasTipCap(), tx.GasFeeCap(), core.ErrTipAboveFeeCap)
 	}
 	// Make sure the transaction is signed properly
 	if _, err := types.Sender(signer, tx); err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidSender, err)
+		return NewAdmissionError(RuleSender, nil, nil, fmt.Errorf("%w: %v", ErrInvalidSender, err))
 	}
 
 	// Ensure the gasprice is high enough to cover the requirement of the calling pool
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
-		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.GasTipCap(), opts.MinTip)
+		err := fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.GasTipCap(), opts.MinTip)
+		return NewAdmissionError(RuleMinTip, tx.GasTipCap(), opts.MinTip, err)
 	}
 	if tx.Type() == types.BlobTxType {
 		// Ensure the blob fee cap satisfies the minimum blob gas price
 		if tx.BlobGasFeeCapIntCmp(blobTxMinBlobGasPrice) < 0 {
-			return fmt.Errorf("%w: blob fee cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.BlobGasFeeCap(), blobTxMinBlobGasPrice)
+			err := fmt.Errorf("%w: blob fee cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.BlobGasFeeCap(), blobTxMinBlobGasPrice)
+			return NewAdmissionError(RuleBlobFee, tx.BlobGasFeeCap(), blobTxMinBlobGasPrice, err)
 		}
 		sidecar := tx.BlobTxSidecar()
 		if sidecar == nil {
-			return errors.New("missing sidecar in blob transaction")
+			return NewAdmissionError(RuleBlobSidecar, nil, nil, errors.New("missing sidecar in blob transaction"))
 		}
 		// Ensure the number of items in the blob transaction and various side
 		// data match up before doing any expensive validations
 		hashes := tx.BlobHashes()
 		if len(hashes) == 0 {
-			return errors.New("blobless blob transaction")
+			return NewAdmissionError(RuleBlobSidecar, new(big.Int), big.NewInt(1), errors.New("blobless blob transaction"))
 		}
 		maxBlobs := eip4844.MaxBlobsPerBlock(opts.Config, head.Time)
 		if len(hashes) > maxBlobs {
-			return fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), maxBlobs)
+			err := fmt.Errorf("too many blobs in transaction: have %d, permitted %d", len(hashes), maxBlobs)
+			return NewAdmissionError(RuleBlobSidecar, big.NewInt(int64(len(hashes))), big.NewInt(int64(maxBlobs)), err)
 		}
 		// Ensure commitments, proofs and hashes are valid
 		if err := validateBlobSidecar(hashes, sidecar); err != nil {
-			return err
+			return NewAdmissionError(RuleBlobSidecar, nil, nil, err)
 		}
 	}
 	if tx.Type() == types.SetCodeTxType {
 		if len(tx.SetCodeAuthorizations()) == 0 {
-			return fmt.Errorf("set code tx must have at least one authorization tuple")
+			return NewAdmissionError(RuleSetCodeAuth, new(big.Int), big.NewInt(1), errors.New("set code tx must have at least one authorization tuple"))
 		}
 	}
 	return nil
@@ -247,13 +252,15 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 	}
 	next := opts.State.GetNonce(from)
 	if next > tx.Nonce() {
-		return fmt.Errorf("%w: next nonce %v, tx nonce %v", core.ErrNonceTooLow, next, tx.Nonce())
+		err := fmt.Errorf("%w: next nonce %v, tx nonce %v", core.ErrNonceTooLow, next, tx.Nonce())
+		return NewAdmissionError(RuleNonce, newUint64(tx.Nonce()), newUint64(next), err)
 	}
 	// Ensure the transaction doesn't produce a nonce gap in pools that do not
 	// support arbitrary orderings
 	if opts.FirstNonceGap != nil {
 		if gap := opts.FirstNonceGap(from); gap < tx.Nonce() {
-			return fmt.Errorf("%w: tx nonce %v, gapped nonce %v", core.ErrNonceTooHigh, tx.Nonce(), gap)
+			err := fmt.Errorf("%w: tx nonce %v, gapped nonce %v", core.ErrNonceTooHigh, tx.Nonce(), gap)
+			return NewAdmissionError(RuleNonceGap, newUint64(tx.Nonce()), newUint64(gap), err)
 		}
 	}
 	// Ensure the transactor has enough funds to cover the transaction costs
@@ -265,13 +272,14 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 		tokenRatio = opts.State.GetState(types.GasOracleAddr, types.TokenRatioSlot).Big().Uint64()
 	)
 	if balance.Cmp(cost) < 0 {
-		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
+		err := fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
+		return NewAdmissionError(RuleBalance, balance, cost, err)
 	}
 
 	// When feecap is smaller than basefee, submission is meaningless.
 	// Report an error quickly instead of getting stuck in txpool.
 	if tx.GasFeeCap().Cmp(head.BaseFee) < 0 {
-		return core.ErrFeeCapTooLow
+		return NewAdmissionError(RuleBaseFee, tx.GasFeeCap(), head.BaseFee, core.ErrFeeCapTooLow)
 	}
 
 	// Ensure the transaction has more gas than the bare minimum needed to cover the transaction metadata
@@ -280,7 +288,8 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 		return err
 	}
 	if tx.Gas() < intrGas*tokenRatio {
-		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas*tokenRatio)
+		err := fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas*tokenRatio)
+		return NewAdmissionError(RuleIntrinsicGas, newUint64(tx.Gas()), newUint64(intrGas*tokenRatio), err)
 	}
 
 	gasRemaining := big.NewInt(int64(tx.Gas() - intrGas*tokenRatio))
@@ -292,7 +301,8 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 			return err
 		}
 		if tx.Gas() < floorDataGas*tokenRatio {
-			return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrFloorDataGas, tx.Gas(), floorDataGas*tokenRatio)
+			err := fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrFloorDataGas, tx.Gas(), floorDataGas*tokenRatio)
+			return NewAdmissionError(RuleFloorDataGas, newUint64(tx.Gas()), newUint64(floorDataGas*tokenRatio), err)
 		}
 
 		if floorDataGas > intrGas {
@@ -305,7 +315,7 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 		if l1Cost := opts.L1CostFn(tx.RollupCostData(), tx.IsDepositTx(), tx.To()); l1Cost != nil {
 			txCost := new(big.Int).Mul(tx.GasPrice(), gasRemaining)
 			if txCost.Cmp(l1Cost) < 0 {
-				return core.ErrInsufficientGasForL1Cost
+				return NewAdmissionError(RuleL1Cost, txCost, l1Cost, core.ErrInsufficientGasForL1Cost)
 			}
 		}
 	}
@@ -317,19 +327,21 @@ func ValidateTransactionWithState(tx *types.Transaction, head *types.Header, sig
 		bump := new(big.Int).Sub(cost, prev)
 		need := new(big.Int).Add(spent, bump)
 		if balance.Cmp(need) < 0 {
-			return fmt.Errorf("%w: balance %v, queued cost %v, tx bumped %v, overshot %v", core.ErrInsufficientFunds, balance, spent, bump, new(big.Int).Sub(need, balance))
+			err := fmt.Errorf("%w: balance %v, queued cost %v, tx bumped %v, overshot %v", core.ErrInsufficientFunds, balance, spent, bump, new(big.Int).Sub(need, balance))
+			return NewAdmissionError(RuleBalance, balance, need, err)
 		}
 	} else {
 		need := new(big.Int).Add(spent, cost)
 		if balance.Cmp(need) < 0 {
-			return fmt.Errorf("%w: balance %v, queued cost %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, spent, cost, new(big.Int).Sub(need, balance))
+			err := fmt.Errorf("%w: balance %v, queued cost %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, spent, cost, new(big.Int).Sub(need, balance))
+			return NewAdmissionError(RuleBalance, balance, need, err)
 		}
 		// Transaction takes a new nonce value out of the pool. Ensure it doesn't
 		// overflow the number of permitted transactions from a single account
 		// (i.e. max cancellable via out-of-bound transaction).
 		if opts.UsedAndLeftSlots != nil {
 			if used, left := opts.UsedAndLeftSlots(from); left <= 0 {
-				return fmt.Errorf("%w: pooled %d txs", ErrAccountLimitExceeded, used)
+				return NewAdmissionError(RuleAccountSlots, big.NewInt(int64(used)), nil, fmt.Errorf("%w: pooled %d txs", ErrAccountLimitExceeded, used))
 			}
 		}
 	}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -242,9 +243,16 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if err != nil {
 		return nil, nil, err
 	}
+	trackStateReads(ctx, stateDb)
 	return stateDb, header, nil
 }
 
+// trackStateReads accounts the state entries loaded from the database to the
+// RPC call of the context.
+func trackStateReads(ctx context.Context, stateDb *state.StateDB) {
+	rpc.TrackDBReads(ctx, func() int { return stateDb.AccountLoaded + stateDb.StorageLoaded })
+}
+
 func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
 	if blockNr, ok := blockNrOrHash.Number(); ok {
 		return b.StateAndHeaderByNumber(ctx, blockNr)
@@ -264,6 +272,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if err != nil {
 			return nil, nil, err
 		}
+		trackStateReads(ctx, stateDb)
 		return stateDb, header, nil
 	}
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
@@ -453,6 +462,19 @@ func (b *EthAPIBackend) TxIndexDone() bool {
 	return b.eth.blockchain.TxIndexDone()
 }
 
+// AddressIndexRange returns the range of blocks whose transactions are indexed
+// by the addresses taking part in them.
+func (b *EthAPIBackend) AddressIndexRange(ctx context.Context) (uint64, uint64, bool, error) {
+	return b.eth.blockchain.AddressIndexRange()
+}
+
+// AddressTransactions scans at most limit index entries of the transactions the
+// address took part in, returning the canonical ones and the entry the next scan
+// resumes at.
+func (b *EthAPIBackend) AddressTransactions(ctx context.Context, address common.Address, number uint64, index uint32, to uint64, limit int) ([]rawdb.AddressIndexEntry, *rawdb.AddressIndexEntry, error) {
+	return b.eth.blockchain.AddressTransactions(address, number, index, to, limit)
+}
+
 func (b *EthAPIBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
 	return b.eth.txPool.PoolNonce(addr), nil
 }
@@ -565,6 +587,35 @@ func (b *EthAPIBackend) HistoricalRPCService() *rpc.Client {
 	return b.eth.historicalRPCService
 }
 
+// TraceCache returns the persistent cache of block trace results, or nil if
+// it is disabled.
+func (b *EthAPIBackend) TraceCache() *tracers.TraceCache {
+	return b.eth.traceCache
+}
+
+// ErrorRegistry returns the custom errors used to decode revert reasons, or nil
+// if none are configured.
+func (b *EthAPIBackend) ErrorRegistry() *abi.ErrorRegistry {
+	return b.eth.errorRegistry
+}
+
+// ReceiptRevertReasons returns whether the revert reasons of failed transactions
+// are added to their receipts.
+func (b *EthAPIBackend) ReceiptRevertReasons() bool {
+	return b.eth.config.ReceiptRevertReasons
+}
+
+// RevertData returns the data each transaction of a block reverted with, nil
+// for the successful ones, as recorded when the block was processed. Nothing is
+// returned for blocks processed without recording it.
+func (b *EthAPIBackend) RevertData(ctx context.Context, blockHash common.Hash) ([][]byte, error) {
+	number := rawdb.ReadHeaderNumber(b.eth.chainDb, blockHash)
+	if number == nil {
+		return nil, fmt.Errorf("block %#x not found", blockHash)
+	}
+	return rawdb.ReadRevertData(b.eth.chainDb, blockHash, *number), nil
+}
+
 func (b *EthAPIBackend) Genesis() *types.Block {
 	return b.eth.blockchain.Genesis()
 }
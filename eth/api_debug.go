@@ -129,6 +129,48 @@ func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 	return results, nil
 }
 
+// BadPayloadArgs represents the entries in the list returned when payloads
+// rejected by the engine API are queried.
+type BadPayloadArgs struct {
+	Hash       common.Hash            `json:"hash"`
+	Number     hexutil.Uint64         `json:"number"`
+	ParentHash common.Hash            `json:"parentHash"`
+	Reason     string                 `json:"reason"`
+	Time       hexutil.Uint64         `json:"time"`
+	Block      map[string]interface{} `json:"block"`
+	RLP        string                 `json:"rlp"`
+}
+
+// GetBadPayloads returns the list of the last payloads rejected by the engine
+// API along with the reason of the rejection, most recent first.
+func (api *DebugAPI) GetBadPayloads(ctx context.Context) ([]*BadPayloadArgs, error) {
+	var (
+		payloads = rawdb.ReadAllBadPayloads(api.eth.chainDb)
+		results  = make([]*BadPayloadArgs, 0, len(payloads))
+	)
+	for _, payload := range payloads {
+		var (
+			block    = payload.Block()
+			blockRlp string
+		)
+		if rlpBytes, err := rlp.EncodeToBytes(block); err != nil {
+			blockRlp = err.Error() // Hacky, but hey, it works
+		} else {
+			blockRlp = fmt.Sprintf("%#x", rlpBytes)
+		}
+		results = append(results, &BadPayloadArgs{
+			Hash:       block.Hash(),
+			Number:     hexutil.Uint64(block.NumberU64()),
+			ParentHash: block.ParentHash(),
+			Reason:     payload.Reason,
+			Time:       hexutil.Uint64(payload.Time),
+			Block:      ethapi.RPCMarshalBlock(ctx, block, true, true, api.eth.APIBackend.ChainConfig(), api.eth.APIBackend),
+			RLP:        blockRlp,
+		})
+	}
+	return results, nil
+}
+
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
@@ -205,12 +247,15 @@ type StorageRangeResult struct {
 type storageMap map[common.Hash]storageEntry
 
 type storageEntry struct {
-	Key   *common.Hash `json:"key"`
-	Value common.Hash  `json:"value"`
+	Key       *common.Hash `json:"key"`
+	Value     common.Hash  `json:"value"`
+	Variables []string     `json:"variables,omitempty"` // Set if a storage layout is given
 }
 
 // StorageRangeAt returns the storage at the given block height and transaction index.
-func (api *DebugAPI) StorageRangeAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, txIndex int, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int) (StorageRangeResult, error) {
+// If the storage layout of the contract is given, the slots are labeled with the
+// variables stored in them.
+func (api *DebugAPI) StorageRangeAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, txIndex int, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int, layout *StorageLayout) (StorageRangeResult, error) {
 	var block *types.Block
 
 	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
@@ -226,7 +271,14 @@ func (api *DebugAPI) StorageRangeAt(ctx context.Context, blockNrOrHash rpc.Block
 	}
 	defer release()
 
-	return storageRangeAt(statedb, block.Root(), contractAddress, keyStart, maxResult)
+	result, err := storageRangeAt(statedb, block.Root(), contractAddress, keyStart, maxResult)
+	if err != nil || layout == nil {
+		return result, err
+	}
+	if err := labelStorageRange(statedb, contractAddress, layout, &result); err != nil {
+		return StorageRangeResult{}, err
+	}
+	return result, nil
 }
 
 func storageRangeAt(statedb *state.StateDB, root common.Hash, address common.Address, start []byte, maxResult int) (StorageRangeResult, error) {
@@ -265,6 +317,22 @@ func storageRangeAt(statedb *state.StateDB, root common.Hash, address common.Add
 	return result, nil
 }
 
+// GetContractStorageDecoded decodes the storage of a contract into its state
+// variables according to the storage layout emitted by the Solidity compiler.
+// Mapping entries are recovered from the preimages of their storage slots, so
+// they are only available if the node records preimages.
+func (api *DebugAPI) GetContractStorageDecoded(ctx context.Context, address common.Address, layout StorageLayout, blockNrOrHash *rpc.BlockNumberOrHash) (*DecodedStorage, error) {
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	statedb, header, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	return decodeStorage(statedb, header.Root, address, &layout)
+}
+
 // GetModifiedAccountsByNumber returns all accounts that have changed between the
 // two blocks specified. A change is defined as a difference in nonce, balance,
 // code hash, or storage hash.
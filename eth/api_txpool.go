@@ -0,0 +1,119 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TxPoolAPI is the collection of transaction pool APIs served by the full node,
+// including the ones carrying the pool contents over between nodes through the
+// local file system.
+type TxPoolAPI struct {
+	eth *Ethereum
+}
+
+// NewTxPoolAPI creates a new instance of TxPoolAPI.
+func NewTxPoolAPI(eth *Ethereum) *TxPoolAPI {
+	return &TxPoolAPI{eth: eth}
+}
+
+// TxPoolImportResult is the outcome of a transaction pool import.
+type TxPoolImportResult struct {
+	Total   int `json:"total"`   // Number of transactions read from the file
+	Dropped int `json:"dropped"` // Number of transactions rejected by the pool
+}
+
+// Export writes all the pending and queued transactions of the pool, together
+// with their arrival times and preconf statuses, into a local file and returns
+// the number of exported transactions.
+func (api *TxPoolAPI) Export(file string) (int, error) {
+	if _, err := os.Stat(file); err == nil {
+		// File already exists. Allowing overwrite could be a DoS vector,
+		// since the 'file' may point to arbitrary paths on the drive.
+		return 0, errors.New("location would overwrite an existing file")
+	}
+	// Make sure we can create the file to export into
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	var writer io.Writer = out
+	if strings.HasSuffix(file, ".gz") {
+		writer = gzip.NewWriter(writer)
+	}
+	exported, err := api.eth.TxPool().Export(writer)
+	if err != nil {
+		return 0, err
+	}
+	// Flush the compressed stream and the file, failing on a truncated snapshot
+	if gz, ok := writer.(*gzip.Writer); ok {
+		if err := gz.Close(); err != nil {
+			return 0, err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return 0, err
+	}
+	log.Info("Exported transaction pool", "file", file, "transactions", exported)
+	return exported, nil
+}
+
+// Import injects the transactions from a file previously created by Export
+// into the pool.
+func (api *TxPoolAPI) Import(file string) (*TxPoolImportResult, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	if strings.HasSuffix(file, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return nil, err
+		}
+	}
+	total, dropped, err := api.eth.TxPool().Import(reader)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Imported transaction pool", "file", file, "transactions", total, "dropped", dropped)
+	return &TxPoolImportResult{Total: total, Dropped: dropped}, nil
+}
+
+// Explain dry-runs the pool admission checks against a signed transaction
+// given in its binary encoding, reporting the rule rejecting it along with
+// the observed and required values. The transaction is not added to the pool.
+func (api *TxPoolAPI) Explain(input hexutil.Bytes) (*txpool.AdmissionResult, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	return api.eth.TxPool().Explain(tx), nil
+}
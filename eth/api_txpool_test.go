@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the transaction pool contents survive an export/import roundtrip.
+func TestTxPoolExportImport(t *testing.T) {
+	for _, name := range []string{"txpool.rlp", "txpool.rlp.gz"} {
+		t.Run(name, func(t *testing.T) {
+			testTxPoolExportImport(t, filepath.Join(t.TempDir(), name))
+		})
+	}
+}
+
+func testTxPoolExportImport(t *testing.T, file string) {
+	src := initBackend(false)
+	defer src.eth.txPool.Close()
+
+	txs := []*types.Transaction{makeTx(0, nil, nil, key), makeTx(1, nil, nil, key), makeTx(3, nil, nil, key)}
+	for i, err := range src.eth.txPool.Add(txs, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	api := NewTxPoolAPI(src.eth)
+	exported, err := api.Export(file)
+	if err != nil {
+		t.Fatalf("failed to export pool: %v", err)
+	}
+	if exported != len(txs) {
+		t.Fatalf("exported transaction count mismatch: have %d, want %d", exported, len(txs))
+	}
+	if _, err := api.Export(file); err == nil {
+		t.Fatalf("export overwrote existing file")
+	}
+	dst := initBackend(false)
+	defer dst.eth.txPool.Close()
+
+	res, err := NewTxPoolAPI(dst.eth).Import(file)
+	if err != nil {
+		t.Fatalf("failed to import pool: %v", err)
+	}
+	if res.Total != len(txs) || res.Dropped != 0 {
+		t.Fatalf("import result mismatch: have %d/%d, want %d/0", res.Total, res.Dropped, len(txs))
+	}
+	if err := dst.eth.txPool.Sync(); err != nil {
+		t.Fatalf("failed to sync pool: %v", err)
+	}
+	pending, queued := dst.eth.txPool.Stats()
+	if pending != 2 || queued != 1 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want 2/1", pending, queued)
+	}
+	for _, tx := range txs {
+		have := dst.eth.txPool.Get(tx.Hash())
+		if have == nil {
+			t.Fatalf("transaction %x missing after import", tx.Hash())
+		}
+		if !have.Time().Equal(tx.Time()) {
+			t.Errorf("transaction %x time mismatch: have %v, want %v", tx.Hash(), have.Time(), tx.Time())
+		}
+	}
+}
+
+// Tests that transactions are explained without being added to the pool.
+func TestTxPoolExplain(t *testing.T) {
+	b := initBackend(false)
+	defer b.eth.txPool.Close()
+
+	api := NewTxPoolAPI(b.eth)
+	explain := func(tx *types.Transaction) *txpool.AdmissionResult {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode transaction: %v", err)
+		}
+		res, err := api.Explain(raw)
+		if err != nil {
+			t.Fatalf("failed to explain transaction: %v", err)
+		}
+		return res
+	}
+	if res := explain(makeTx(0, nil, nil, key)); !res.Accepted {
+		t.Fatalf("valid transaction rejected: %q %s", res.Rule, res.Reason)
+	}
+	if res := explain(makeTx(0, nil, funds, key)); res.Accepted || res.Rule != txpool.RuleBalance {
+		t.Fatalf("overdraft rule mismatch: have %v/%q, want false/%q", res.Accepted, res.Rule, txpool.RuleBalance)
+	}
+	if pending, queued := b.eth.txPool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool modified by explain: pending %d, queued %d", pending, queued)
+	}
+	if _, err := api.Explain(hexutil.Bytes{0x01}); err == nil {
+		t.Fatalf("invalid transaction encoding accepted")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -38,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
+	"github.com/ethereum/go-ethereum/core/txpool/txfilter"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -47,6 +49,8 @@ import (
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
@@ -72,6 +76,10 @@ type Ethereum struct {
 	// core protocol objects
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
+	txDenylist     *txfilter.Denylist
+	traceCache     *tracers.TraceCache
+	traceCacheDb   ethdb.KeyValueStore
+	errorRegistry  *abi.ErrorRegistry
 	localTxTracker *locals.TxTracker
 	blockchain     *core.BlockChain
 
@@ -210,8 +218,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			StateHistory:        config.StateHistory,
 			StateScheme:         scheme,
 			ChainHistoryMode:    config.HistoryMode,
+			RevertData:          config.ReceiptRevertReasons,
 		}
 	)
+	if config.AddressIndex {
+		cacheConfig.AddressHistory = &config.AddressHistory
+	}
 	if config.VMTrace != "" {
 		traceConfig := json.RawMessage("{}")
 		if config.VMTraceJsonConfig != "" {
@@ -287,6 +299,31 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.TxPool.Denylist != "" {
+		eth.txDenylist, err = txfilter.NewDenylist(stack.ResolvePath(config.TxPool.Denylist))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transaction denylist: %v", err)
+		}
+		eth.txPool.SetTxFilter(eth.txDenylist)
+	}
+	if config.TraceCache > 0 {
+		if path := stack.ResolvePath("tracecache"); path == "" {
+			eth.traceCacheDb = memorydb.New()
+		} else if eth.traceCacheDb, err = pebble.New(path, 16, 16, "eth/db/tracecache/", false, false); err != nil {
+			return nil, fmt.Errorf("failed to open trace cache: %v", err)
+		}
+		eth.traceCache, err = tracers.NewTraceCache(eth.traceCacheDb, uint64(config.TraceCache)*1024*1024, eth.blockchain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trace cache: %v", err)
+		}
+	}
+	if config.ErrorABIs != "" {
+		eth.errorRegistry, err = abi.LoadErrorRegistry(stack.ResolvePath(config.ErrorABIs))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load error ABIs: %v", err)
+		}
+		log.Info("Loaded custom error registry", "path", config.ErrorABIs, "errors", eth.errorRegistry.Len())
+	}
 
 	rejournal := config.TxPool.Rejournal
 	if rejournal < time.Second {
@@ -407,6 +444,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
+		}, {
+			Namespace: "txpool",
+			Service:   NewTxPoolAPI(s),
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
@@ -567,6 +607,13 @@ func (s *Ethereum) Stop() error {
 	<-ch
 	s.filterMaps.Stop()
 	s.txPool.Close()
+	if s.txDenylist != nil {
+		s.txDenylist.Close()
+	}
+	if s.traceCache != nil {
+		s.traceCache.Close()
+		s.traceCacheDb.Close()
+	}
 	s.blockchain.Stop()
 	s.engine.Close()
 
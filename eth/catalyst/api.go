@@ -128,7 +128,8 @@ type ConsensusAPI struct {
 	//   - The bad block tracking is ephemeral, in-memory only. We must never
 	//     persist any bad block information to disk as a bug in Geth could end
 	//     up blocking a valid chain, even if a later Geth update would accept
-	//     it.
+	//     it. Rejected payloads are saved to the database for post-mortem
+	//     debugging, but that record is never consulted for rejections.
 	//   - Bad blocks will get forgotten after a certain threshold of import
 	//     attempts and will be retried. The rationale is that if the network
 	//     really-really-really tries to feed us a block, we should give it a
@@ -907,6 +908,7 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 	}
 	// If this block was rejected previously, keep rejecting it
 	if res := api.checkInvalidAncestor(block.Hash(), block.Hash()); res != nil {
+		api.writeBadPayload(block, errors.New(*res.ValidationError))
 		return *res, nil
 	}
 	// If the parent is missing, we - in theory - could trigger a sync, but that
@@ -921,7 +923,9 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 	}
 	if block.Time() <= parent.Time() {
 		log.Warn("Invalid timestamp", "parent", block.Time(), "block", block.Time())
-		return api.invalid(errors.New("invalid timestamp"), parent.Header()), nil
+		err := errors.New("invalid timestamp")
+		api.writeBadPayload(block, err)
+		return api.invalid(err, parent.Header()), nil
 	}
 	// Another corner case: if the node is in snap sync mode, but the CL client
 	// tries to make it import a block. That should be denied as pushing something
@@ -945,6 +949,7 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 		api.invalidTipsets[block.Hash()] = block.Header()
 		api.invalidLock.Unlock()
 
+		api.writeBadPayload(block, err)
 		return api.invalid(err, parent.Header()), nil
 	}
 	hash := block.Hash()
@@ -1023,6 +1028,7 @@ func (api *ConsensusAPI) delayPayloadImport(block *types.Block) engine.PayloadSt
 	// Sanity check that this block's parent is not on a previously invalidated
 	// chain. If it is, mark the block as invalid too.
 	if res := api.checkInvalidAncestor(block.ParentHash(), block.Hash()); res != nil {
+		api.writeBadPayload(block, errors.New(*res.ValidationError))
 		return *res
 	}
 	// Stash the block away for a potential forced forkchoice update to it
@@ -1055,6 +1061,12 @@ func (api *ConsensusAPI) delayPayloadImport(block *types.Block) engine.PayloadSt
 	return engine.PayloadStatusV1{Status: engine.SYNCING}
 }
 
+// writeBadPayload persists a payload rejected by newPayload, so it can be
+// inspected and replayed later.
+func (api *ConsensusAPI) writeBadPayload(block *types.Block, reason error) {
+	rawdb.WriteBadPayload(api.eth.ChainDb(), block, reason, uint64(time.Now().Unix()))
+}
+
 // setInvalidAncestor is a callback for the downloader to notify us if a bad block
 // is encountered during the async sync.
 func (api *ConsensusAPI) setInvalidAncestor(invalid *types.Header, origin *types.Header) {
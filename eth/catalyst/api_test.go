@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -733,6 +734,33 @@ func TestEmptyBlocks(t *testing.T) {
 	if !bytes.Equal(status.LatestValidHash[:], expected[:]) {
 		t.Fatalf("invalid LVH: got %v want %v", status.LatestValidHash, expected)
 	}
+	// The rejected payload should be persisted along with the rejection reason
+	bad := rawdb.ReadBadPayload(ethservice.ChainDb(), payload.BlockHash)
+	if bad == nil {
+		t.Fatalf("rejected payload not persisted")
+	}
+	if bad.Reason != *status.ValidationError {
+		t.Fatalf("invalid bad payload reason: got %q want %q", bad.Reason, *status.ValidationError)
+	}
+	if bad.Header.ParentHash != payload.ParentHash {
+		t.Fatalf("invalid bad payload parent: got %v want %v", bad.Header.ParentHash, payload.ParentHash)
+	}
+	// A descendant of the rejected payload should be rejected and persisted too
+	child := getNewPayload(t, api, commonAncestor, nil, nil)
+	child.ParentHash = payload.BlockHash
+	child.Number = payload.Number + 1
+	child = setBlockhash(child)
+	if status, err = api.NewPayloadV1(*child); err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != engine.INVALID {
+		t.Errorf("invalid status: expected INVALID got: %v", status.Status)
+	}
+	if bad := rawdb.ReadBadPayload(ethservice.ChainDb(), child.BlockHash); bad == nil {
+		t.Fatalf("payload with rejected ancestor not persisted")
+	} else if bad.Reason != *status.ValidationError {
+		t.Fatalf("invalid bad payload reason: got %q want %q", bad.Reason, *status.ValidationError)
+	}
 
 	// (3) Now send a payload with unknown parent
 	payload = getNewPayload(t, api, commonAncestor, nil, nil)
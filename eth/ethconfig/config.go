@@ -105,6 +105,8 @@ type Config struct {
 	LogHistory           uint64 `toml:",omitempty"` // The maximum number of blocks from head where a log search index is maintained.
 	LogNoHistory         bool   `toml:",omitempty"` // No log search index is maintained.
 	LogExportCheckpoints string // export log index checkpoints to file
+	AddressIndex         bool   `toml:",omitempty"` // Whether transactions are indexed by the addresses taking part in them.
+	AddressHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose transactions are indexed by address.
 	StateHistory         uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
 
 	// State scheme represents the scheme used to store ethereum states and trie
@@ -159,6 +161,18 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// TraceCache is the size in megabytes of the persistent cache for block
+	// trace results (0 = disabled).
+	TraceCache int `toml:",omitempty"`
+
+	// ErrorABIs is the path of a contract ABI file, or a directory of them, with
+	// the custom errors used to decode revert reasons.
+	ErrorABIs string `toml:",omitempty"`
+
+	// ReceiptRevertReasons records the revert data of failed transactions when
+	// processing blocks, and adds their revert reasons to their receipts.
+	ReceiptRevertReasons bool `toml:",omitempty"`
+
 	// OverridePrague (TODO: remove after the fork)
 	OverridePrague *uint64 `toml:",omitempty"`
 
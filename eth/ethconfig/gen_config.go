@@ -31,6 +31,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		LogHistory                   uint64 `toml:",omitempty"`
 		LogNoHistory                 bool   `toml:",omitempty"`
 		LogExportCheckpoints         string
+		AddressIndex                 bool                   `toml:",omitempty"`
+		AddressHistory               uint64                 `toml:",omitempty"`
 		StateHistory                 uint64                 `toml:",omitempty"`
 		StateScheme                  string                 `toml:",omitempty"`
 		RequiredBlocks               map[uint64]common.Hash `toml:"-"`
@@ -54,6 +56,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCGasCap                    uint64
 		RPCEVMTimeout                time.Duration
 		RPCTxFeeCap                  float64
+		TraceCache                   int     `toml:",omitempty"`
+		ErrorABIs                    string  `toml:",omitempty"`
+		ReceiptRevertReasons         bool    `toml:",omitempty"`
 		OverridePrague               *uint64 `toml:",omitempty"`
 		OverrideVerkle               *uint64 `toml:",omitempty"`
 		OverrideOptimismBedrock      *big.Int
@@ -80,6 +85,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.LogHistory = c.LogHistory
 	enc.LogNoHistory = c.LogNoHistory
 	enc.LogExportCheckpoints = c.LogExportCheckpoints
+	enc.AddressIndex = c.AddressIndex
+	enc.AddressHistory = c.AddressHistory
 	enc.StateHistory = c.StateHistory
 	enc.StateScheme = c.StateScheme
 	enc.RequiredBlocks = c.RequiredBlocks
@@ -103,6 +110,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.TraceCache = c.TraceCache
+	enc.ErrorABIs = c.ErrorABIs
+	enc.ReceiptRevertReasons = c.ReceiptRevertReasons
 	enc.OverridePrague = c.OverridePrague
 	enc.OverrideVerkle = c.OverrideVerkle
 	enc.OverrideOptimismBedrock = c.OverrideOptimismBedrock
@@ -133,6 +143,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		LogHistory                   *uint64 `toml:",omitempty"`
 		LogNoHistory                 *bool   `toml:",omitempty"`
 		LogExportCheckpoints         *string
+		AddressIndex                 *bool                  `toml:",omitempty"`
+		AddressHistory               *uint64                `toml:",omitempty"`
 		StateHistory                 *uint64                `toml:",omitempty"`
 		StateScheme                  *string                `toml:",omitempty"`
 		RequiredBlocks               map[uint64]common.Hash `toml:"-"`
@@ -156,6 +168,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCGasCap                    *uint64
 		RPCEVMTimeout                *time.Duration
 		RPCTxFeeCap                  *float64
+		TraceCache                   *int    `toml:",omitempty"`
+		ErrorABIs                    *string `toml:",omitempty"`
+		ReceiptRevertReasons         *bool   `toml:",omitempty"`
 		OverridePrague               *uint64 `toml:",omitempty"`
 		OverrideVerkle               *uint64 `toml:",omitempty"`
 		OverrideOptimismBedrock      *big.Int
@@ -211,6 +226,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.LogExportCheckpoints != nil {
 		c.LogExportCheckpoints = *dec.LogExportCheckpoints
 	}
+	if dec.AddressIndex != nil {
+		c.AddressIndex = *dec.AddressIndex
+	}
+	if dec.AddressHistory != nil {
+		c.AddressHistory = *dec.AddressHistory
+	}
 	if dec.StateHistory != nil {
 		c.StateHistory = *dec.StateHistory
 	}
@@ -280,6 +301,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.TraceCache != nil {
+		c.TraceCache = *dec.TraceCache
+	}
+	if dec.ErrorABIs != nil {
+		c.ErrorABIs = *dec.ErrorABIs
+	}
+	if dec.ReceiptRevertReasons != nil {
+		c.ReceiptRevertReasons = *dec.ReceiptRevertReasons
+	}
 	if dec.OverridePrague != nil {
 		c.OverridePrague = dec.OverridePrague
 	}
@@ -285,18 +285,27 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 }
 
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
-func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+//
+// If the criteria contain a cursor, or set resume along with an optional
+// fromBlock, the subscription is resumable: the logs from the cursor or block on
+// are delivered first, followed by the new ones. Every item is wrapped in a LogsNotification
+// carrying the cursor to resume after it, and reorgs are announced explicitly
+// instead of through removed logs.
+func (api *FilterAPI) Logs(ctx context.Context, crit LogsCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if crit.resumable() {
+		return api.resumableLogs(ctx, notifier, crit)
+	}
 
 	var (
 		rpcSub      = notifier.CreateSubscription()
 		matchedLogs = make(chan []*types.Log)
 	)
 
-	logsSub, err := api.events.SubscribeLogs(ethereum.FilterQuery(crit), matchedLogs)
+	logsSub, err := api.events.SubscribeLogs(ethereum.FilterQuery(crit.FilterCriteria), matchedLogs)
 	if err != nil {
 		return nil, err
 	}
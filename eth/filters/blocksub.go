@@ -0,0 +1,153 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Notification types of block subscriptions.
+const (
+	BlockNotificationBlock = "block" // a new canonical block
+	BlockNotificationReorg = "reorg" // the blocks after the given one were removed
+	BlockNotificationError = "error" // the subscription failed and is terminated
+)
+
+// BlockReceiptsNotification is an item of the blockReceipts subscription, either
+// the receipts of a new canonical block or a reorg announcement. A reorg item
+// references the last block shared by the old and new chain, the blocks
+// delivered after it were removed and the new ones follow. An error item
+// terminates the subscription.
+type BlockReceiptsNotification struct {
+	Type        string                   `json:"type"`
+	BlockHash   common.Hash              `json:"blockHash"`
+	BlockNumber hexutil.Uint64           `json:"blockNumber"`
+	Receipts    []map[string]interface{} `json:"receipts,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+// FollowChain delivers every new canonical block after the given one, and
+// announces the reorgs removing delivered blocks. It returns the last block
+// handled, which is the given one if nothing changed.
+func FollowChain(ctx context.Context, backend ChainHeaderReader, last *types.Header, deliver func(*types.Header) error, reorg func(*types.Header) error, quit <-chan error) (*types.Header, error) {
+	canonical, err := isCanonical(ctx, backend, last)
+	if err != nil {
+		return last, err
+	}
+	if !canonical {
+		ancestor, err := canonicalAncestor(ctx, backend, last)
+		if err != nil {
+			return last, err
+		}
+		if err := reorg(ancestor); err != nil {
+			return last, err
+		}
+		last = ancestor
+	}
+	head, err := backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return last, err
+	}
+	for head != nil && last.Number.Uint64() < head.Number.Uint64() {
+		select {
+		case <-quit:
+			return last, nil
+		default:
+		}
+		header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(last.Number.Uint64()+1))
+		if err != nil {
+			return last, err
+		}
+		if header == nil || header.ParentHash != last.Hash() {
+			break // chain changed meanwhile, retry with the next head
+		}
+		if err := deliver(header); err != nil {
+			return last, err
+		}
+		last = header
+	}
+	return last, nil
+}
+
+// BlockReceipts creates a subscription that fires with the receipts of every
+// new canonical block. Reorgs are announced with the last block shared by the
+// old and the new chain before the receipts of the new blocks.
+func (api *FilterAPI) BlockReceipts(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		backend       = api.sys.backend
+		rpcSub        = notifier.CreateSubscription()
+		wake, headSub = SubscribeChainWakeups(backend.SubscribeChainEvent)
+		last          = backend.CurrentHeader()
+	)
+	go func() {
+		defer headSub.Unsubscribe()
+
+		deliver := func(header *types.Header) error {
+			body, err := backend.GetBody(context.Background(), header.Hash(), rpc.BlockNumber(header.Number.Int64()))
+			if err != nil {
+				return err
+			}
+			receipts, err := backend.GetReceipts(context.Background(), header.Hash())
+			if err != nil {
+				return err
+			}
+			block := types.NewBlockWithHeader(header).WithBody(*body)
+			marshalled, err := ethapi.MarshalBlockReceipts(block, receipts, backend.ChainConfig())
+			if err != nil {
+				return err
+			}
+			return notifier.Notify(rpcSub.ID, &BlockReceiptsNotification{
+				Type:        BlockNotificationBlock,
+				BlockHash:   header.Hash(),
+				BlockNumber: hexutil.Uint64(header.Number.Uint64()),
+				Receipts:    marshalled,
+			})
+		}
+		reorg := func(ancestor *types.Header) error {
+			return notifier.Notify(rpcSub.ID, &BlockReceiptsNotification{
+				Type:        BlockNotificationReorg,
+				BlockHash:   ancestor.Hash(),
+				BlockNumber: hexutil.Uint64(ancestor.Number.Uint64()),
+			})
+		}
+		for {
+			select {
+			case <-wake:
+				var err error
+				if last, err = FollowChain(context.Background(), backend, last, deliver, reorg, rpcSub.Err()); err != nil {
+					log.Debug("Block receipts subscription failed", "err", err)
+					notifier.Notify(rpcSub.ID, &BlockReceiptsNotification{Type: BlockNotificationError, Error: err.Error()})
+					return
+				}
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
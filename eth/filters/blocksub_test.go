@@ -0,0 +1,126 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+func TestBlockReceiptsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		key, _       = crypto.GenerateKey()
+		sender       = crypto.PubkeyToAddress(key.PublicKey)
+		signer       = types.LatestSigner(params.TestChainConfig)
+		gspec        = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	genesis, err := gspec.Commit(db, triedb.NewDatabase(db, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// transfer adds n transactions to the block
+	transfer := func(n int) func(int, *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			gen.SetCoinbase(common.Address{byte(n)})
+			for j := 0; j < n; j++ {
+				tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), common.Address{0xaa}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+				gen.AddTx(tx)
+			}
+		}
+	}
+	write := func(blocks []*types.Block, receipts []types.Receipts) {
+		for i, block := range blocks {
+			rawdb.WriteBlock(db, block)
+			rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+			rawdb.WriteHeadBlockHash(db, block.Hash())
+			rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+		}
+		backend.chainFeed.Send(core.ChainEvent{Header: blocks[len(blocks)-1].Header()})
+	}
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", NewFilterAPI(sys)); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan *BlockReceiptsNotification, 100)
+	sub, err := client.EthSubscribe(context.Background(), ch, "blockReceipts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// expect reads the next notification and checks its type, block and receipts
+	expect := func(typ string, block *types.Block, receipts int) {
+		t.Helper()
+		select {
+		case n := <-ch:
+			if n.Type != typ || n.BlockHash != block.Hash() || uint64(n.BlockNumber) != block.NumberU64() {
+				t.Fatalf("want %s #%d, have %s #%d", typ, block.NumberU64(), n.Type, n.BlockNumber)
+			}
+			if len(n.Receipts) != receipts {
+				t.Fatalf("#%d: receipt count mismatch, have %d, want %d", block.NumberU64(), len(n.Receipts), receipts)
+			}
+			for i, receipt := range n.Receipts {
+				if receipt["transactionHash"] != block.Transactions()[i].Hash().Hex() {
+					t.Fatalf("#%d: receipt %d hash mismatch", block.NumberU64(), i)
+				}
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %s #%d", typ, block.NumberU64())
+		}
+	}
+	chain, receipts := core.GenerateChain(gspec.Config, genesis, ethash.NewFaker(), db, 4, transfer(1))
+	write(chain, receipts)
+	for _, block := range chain {
+		expect(BlockNotificationBlock, block, 1)
+	}
+	// Reorg the last two blocks to a longer chain
+	fork, forkReceipts := core.GenerateChain(gspec.Config, chain[1], ethash.NewFaker(), db, 4, transfer(2))
+	write(fork, forkReceipts)
+	expect(BlockNotificationReorg, chain[1], 0)
+	for _, block := range fork {
+		expect(BlockNotificationBlock, block, 2)
+	}
+	select {
+	case n := <-ch:
+		t.Fatalf("unexpected notification %+v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -41,8 +41,9 @@ import (
 
 // Config represents the configuration of the filter system.
 type Config struct {
-	LogCacheSize int           // maximum number of cached blocks (default: 32)
-	Timeout      time.Duration // how long filters stay active (default: 5min)
+	LogCacheSize   int           // maximum number of cached blocks (default: 32)
+	Timeout        time.Duration // how long filters stay active (default: 5min)
+	LogResumeRange uint64        // maximum number of past blocks a resumable log subscription catches up with (default: 100000)
 }
 
 func (cfg Config) withDefaults() Config {
@@ -52,6 +53,9 @@ func (cfg Config) withDefaults() Config {
 	if cfg.LogCacheSize == 0 {
 		cfg.LogCacheSize = 32
 	}
+	if cfg.LogResumeRange == 0 {
+		cfg.LogResumeRange = 100000
+	}
 	return cfg
 }
 
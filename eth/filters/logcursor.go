@@ -0,0 +1,338 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// logsResumeBatch is the number of blocks searched at once while a resumable
+// log subscription catches up with the chain.
+const logsResumeBatch = 1000
+
+// Notification types of resumable log subscriptions.
+const (
+	LogsNotificationLog   = "log"   // a log matching the criteria
+	LogsNotificationReorg = "reorg" // delivered logs past the cursor were removed
+	LogsNotificationError = "error" // the subscription failed and is terminated
+)
+
+// LogCursor is a position in the stream of logs of a resumable subscription.
+// Without a log index, the cursor is placed after all logs of the block.
+type LogCursor struct {
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	LogIndex    *hexutil.Uint  `json:"logIndex,omitempty"`
+}
+
+// LogsNotification is an item of a resumable log subscription. Every item
+// carries the cursor to resume the subscription after it.
+//
+// A reorg item announces that the blocks after its cursor were removed from the
+// chain, the logs delivered for them must be dropped. The logs of the new
+// chain are delivered afterwards.
+type LogsNotification struct {
+	Type   string     `json:"type"`
+	Log    *types.Log `json:"log,omitempty"`
+	Cursor *LogCursor `json:"cursor,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// LogsCriteria is the criteria of a log subscription, optionally resuming at a
+// cursor or, with Resume set, starting at fromBlock.
+type LogsCriteria struct {
+	FilterCriteria
+	Cursor *LogCursor
+	Resume bool
+}
+
+// UnmarshalJSON sets *args fields with given data.
+func (args *LogsCriteria) UnmarshalJSON(data []byte) error {
+	if err := args.FilterCriteria.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	var raw struct {
+		Cursor *LogCursor `json:"cursor"`
+		Resume bool       `json:"resume"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	args.Cursor, args.Resume = raw.Cursor, raw.Resume
+	return nil
+}
+
+// resumable returns whether the criteria ask for a resumable subscription. The
+// mode is opt-in, through a cursor or the resume flag, as it changes the format
+// of the notifications.
+func (args *LogsCriteria) resumable() bool {
+	return args.Cursor != nil || args.Resume
+}
+
+// logResumer delivers the logs of a resumable subscription in chain order,
+// first catching up with the chain through the log index and then following
+// it block by block.
+type logResumer struct {
+	sys       *FilterSystem
+	addresses []common.Address
+	topics    [][]common.Hash
+
+	last *types.Header // Last block whose logs are delivered, nil before genesis
+	next uint64        // First block whose logs are not delivered yet
+	skip *hexutil.Uint // Index of the last log delivered in block next, if any
+	drop *types.Header // Ancestor to announce a reorg at before anything else
+}
+
+// newLogResumer positions a resumer at the cursor or starting block of the
+// given criteria.
+func newLogResumer(ctx context.Context, sys *FilterSystem, crit LogsCriteria) (*logResumer, error) {
+	if crit.BlockHash != nil {
+		return nil, errors.New("blockHash is not supported by resumable log subscriptions")
+	}
+	if crit.ToBlock != nil && crit.ToBlock.Int64() != rpc.LatestBlockNumber.Int64() {
+		return nil, errors.New("toBlock is not supported by resumable log subscriptions")
+	}
+	var (
+		backend = sys.backend
+		r       = &logResumer{sys: sys, addresses: crit.Addresses, topics: crit.Topics}
+	)
+	if crit.Cursor != nil {
+		header, err := backend.HeaderByHash(ctx, crit.Cursor.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("unknown cursor block %#x", crit.Cursor.BlockHash)
+		}
+		canonical, err := isCanonical(ctx, backend, header)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !canonical:
+			// The cursor block was reorged while the client was away, the
+			// reorg is announced first.
+			ancestor, err := canonicalAncestor(ctx, backend, header)
+			if err != nil {
+				return nil, err
+			}
+			r.last, r.next, r.drop = ancestor, ancestor.Number.Uint64()+1, ancestor
+		case crit.Cursor.LogIndex != nil:
+			r.last, r.next, r.skip = header, header.Number.Uint64(), crit.Cursor.LogIndex
+		default:
+			r.last, r.next = header, header.Number.Uint64()+1
+		}
+	} else {
+		from := rpc.LatestBlockNumber.Int64()
+		if crit.FromBlock != nil {
+			from = crit.FromBlock.Int64()
+		}
+		if from == rpc.PendingBlockNumber.Int64() {
+			return nil, errors.New("pending fromBlock is not supported by resumable log subscriptions")
+		}
+		if from < 0 {
+			header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(from))
+			if err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, errors.New("fromBlock not found")
+			}
+			from = header.Number.Int64()
+		}
+		if from > 0 {
+			header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(from-1))
+			if err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, errors.New("fromBlock is beyond the current head")
+			}
+			r.last = header
+		}
+		r.next = uint64(from)
+	}
+	if r.next < backend.HistoryPruningCutoff() {
+		return nil, &history.PrunedHistoryError{}
+	}
+	if head := backend.CurrentHeader(); head != nil && head.Number.Uint64() >= r.next {
+		if blocks := head.Number.Uint64() - r.next + 1; blocks > sys.cfg.LogResumeRange {
+			return nil, fmt.Errorf("resume range of %d blocks exceeds the limit of %d", blocks, sys.cfg.LogResumeRange)
+		}
+	}
+	return r, nil
+}
+
+// ChainHeaderReader is the subset of the backend methods needed to follow the
+// canonical chain.
+type ChainHeaderReader interface {
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// isCanonical returns whether the given header is part of the canonical chain.
+func isCanonical(ctx context.Context, backend ChainHeaderReader, header *types.Header) (bool, error) {
+	canon, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()))
+	if err != nil {
+		return false, err
+	}
+	return canon != nil && canon.Hash() == header.Hash(), nil
+}
+
+// canonicalAncestor returns the last canonical ancestor of a header.
+func canonicalAncestor(ctx context.Context, backend ChainHeaderReader, header *types.Header) (*types.Header, error) {
+	for {
+		parent, err := backend.HeaderByHash(ctx, header.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("missing ancestor %#x of block #%d", header.ParentHash, header.Number)
+		}
+		canonical, err := isCanonical(ctx, backend, parent)
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			return parent, nil
+		}
+		header = parent
+	}
+}
+
+// SubscribeChainWakeups subscribes to the chain events, coalescing them into
+// wakeups so that the chain never waits for a slow subscriber.
+func SubscribeChainWakeups(subscribe func(chan<- core.ChainEvent) event.Subscription) (<-chan struct{}, event.Subscription) {
+	var (
+		heads = make(chan core.ChainEvent, chainEvChanSize)
+		sub   = subscribe(heads)
+		wake  = make(chan struct{}, 1)
+	)
+	go func() {
+		for {
+			select {
+			case <-heads:
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return wake, sub
+}
+
+// catchUp delivers the logs up to the current head, announcing first if the
+// delivered blocks were reorged out of the chain. It returns early if quit is
+// closed.
+func (r *logResumer) catchUp(ctx context.Context, notify func(*LogsNotification) error, quit <-chan error) error {
+	if r.drop == nil && r.last != nil {
+		canonical, err := isCanonical(ctx, r.sys.backend, r.last)
+		if err != nil {
+			return err
+		}
+		if !canonical {
+			if r.drop, err = canonicalAncestor(ctx, r.sys.backend, r.last); err != nil {
+				return err
+			}
+		}
+	}
+	if r.drop != nil {
+		cursor := &LogCursor{BlockHash: r.drop.Hash(), BlockNumber: hexutil.Uint64(r.drop.Number.Uint64())}
+		if err := notify(&LogsNotification{Type: LogsNotificationReorg, Cursor: cursor}); err != nil {
+			return err
+		}
+		r.last, r.next, r.skip, r.drop = r.drop, r.drop.Number.Uint64()+1, nil, nil
+	}
+	head := r.sys.backend.CurrentHeader()
+	for head != nil && r.next <= head.Number.Uint64() {
+		select {
+		case <-quit:
+			return nil
+		default:
+		}
+		end := min(head.Number.Uint64(), r.next+logsResumeBatch-1)
+		header, err := r.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(end))
+		if err != nil {
+			return err
+		}
+		if header == nil {
+			return nil // chain rewound, retry with the next head
+		}
+		logs, err := r.sys.NewRangeFilter(int64(r.next), int64(end), r.addresses, r.topics).Logs(ctx)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if r.skip != nil && log.BlockNumber == r.next && log.Index <= uint(*r.skip) {
+				continue
+			}
+			index := hexutil.Uint(log.Index)
+			cursor := &LogCursor{BlockHash: log.BlockHash, BlockNumber: hexutil.Uint64(log.BlockNumber), LogIndex: &index}
+			if err := notify(&LogsNotification{Type: LogsNotificationLog, Log: log, Cursor: cursor}); err != nil {
+				return err
+			}
+		}
+		r.last, r.next, r.skip = header, end+1, nil
+	}
+	return nil
+}
+
+// resumableLogs creates a resumable log subscription, delivering the logs from
+// the cursor or starting block of the criteria and then the new ones.
+func (api *FilterAPI) resumableLogs(ctx context.Context, notifier *rpc.Notifier, crit LogsCriteria) (*rpc.Subscription, error) {
+	resumer, err := newLogResumer(ctx, api.sys, crit)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		rpcSub        = notifier.CreateSubscription()
+		wake, headSub = SubscribeChainWakeups(api.sys.backend.SubscribeChainEvent)
+	)
+	go func() {
+		defer headSub.Unsubscribe()
+
+		notify := func(n *LogsNotification) error {
+			return notifier.Notify(rpcSub.ID, n)
+		}
+		for {
+			if err := resumer.catchUp(context.Background(), notify, rpcSub.Err()); err != nil {
+				notify(&LogsNotification{Type: LogsNotificationError, Error: err.Error()})
+				return
+			}
+			select {
+			case <-wake:
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
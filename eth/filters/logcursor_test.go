@@ -0,0 +1,192 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// logPosition is the block and index of a delivered log.
+type logPosition struct {
+	number uint64
+	index  uint
+}
+
+// expectLogNotifications reads the expected items from a resumable subscription,
+// the positions flagged in reorgs are expected to announce a reorg at the block.
+func expectLogNotifications(t *testing.T, ch chan *LogsNotification, want []logPosition, reorgs map[int]bool) *LogCursor {
+	t.Helper()
+
+	var cursor *LogCursor
+	for i, pos := range want {
+		select {
+		case n := <-ch:
+			if reorgs[i] {
+				if n.Type != LogsNotificationReorg || uint64(n.Cursor.BlockNumber) != pos.number {
+					t.Fatalf("item %d: want reorg at #%d, have %s at #%d", i, pos.number, n.Type, n.Cursor.BlockNumber)
+				}
+			} else {
+				if n.Type != LogsNotificationLog || n.Log.BlockNumber != pos.number || n.Log.Index != pos.index {
+					t.Fatalf("item %d: want log %d of #%d, have %s %+v", i, pos.index, pos.number, n.Type, n.Log)
+				}
+				if n.Cursor.BlockHash != n.Log.BlockHash || uint(*n.Cursor.LogIndex) != n.Log.Index {
+					t.Fatalf("item %d: cursor mismatch %+v", i, n.Cursor)
+				}
+			}
+			cursor = n.Cursor
+		case <-time.After(5 * time.Second):
+			t.Fatalf("item %d: timeout waiting for %+v", i, pos)
+		}
+	}
+	select {
+	case n := <-ch:
+		t.Fatalf("unexpected item %+v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return cursor
+}
+
+func TestResumableLogs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{LogResumeRange: 10})
+		key, _       = crypto.GenerateKey()
+		sender       = crypto.PubkeyToAddress(key.PublicKey)
+		emitter      = common.HexToAddress("0xee")
+		signer       = types.LatestSigner(params.TestChainConfig)
+		gspec        = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:  {Balance: big.NewInt(params.Ether)},
+				emitter: {Code: []byte{0x60, 0x00, 0x60, 0x00, 0xa0, 0x00}}, // LOG0(0, 0)
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	genesis, err := gspec.Commit(db, triedb.NewDatabase(db, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// emit adds transactions emitting a log each to the block
+	emit := func(n int) func(int, *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			gen.SetCoinbase(common.Address{byte(n)})
+			for j := 0; j < n; j++ {
+				tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(sender), emitter, new(big.Int), 50000, gen.BaseFee(), nil), signer, key)
+				gen.AddTx(tx)
+			}
+		}
+	}
+	write := func(blocks []*types.Block, receipts []types.Receipts) {
+		for i, block := range blocks {
+			rawdb.WriteBlock(db, block)
+			rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+			rawdb.WriteHeadBlockHash(db, block.Hash())
+			rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+		}
+	}
+	chain, receipts := core.GenerateChain(gspec.Config, genesis, ethash.NewFaker(), db, 10, emit(1))
+	write(chain, receipts)
+	backend.startFilterMaps(0, true, filtermaps.DefaultParams)
+	defer backend.stopFilterMaps()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", NewFilterAPI(sys)); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	subscribe := func(crit map[string]interface{}) chan *LogsNotification {
+		ch := make(chan *LogsNotification, 100)
+		sub, err := client.EthSubscribe(context.Background(), ch, "logs", crit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(sub.Unsubscribe)
+		return ch
+	}
+	// Backfill from a past block, then follow a reorg replacing blocks 7-10
+	live := subscribe(map[string]interface{}{"fromBlock": "0x3", "resume": true, "address": emitter})
+	expectLogNotifications(t, live, []logPosition{{3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0}, {8, 0}, {9, 0}, {10, 0}}, nil)
+
+	fork, forkReceipts := core.GenerateChain(gspec.Config, chain[5], ethash.NewFaker(), db, 6, emit(2))
+	write(fork, forkReceipts)
+	backend.chainFeed.Send(core.ChainEvent{Header: fork[len(fork)-1].Header()})
+
+	want := []logPosition{{6, 0}}
+	for n := uint64(7); n <= 12; n++ {
+		want = append(want, logPosition{n, 0}, logPosition{n, 1})
+	}
+	cursor := expectLogNotifications(t, live, want, map[int]bool{0: true})
+	if cursor.BlockHash != fork[5].Hash() || uint(*cursor.LogIndex) != 1 {
+		t.Fatalf("unexpected final cursor %+v", cursor)
+	}
+	// Resuming at a reorged log announces the reorg first
+	stale := subscribe(map[string]interface{}{"cursor": map[string]interface{}{"blockHash": chain[7].Hash(), "logIndex": "0x0"}})
+	expectLogNotifications(t, stale, want, map[int]bool{0: true})
+
+	// Resuming in the middle of a block delivers its remaining logs
+	index := hexutil.Uint(0)
+	resumed := subscribe(map[string]interface{}{"cursor": &LogCursor{BlockHash: fork[2].Hash(), LogIndex: &index}})
+	expectLogNotifications(t, resumed, want[6:], nil)
+
+	// Resuming after a block delivers the following ones
+	resumed = subscribe(map[string]interface{}{"cursor": &LogCursor{BlockHash: fork[4].Hash()}})
+	expectLogNotifications(t, resumed, want[11:], nil)
+
+	// Criteria without cursor or resume flag keep the plain subscription
+	plain := make(chan types.Log, 10)
+	sub, err := client.EthSubscribe(context.Background(), plain, "logs", map[string]interface{}{"fromBlock": "0x0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	select {
+	case log := <-plain:
+		t.Fatalf("unexpected backfilled log %+v", log)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Unknown cursors are rejected
+	ch := make(chan *LogsNotification)
+	if _, err := client.EthSubscribe(context.Background(), ch, "logs", map[string]interface{}{"cursor": map[string]interface{}{"blockHash": common.Hash{1}}}); err == nil {
+		t.Fatal("expected error for unknown cursor")
+	}
+	// Backfills beyond the resume range are rejected
+	if _, err := client.EthSubscribe(context.Background(), ch, "logs", map[string]interface{}{"fromBlock": "earliest", "resume": true}); err == nil {
+		t.Fatal("expected error for backfill beyond the resume range")
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// maxDecodedStorageSlots is the maximum number of storage slots of a
+	// contract which are scanned for mapping keys.
+	maxDecodedStorageSlots = 100_000
+
+	// maxDecodedArrayItems is the maximum total number of array items decoded
+	// from the storage of a contract, and the maximum number of slots read for
+	// a byte string.
+	maxDecodedArrayItems = 1024
+
+	// maxDecodedDepth is the maximum nesting depth of decoded values.
+	maxDecodedDepth = 64
+
+	// maxMappingValueSlots is the maximum distance between a storage slot and
+	// the base slot of the mapping value it belongs to which is searched when
+	// recovering mapping keys.
+	maxMappingValueSlots = 16
+)
+
+// StorageLayout is the storage layout of a contract, as emitted by the Solidity
+// compiler with the storageLayout output selection.
+type StorageLayout struct {
+	Storage []StorageLayoutVariable       `json:"storage"`
+	Types   map[string]*StorageLayoutType `json:"types"`
+}
+
+// StorageLayoutVariable is a state variable, or a struct member, of a storage
+// layout.
+type StorageLayoutVariable struct {
+	Label  string `json:"label"`
+	Offset uint64 `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// StorageLayoutType describes how a type of a storage layout is encoded.
+type StorageLayoutType struct {
+	Encoding      string                  `json:"encoding"` // inplace, mapping, dynamic_array or bytes
+	Label         string                  `json:"label"`
+	NumberOfBytes string                  `json:"numberOfBytes"`
+	Key           string                  `json:"key,omitempty"`     // Key type of mappings
+	Value         string                  `json:"value,omitempty"`   // Value type of mappings
+	Base          string                  `json:"base,omitempty"`    // Item type of arrays
+	Members       []StorageLayoutVariable `json:"members,omitempty"` // Members of structs
+}
+
+// DecodedStorage is the result of a debug_getContractStorageDecoded API call.
+type DecodedStorage struct {
+	Variables map[string]interface{} `json:"variables"`
+	// Incomplete is set if the storage was too large to be scanned for mapping
+	// keys, or arrays were truncated.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// mappingEntry is an entry of a mapping, recovered from the preimage of the
+// slot holding its value.
+type mappingEntry struct {
+	key  []byte
+	slot common.Hash
+}
+
+// storageDecoder decodes the storage of a contract according to its layout.
+type storageDecoder struct {
+	layout     *StorageLayout
+	read       func(slot common.Hash) common.Hash
+	entries    map[common.Hash][]mappingEntry // Known entries by mapping slot
+	slots      map[common.Hash][]string       // Variables stored in each decoded slot
+	incomplete bool
+
+	inplace map[string]bool // Inplace types being decoded since the last mapping or dynamic array
+	depth   int             // Nesting depth of the value being decoded
+	items   int             // Number of array items decoded so far
+}
+
+func newStorageDecoder(layout *StorageLayout, read func(common.Hash) common.Hash, entries map[common.Hash][]mappingEntry) *storageDecoder {
+	return &storageDecoder{
+		layout:  layout,
+		read:    read,
+		entries: entries,
+		slots:   make(map[common.Hash][]string),
+		inplace: make(map[string]bool),
+	}
+}
+
+// decode decodes all state variables of the layout.
+func (d *storageDecoder) decode() (map[string]interface{}, error) {
+	return d.decodeMembers(d.layout.Storage, common.Hash{}, "")
+}
+
+// decodeMembers decodes a list of variables, located relative to base.
+func (d *storageDecoder) decodeMembers(vars []StorageLayoutVariable, base common.Hash, prefix string) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(vars))
+	for _, v := range vars {
+		offset, err := uint256.FromDecimal(v.Slot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot %q of %s", v.Slot, v.Label)
+		}
+		if v.Offset >= 32 {
+			return nil, fmt.Errorf("invalid offset %d of %s", v.Offset, v.Label)
+		}
+		value, err := d.decodeValue(v.Type, addSlot(base, offset), v.Offset, prefix+v.Label)
+		if err != nil {
+			return nil, err
+		}
+		res[v.Label] = value
+	}
+	return res, nil
+}
+
+// decodeValue decodes a value of the given type stored at slot.
+//
+// Structs and static arrays are stored in place, so an inplace type containing
+// itself, other than through a mapping or a dynamic array, would never end and
+// is rejected. Values nested through mappings and dynamic arrays are bounded by
+// the stored data, but are limited to maxDecodedDepth levels nevertheless.
+func (d *storageDecoder) decodeValue(typ string, slot common.Hash, offset uint64, path string) (interface{}, error) {
+	t, err := d.typeOf(typ)
+	if err != nil {
+		return nil, err
+	}
+	if d.depth >= maxDecodedDepth {
+		return nil, fmt.Errorf("value %s nested too deeply", path)
+	}
+	d.depth++
+	defer func() { d.depth-- }()
+
+	switch t.Encoding {
+	case "inplace":
+		if len(t.Members) > 0 || t.Base != "" {
+			if d.inplace[typ] {
+				return nil, fmt.Errorf("recursive type %s", typ)
+			}
+			d.inplace[typ] = true
+			defer delete(d.inplace, typ)
+		}
+		switch {
+		case len(t.Members) > 0:
+			return d.decodeMembers(t.Members, slot, path+".")
+		case t.Base != "":
+			n, err := staticArrayLength(typ)
+			if err != nil {
+				return nil, err
+			}
+			return d.decodeArray(t.Base, slot, n, path)
+		}
+		size, err := t.size()
+		if err != nil {
+			return nil, err
+		}
+		if offset+size > 32 {
+			return nil, fmt.Errorf("value %s exceeds its slot", path)
+		}
+		word := d.readSlot(slot, path)
+		return formatStorageValue(typ, word[32-offset-size:32-offset]), nil
+
+	case "bytes":
+		return d.decodeBytes(typ, slot, path), nil
+
+	case "dynamic_array":
+		length := new(big.Int).SetBytes(d.readSlot(slot, path).Bytes())
+		n := uint64(maxDecodedArrayItems)
+		if length.IsUint64() && length.Uint64() <= n {
+			n = length.Uint64()
+		} else {
+			d.incomplete = true
+		}
+		defer d.indirect()()
+		return d.decodeArray(t.Base, crypto.Keccak256Hash(slot.Bytes()), n, path)
+
+	case "mapping":
+		key, err := d.typeOf(t.Key)
+		if err != nil {
+			return nil, err
+		}
+		defer d.indirect()()
+
+		res := make(map[string]interface{})
+		for _, entry := range d.entries[slot] {
+			name, ok := formatMappingKey(t.Key, key, entry.key)
+			if !ok {
+				continue
+			}
+			value, err := d.decodeValue(t.Value, entry.slot, 0, path+"["+name+"]")
+			if err != nil {
+				return nil, err
+			}
+			res[name] = value
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("unknown encoding %q of type %s", t.Encoding, typ)
+}
+
+// indirect starts decoding the values behind a mapping or a dynamic array, which
+// are stored at hashed slots and may contain the inplace types being decoded.
+// The returned function restores the types being decoded.
+func (d *storageDecoder) indirect() func() {
+	inplace := d.inplace
+	d.inplace = make(map[string]bool)
+	return func() { d.inplace = inplace }
+}
+
+// decodeArray decodes n items of an array starting at slot. Items of value types
+// are packed into slots, other items start at a new slot. The array is cut short
+// once maxDecodedArrayItems items were decoded in total, so nested arrays can't
+// multiply the limit.
+func (d *storageDecoder) decodeArray(base string, slot common.Hash, n uint64, path string) ([]interface{}, error) {
+	t, err := d.typeOf(base)
+	if err != nil {
+		return nil, err
+	}
+	size, err := t.size()
+	if err != nil {
+		return nil, err
+	}
+	if left := uint64(maxDecodedArrayItems - d.items); n > left {
+		n, d.incomplete = left, true
+	}
+	packed := t.Encoding == "inplace" && len(t.Members) == 0 && t.Base == "" && size < 32
+	res := make([]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		var (
+			itemSlot   common.Hash
+			itemOffset uint64
+		)
+		if packed {
+			perSlot := 32 / size
+			itemSlot, itemOffset = addSlot(slot, uint256.NewInt(i/perSlot)), (i%perSlot)*size
+		} else {
+			itemSlot = addSlot(slot, uint256.NewInt(i*slotsOf(size)))
+		}
+		if d.items >= maxDecodedArrayItems {
+			d.incomplete = true
+			return res[:i], nil
+		}
+		d.items++
+		if res[i], err = d.decodeValue(base, itemSlot, itemOffset, path+"["+strconv.FormatUint(i, 10)+"]"); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// decodeBytes decodes a bytes or string value. Short values are stored in the
+// slot itself along with twice their length, long values in consecutive slots
+// starting at the hash of the slot, which holds twice their length plus one.
+func (d *storageDecoder) decodeBytes(typ string, slot common.Hash, path string) interface{} {
+	word := d.readSlot(slot, path)
+	var data []byte
+	if word[31]&1 == 0 {
+		length := min(int(word[31]/2), 31)
+		data = word[:length]
+	} else {
+		length := new(big.Int).Rsh(new(big.Int).SetBytes(word.Bytes()), 1)
+		n := uint64(maxDecodedArrayItems * 32)
+		if length.IsUint64() && length.Uint64() <= n {
+			n = length.Uint64()
+		} else {
+			d.incomplete = true
+		}
+		start := crypto.Keccak256Hash(slot.Bytes())
+		for i := uint64(0); uint64(len(data)) < n; i++ {
+			chunk := d.readSlot(addSlot(start, uint256.NewInt(i)), path)
+			data = append(data, chunk[:min(32, n-uint64(len(data)))]...)
+		}
+	}
+	if strings.HasPrefix(typ, "t_string") && utf8.Valid(data) {
+		return string(data)
+	}
+	return hexutil.Bytes(data)
+}
+
+// readSlot reads a storage slot and records the variable stored in it.
+func (d *storageDecoder) readSlot(slot common.Hash, path string) common.Hash {
+	d.slots[slot] = append(d.slots[slot], path)
+	return d.read(slot)
+}
+
+func (d *storageDecoder) typeOf(typ string) (*StorageLayoutType, error) {
+	t, ok := d.layout.Types[typ]
+	if !ok || t == nil {
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+	return t, nil
+}
+
+// size returns the number of bytes occupied by values of the type.
+func (t *StorageLayoutType) size() (uint64, error) {
+	size, err := strconv.ParseUint(t.NumberOfBytes, 10, 64)
+	if err != nil || size == 0 {
+		return 0, fmt.Errorf("invalid size %q of type %s", t.NumberOfBytes, t.Label)
+	}
+	return size, nil
+}
+
+// staticArrayLength parses the length of a static array from its type identifier,
+// e.g. t_array(t_uint256)3_storage.
+func staticArrayLength(typ string) (uint64, error) {
+	end := strings.LastIndex(typ, "_")
+	start := strings.LastIndex(typ, ")")
+	if start < 0 || end <= start {
+		return 0, fmt.Errorf("invalid array type %s", typ)
+	}
+	return strconv.ParseUint(typ[start+1:end], 10, 64)
+}
+
+// slotsOf returns the number of slots occupied by a value of the given size.
+func slotsOf(size uint64) uint64 {
+	return (size + 31) / 32
+}
+
+// addSlot returns slot+offset, wrapping around at 2^256.
+func addSlot(slot common.Hash, offset *uint256.Int) common.Hash {
+	return new(uint256.Int).Add(new(uint256.Int).SetBytes32(slot[:]), offset).Bytes32()
+}
+
+// formatStorageValue renders a value type stored in data.
+func formatStorageValue(typ string, data []byte) interface{} {
+	switch {
+	case strings.HasPrefix(typ, "t_bool"):
+		return new(big.Int).SetBytes(data).Sign() != 0
+	case strings.HasPrefix(typ, "t_address"), strings.HasPrefix(typ, "t_contract"):
+		return common.BytesToAddress(data)
+	case strings.HasPrefix(typ, "t_uint"), strings.HasPrefix(typ, "t_enum"):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(typ, "t_int"):
+		v := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+		}
+		return v.String()
+	}
+	return hexutil.Bytes(common.CopyBytes(data))
+}
+
+// formatMappingKey renders the key of a mapping entry. Keys of value types are
+// padded to 32 bytes, string and bytes keys are stored as is.
+func formatMappingKey(typ string, t *StorageLayoutType, key []byte) (string, bool) {
+	if t.Encoding == "bytes" {
+		if strings.HasPrefix(typ, "t_string") && utf8.Valid(key) {
+			return string(key), true
+		}
+		return hexutil.Encode(key), true
+	}
+	size, err := t.size()
+	if err != nil || len(key) != 32 || size > 32 {
+		return "", false
+	}
+	data := key[32-size:]
+	if strings.HasPrefix(typ, "t_bytes") {
+		data = key[:size] // Fixed size byte arrays are right padded
+	}
+	switch v := formatStorageValue(typ, data).(type) {
+	case common.Address:
+		return v.Hex(), true
+	case hexutil.Bytes:
+		return v.String(), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// discoverMappingKeys recovers the entries of mappings from the preimages of
+// the given storage slots. The value of a mapping entry is stored at the hash
+// of its key concatenated with the mapping slot, so the preimage of the value
+// slot, or of the base slot of multi-slot values, holds both. Mapping slots are
+// resolved recursively to find the entries of nested mappings.
+func discoverMappingKeys(slots []common.Hash, preimage func(common.Hash) []byte) map[common.Hash][]mappingEntry {
+	var (
+		entries = make(map[common.Hash][]mappingEntry)
+		visited = make(map[common.Hash]struct{})
+		queue   = slots
+	)
+	for len(queue) > 0 {
+		slot := queue[0]
+		queue = queue[1:]
+
+		for i := uint64(0); i < maxMappingValueSlots; i++ {
+			base := addSlot(slot, new(uint256.Int).Neg(uint256.NewInt(i)))
+			if _, ok := visited[base]; ok {
+				continue
+			}
+			visited[base] = struct{}{}
+
+			blob := preimage(base)
+			if len(blob) < 32 || crypto.Keccak256Hash(blob) != base {
+				continue
+			}
+			parent := common.BytesToHash(blob[len(blob)-32:])
+			entries[parent] = append(entries[parent], mappingEntry{key: common.CopyBytes(blob[:len(blob)-32]), slot: base})
+			queue = append(queue, parent)
+		}
+	}
+	return entries
+}
+
+// storageSlots returns the storage slots of an account with a known preimage.
+// The returned flag reports whether all slots were retrieved.
+func storageSlots(statedb *state.StateDB, root common.Hash, address common.Address, limit int) ([]common.Hash, bool, error) {
+	storageRoot := statedb.GetStorageRoot(address)
+	if storageRoot == types.EmptyRootHash || storageRoot == (common.Hash{}) {
+		return nil, true, nil
+	}
+	id := trie.StorageTrieID(root, crypto.Keccak256Hash(address.Bytes()), storageRoot)
+	tr, err := trie.NewStateTrie(id, statedb.Database().TrieDB())
+	if err != nil {
+		return nil, false, err
+	}
+	trieIt, err := tr.NodeIterator(nil)
+	if err != nil {
+		return nil, false, err
+	}
+	var (
+		it       = trie.NewIterator(trieIt)
+		slots    []common.Hash
+		complete = true
+	)
+	for it.Next() {
+		if len(slots) >= limit {
+			return slots, false, nil
+		}
+		preimage := tr.GetKey(it.Key)
+		if preimage == nil {
+			complete = false
+			continue
+		}
+		slots = append(slots, common.BytesToHash(preimage))
+	}
+	return slots, complete, it.Err
+}
+
+// decodeStorage decodes the storage of an account according to a storage layout.
+// Mapping entries are recovered from preimages, so they are only available if
+// preimages are recorded by the node.
+func decodeStorage(statedb *state.StateDB, root common.Hash, address common.Address, layout *StorageLayout) (*DecodedStorage, error) {
+	slots, complete, err := storageSlots(statedb, root, address, maxDecodedStorageSlots)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		entries = discoverMappingKeys(slots, statedb.Database().TrieDB().Preimage)
+		read    = func(slot common.Hash) common.Hash { return statedb.GetState(address, slot) }
+		decoder = newStorageDecoder(layout, read, entries)
+	)
+	vars, err := decoder.decode()
+	if err != nil {
+		return nil, err
+	}
+	return &DecodedStorage{Variables: vars, Incomplete: !complete || decoder.incomplete}, nil
+}
+
+// labelStorageRange sets the names of the variables stored in the slots of a
+// storage range according to a storage layout.
+func labelStorageRange(statedb *state.StateDB, address common.Address, layout *StorageLayout, result *StorageRangeResult) error {
+	var slots []common.Hash
+	for _, entry := range result.Storage {
+		if entry.Key != nil {
+			slots = append(slots, *entry.Key)
+		}
+	}
+	var (
+		entries = discoverMappingKeys(slots, statedb.Database().TrieDB().Preimage)
+		read    = func(slot common.Hash) common.Hash { return statedb.GetState(address, slot) }
+		decoder = newStorageDecoder(layout, read, entries)
+	)
+	if _, err := decoder.decode(); err != nil {
+		return err
+	}
+	for hash, entry := range result.Storage {
+		if entry.Key == nil {
+			continue
+		}
+		if names := decoder.slots[*entry.Key]; len(names) > 0 {
+			sort.Strings(names)
+			entry.Variables = names
+			result.Storage[hash] = entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+// storageLayoutTestJSON is the layout of the following contract:
+//
+//	contract Token {
+//	    struct Info { uint128 a; int128 b; }
+//	    address owner; bool paused;
+//	    uint256 totalSupply;
+//	    mapping(address => uint256) balances;
+//	    string name;
+//	    uint64[] values;
+//	    mapping(address => mapping(address => uint256)) allowances;
+//	    string description;
+//	    Info info;
+//	}
+const storageLayoutTestJSON = `{
+	"storage": [
+		{"label": "owner", "offset": 0, "slot": "0", "type": "t_address"},
+		{"label": "paused", "offset": 20, "slot": "0", "type": "t_bool"},
+		{"label": "totalSupply", "offset": 0, "slot": "1", "type": "t_uint256"},
+		{"label": "balances", "offset": 0, "slot": "2", "type": "t_mapping(t_address,t_uint256)"},
+		{"label": "name", "offset": 0, "slot": "3", "type": "t_string_storage"},
+		{"label": "values", "offset": 0, "slot": "4", "type": "t_array(t_uint64)dyn_storage"},
+		{"label": "allowances", "offset": 0, "slot": "5", "type": "t_mapping(t_address,t_mapping(t_address,t_uint256))"},
+		{"label": "description", "offset": 0, "slot": "6", "type": "t_string_storage"},
+		{"label": "info", "offset": 0, "slot": "7", "type": "t_struct(Info)12_storage"}
+	],
+	"types": {
+		"t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+		"t_bool": {"encoding": "inplace", "label": "bool", "numberOfBytes": "1"},
+		"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+		"t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+		"t_int128": {"encoding": "inplace", "label": "int128", "numberOfBytes": "16"},
+		"t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+		"t_string_storage": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+		"t_array(t_uint64)dyn_storage": {"encoding": "dynamic_array", "label": "uint64[]", "numberOfBytes": "32", "base": "t_uint64"},
+		"t_mapping(t_address,t_uint256)": {"encoding": "mapping", "label": "mapping(address => uint256)", "numberOfBytes": "32", "key": "t_address", "value": "t_uint256"},
+		"t_mapping(t_address,t_mapping(t_address,t_uint256))": {"encoding": "mapping", "label": "mapping(address => mapping(address => uint256))", "numberOfBytes": "32", "key": "t_address", "value": "t_mapping(t_address,t_uint256)"},
+		"t_struct(Info)12_storage": {"encoding": "inplace", "label": "struct Token.Info", "numberOfBytes": "32", "members": [
+			{"label": "a", "offset": 0, "slot": "0", "type": "t_uint128"},
+			{"label": "b", "offset": 16, "slot": "0", "type": "t_int128"}
+		]}
+	}
+}`
+
+func TestDecodeStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mdb       = rawdb.NewMemoryDatabase()
+		tdb       = triedb.NewDatabase(mdb, &triedb.Config{Preimages: true})
+		db        = state.NewDatabase(tdb, nil)
+		sdb, _    = state.New(types.EmptyRootHash, db)
+		addr      = common.Address{0x01}
+		owner     = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		spender   = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		preimages = make(map[common.Hash][]byte)
+		long      = strings.Repeat("a long token description ", 3)
+	)
+	slot := func(n int64) common.Hash { return common.BigToHash(big.NewInt(n)) }
+	set := func(slot common.Hash, value common.Hash) {
+		sdb.SetState(addr, slot, value)
+		preimages[crypto.Keccak256Hash(slot.Bytes())] = slot.Bytes()
+	}
+	entry := func(key []byte, parent common.Hash) common.Hash {
+		preimage := append(common.LeftPadBytes(key, 32), parent.Bytes()...)
+		hash := crypto.Keccak256Hash(preimage)
+		preimages[hash] = preimage
+		return hash
+	}
+	// owner and paused packed into slot 0
+	var word common.Hash
+	copy(word[12:], owner.Bytes())
+	word[11] = 1
+	set(slot(0), word)
+	set(slot(1), slot(1000))
+	set(entry(owner.Bytes(), slot(2)), slot(600))
+	set(entry(spender.Bytes(), slot(2)), slot(400))
+
+	// short string: data left aligned, twice the length in the last byte
+	var name common.Hash
+	copy(name[:], "Token")
+	name[31] = 2 * 5
+	set(slot(3), name)
+
+	// dynamic array of packed uint64s: length, then data at keccak(slot)
+	set(slot(4), slot(3))
+	data := crypto.Keccak256Hash(slot(4).Bytes())
+	var values common.Hash
+	values[31], values[23], values[15] = 7, 8, 9
+	set(data, values)
+
+	set(entry(spender.Bytes(), entry(owner.Bytes(), slot(5))), slot(50))
+
+	// long string: twice the length plus one, data at keccak(slot)
+	set(slot(6), slot(int64(2*len(long)+1)))
+	start := crypto.Keccak256Hash(slot(6).Bytes())
+	for i := 0; i*32 < len(long); i++ {
+		set(addSlot(start, uint256.NewInt(uint64(i))), common.BytesToHash(common.RightPadBytes([]byte(long[i*32:min(len(long), (i+1)*32)]), 32)))
+	}
+	// struct with two packed 128 bit members, b is negative
+	var info common.Hash
+	info[31] = 5
+	for i := 0; i < 16; i++ {
+		info[i] = 0xff
+	}
+	set(slot(7), info)
+
+	root, _ := sdb.Commit(0, false, false)
+	tdb.InsertPreimage(preimages)
+	sdb, _ = state.New(root, db)
+
+	var layout StorageLayout
+	if err := json.Unmarshal([]byte(storageLayoutTestJSON), &layout); err != nil {
+		t.Fatal(err)
+	}
+	res, err := decodeStorage(sdb, root, addr, &layout)
+	if err != nil {
+		t.Fatalf("failed to decode storage: %v", err)
+	}
+	if res.Incomplete {
+		t.Error("decoded storage unexpectedly incomplete")
+	}
+	have, _ := json.Marshal(res.Variables)
+	want := `{"allowances":{"` + owner.Hex() + `":{"` + spender.Hex() + `":"50"}},` +
+		`"balances":{"` + owner.Hex() + `":"600","` + spender.Hex() + `":"400"},` +
+		`"description":"` + long + `",` +
+		`"info":{"a":"5","b":"-1"},` +
+		`"name":"Token",` +
+		`"owner":"` + strings.ToLower(owner.Hex()) + `",` +
+		`"paused":true,` +
+		`"totalSupply":"1000",` +
+		`"values":["7","8","9"]}`
+	if string(have) != want {
+		t.Fatalf("decoded storage mismatch:\nhave %s\nwant %s", have, want)
+	}
+
+	// Slots of a storage range are labeled with their variables
+	result, err := storageRangeAt(sdb, root, addr, nil, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := labelStorageRange(sdb, addr, &layout, &result); err != nil {
+		t.Fatalf("failed to label storage: %v", err)
+	}
+	labels := make(map[common.Hash][]string)
+	for _, entry := range result.Storage {
+		labels[*entry.Key] = entry.Variables
+	}
+	for slot, want := range map[common.Hash][]string{
+		slot(0):                       {"owner", "paused"},
+		entry(owner.Bytes(), slot(2)): {"balances[" + owner.Hex() + "]"},
+		data:                          {"values[0]", "values[1]", "values[2]"},
+		entry(spender.Bytes(), entry(owner.Bytes(), slot(5))): {"allowances[" + owner.Hex() + "][" + spender.Hex() + "]"},
+		slot(7): {"info.a", "info.b"},
+	} {
+		if !slices.Equal(labels[slot], want) {
+			t.Errorf("slot %x: labels mismatch, have %v, want %v", slot, labels[slot], want)
+		}
+	}
+}
+
+// Tests that types containing themselves are rejected instead of recursing
+// forever, while structs nested through dynamic arrays are decoded.
+func TestDecodeStorageRecursive(t *testing.T) {
+	t.Parallel()
+
+	decode := func(layout string, read func(common.Hash) common.Hash) error {
+		var l StorageLayout
+		if err := json.Unmarshal([]byte(layout), &l); err != nil {
+			t.Fatalf("failed to parse layout: %v", err)
+		}
+		_, err := newStorageDecoder(&l, read, nil).decode()
+		return err
+	}
+	zero := func(common.Hash) common.Hash { return common.Hash{} }
+	one := func(common.Hash) common.Hash { return common.BigToHash(big.NewInt(1)) }
+
+	// struct S { uint256 a; S s; } can't be compiled, but can be specified
+	recursive := `{
+		"storage": [{"label": "s", "offset": 0, "slot": "0", "type": "t_struct(S)"}],
+		"types": {
+			"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+			"t_struct(S)": {"encoding": "inplace", "label": "struct S", "numberOfBytes": "64", "members": [
+				{"label": "a", "offset": 0, "slot": "0", "type": "t_uint256"},
+				{"label": "s", "offset": 0, "slot": "1", "type": "t_struct(S)"}
+			]}
+		}
+	}`
+	if err := decode(recursive, zero); err == nil || !strings.Contains(err.Error(), "recursive type") {
+		t.Fatalf("unexpected error for recursive type: %v", err)
+	}
+	// struct Node { uint256 value; Node[] children; }
+	tree := `{
+		"storage": [{"label": "root", "offset": 0, "slot": "0", "type": "t_struct(Node)"}],
+		"types": {
+			"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+			"t_array(t_struct(Node))dyn_storage": {"encoding": "dynamic_array", "label": "struct Node[]", "numberOfBytes": "32", "base": "t_struct(Node)"},
+			"t_struct(Node)": {"encoding": "inplace", "label": "struct Node", "numberOfBytes": "64", "members": [
+				{"label": "value", "offset": 0, "slot": "0", "type": "t_uint256"},
+				{"label": "children", "offset": 0, "slot": "1", "type": "t_array(t_struct(Node))dyn_storage"}
+			]}
+		}
+	}`
+	if err := decode(tree, zero); err != nil {
+		t.Fatalf("failed to decode tree: %v", err)
+	}
+	// Every node having a child nests without end
+	if err := decode(tree, one); err == nil || !strings.Contains(err.Error(), "nested too deeply") {
+		t.Fatalf("unexpected error for endless tree: %v", err)
+	}
+}
+
+// Tests that the item limit applies to all arrays together, so nested static
+// arrays don't multiply it.
+func TestDecodeStorageNestedArrayLimit(t *testing.T) {
+	t.Parallel()
+
+	var layout StorageLayout
+	err := json.Unmarshal([]byte(`{
+		"storage": [{"label": "grid", "offset": 0, "slot": "0", "type": "t_array(t_array(t_uint256)1024_storage)1024_storage"}],
+		"types": {
+			"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+			"t_array(t_uint256)1024_storage": {"encoding": "inplace", "label": "uint256[1024]", "numberOfBytes": "32768", "base": "t_uint256"},
+			"t_array(t_array(t_uint256)1024_storage)1024_storage": {"encoding": "inplace", "label": "uint256[1024][1024]", "numberOfBytes": "33554432", "base": "t_array(t_uint256)1024_storage"}
+		}
+	}`), &layout)
+	if err != nil {
+		t.Fatalf("failed to parse layout: %v", err)
+	}
+	var reads int
+	decoder := newStorageDecoder(&layout, func(common.Hash) common.Hash {
+		reads++
+		return common.Hash{}
+	}, nil)
+	if _, err := decoder.decode(); err != nil {
+		t.Fatalf("failed to decode storage: %v", err)
+	}
+	if !decoder.incomplete {
+		t.Error("truncated storage not reported incomplete")
+	}
+	if reads > maxDecodedArrayItems {
+		t.Errorf("too many slots read: have %d, want at most %d", reads, maxDecodedArrayItems)
+	}
+}
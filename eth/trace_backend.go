@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// chainTraceBackend implements tracers.Backend on top of a bare blockchain,
+// without the networking and RPC services of a running node.
+type chainTraceBackend struct {
+	eth *Ethereum
+}
+
+// NewChainTraceBackend creates a tracing backend for the given chain, so blocks
+// can be traced straight from the database. The historical states are obtained
+// the same way as by a running node.
+func NewChainTraceBackend(chain *core.BlockChain, db ethdb.Database) tracers.Backend {
+	return &chainTraceBackend{eth: &Ethereum{blockchain: chain, chainDb: db}}
+}
+
+func (b *chainTraceBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.eth.blockchain.GetHeaderByHash(hash), nil
+}
+
+func (b *chainTraceBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	switch number {
+	case rpc.LatestBlockNumber:
+		return b.eth.blockchain.CurrentBlock(), nil
+	case rpc.PendingBlockNumber, rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+		return nil, errors.New("block tag not supported")
+	}
+	return b.eth.blockchain.GetHeaderByNumber(uint64(number.Int64())), nil
+}
+
+func (b *chainTraceBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return b.eth.blockchain.GetBlockByHash(hash), nil
+}
+
+func (b *chainTraceBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	header, err := b.HeaderByNumber(ctx, number)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
+}
+
+func (b *chainTraceBackend) GetTransaction(txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64) {
+	lookup, tx := b.eth.blockchain.GetTransactionLookup(txHash)
+	if lookup == nil || tx == nil {
+		return false, nil, common.Hash{}, 0, 0
+	}
+	return true, tx, lookup.BlockHash, lookup.BlockIndex, lookup.Index
+}
+
+func (b *chainTraceBackend) TxIndexDone() bool {
+	return b.eth.blockchain.TxIndexDone()
+}
+
+func (b *chainTraceBackend) RPCGasCap() uint64 {
+	return ethconfig.Defaults.RPCGasCap
+}
+
+func (b *chainTraceBackend) ChainConfig() *params.ChainConfig {
+	return b.eth.blockchain.Config()
+}
+
+func (b *chainTraceBackend) Engine() consensus.Engine {
+	return b.eth.blockchain.Engine()
+}
+
+func (b *chainTraceBackend) ChainDb() ethdb.Database {
+	return b.eth.chainDb
+}
+
+func (b *chainTraceBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	return b.eth.stateAtBlock(ctx, block, reexec, base, readOnly, preferDisk)
+}
+
+func (b *chainTraceBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*types.Transaction, vm.BlockContext, *state.StateDB, tracers.StateReleaseFunc, error) {
+	return b.eth.stateAtTransaction(ctx, block, txIndex, reexec)
+}
+
+func (b *chainTraceBackend) HistoricalRPCService() *rpc.Client {
+	return nil
+}
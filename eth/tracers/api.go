@@ -26,14 +26,17 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -93,14 +96,38 @@ type Backend interface {
 	HistoricalRPCService() *rpc.Client
 }
 
+// traceCacheBackend is implemented by backends offering a persistent cache for
+// block trace results.
+type traceCacheBackend interface {
+	TraceCache() *TraceCache
+}
+
+// errorRegistryBackend is implemented by backends offering a registry of custom
+// errors to decode revert reasons with.
+type errorRegistryBackend interface {
+	ErrorRegistry() *abi.ErrorRegistry
+}
+
 // API is the collection of tracing APIs exposed over the private debugging endpoint.
 type API struct {
-	backend Backend
+	backend    Backend
+	cache      *TraceCache        // Optional cache of block trace results
+	errors     *abi.ErrorRegistry // Optional registry of custom errors
+	errorsHash common.Hash        // Fingerprint of the error registry, part of the trace cache keys
+	traceSubs  atomic.Int32       // Number of active blockTraces subscriptions
 }
 
 // NewAPI creates a new API definition for the tracing methods of the Ethereum service.
 func NewAPI(backend Backend) *API {
-	return &API{backend: backend}
+	api := &API{backend: backend}
+	if b, ok := backend.(traceCacheBackend); ok {
+		api.cache = b.TraceCache()
+	}
+	if b, ok := backend.(errorRegistryBackend); ok {
+		api.errors = b.ErrorRegistry()
+		api.errorsHash = api.errors.Fingerprint()
+	}
+	return api
 }
 
 // chainContext constructs the context reader which is used by the evm for reading
@@ -240,6 +267,72 @@ func (api *API) TraceChain(ctx context.Context, start, end rpc.BlockNumber, conf
 	return sub, nil
 }
 
+// TraceRange traces the blocks between start and end, both inclusive, in the
+// same way as TraceChain and hands the json-encoded results to fn in block
+// order. Blocks without transactions are skipped, except the end block. Tracing
+// is aborted if the context is cancelled or fn returns an error.
+func (api *API) TraceRange(ctx context.Context, start, end uint64, config *TraceConfig, fn func(number uint64, hash common.Hash, result []byte) error) error {
+	// The genesis block has no transactions, start tracing on top of it
+	if start == 0 {
+		start = 1
+	}
+	if start > end {
+		return fmt.Errorf("end block (#%d) needs to come after start block (#%d)", end, start)
+	}
+	from, err := api.blockByNumber(ctx, rpc.BlockNumber(start-1))
+	if err != nil {
+		return err
+	}
+	to, err := api.blockByNumber(ctx, rpc.BlockNumber(end))
+	if err != nil {
+		return err
+	}
+	var (
+		closed = make(chan error)
+		once   sync.Once
+		abort  = func() { once.Do(func() { close(closed) }) }
+		done   = make(chan struct{})
+	)
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			abort()
+		case <-done:
+		}
+	}()
+	var (
+		resCh  = api.traceChain(from, to, config, closed)
+		last   uint64
+		failed error
+	)
+	// Keep draining the results after a failure so the tracer can shut down
+	for res := range resCh {
+		if failed != nil {
+			continue
+		}
+		blob, err := json.Marshal(res)
+		if err == nil {
+			err = fn(uint64(res.Block), res.Hash, blob)
+		}
+		if err != nil {
+			failed = err
+			abort()
+			continue
+		}
+		last = uint64(res.Block)
+	}
+	switch {
+	case failed != nil:
+		return failed
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case last != end:
+		return fmt.Errorf("chain tracing stopped before block #%d", end)
+	}
+	return nil
+}
+
 // traceChain configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The tracing chain range includes
 // the end block but excludes the start one. The return value will be one item per
@@ -465,7 +558,7 @@ func (api *API) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber,
 		}
 	}
 
-	return api.traceBlock(ctx, block, config)
+	return api.traceBlockCached(ctx, block, config)
 }
 
 // TraceBlockByHash returns the structured logs created during the execution of
@@ -489,7 +582,7 @@ func (api *API) TraceBlockByHash(ctx context.Context, hash common.Hash, config *
 		}
 	}
 
-	return api.traceBlock(ctx, block, config)
+	return api.traceBlockCached(ctx, block, config)
 }
 
 // TraceBlock returns the structured logs created during the execution of EVM
@@ -611,6 +704,45 @@ func (api *API) StandardTraceBadBlockToFile(ctx context.Context, hash common.Has
 	return api.standardTraceBlockToFile(ctx, block, config)
 }
 
+// traceBlockCached traces the given block like traceBlock, serving the results
+// from the trace cache if enabled. Only results without any failed transaction
+// trace are cached.
+func (api *API) traceBlockCached(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
+	if api.cache == nil {
+		return api.traceBlock(ctx, block, config)
+	}
+	name, confHash, err := traceCacheConfig(config, api.errorsHash)
+	if err != nil {
+		return api.traceBlock(ctx, block, config)
+	}
+	if blob, ok := api.cache.Get(block.NumberU64(), block.Hash(), name, confHash); ok {
+		var cached []struct {
+			TxHash common.Hash     `json:"txHash"`
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(blob, &cached); err == nil {
+			results := make([]*txTraceResult, len(cached))
+			for i, res := range cached {
+				results[i] = &txTraceResult{TxHash: res.TxHash, Result: res.Result}
+			}
+			return results, nil
+		}
+	}
+	results, err := api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if res.Error != "" {
+			return results, nil
+		}
+	}
+	if blob, err := json.Marshal(results); err == nil {
+		api.cache.Put(block.NumberU64(), block.Hash(), name, confHash, blob)
+	}
+	return results, nil
+}
+
 // traceBlock configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
@@ -1046,6 +1178,142 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 	return api.traceTx(ctx, tx, msg, new(Context), vmctx, statedb, traceConfig, precompiles)
 }
 
+// TraceCallMany lets you trace a series of calls, packed into simulated blocks
+// the same way as for eth_simulateV1. The calls are executed on top of the state
+// of the given block and of each other, block and state overrides can be set
+// for every simulated block. The traces of all calls are returned, grouped by
+// simulated block. Like eth_simulateV1, the result holds an entry for every
+// simulated block, including the empty ones and those inserted to fill gaps
+// between the requested block numbers.
+func (api *API) TraceCallMany(ctx context.Context, blocks []ethapi.SimBlock, blockNrOrHash *rpc.BlockNumberOrHash, config *TraceConfig) ([][]*txTraceResult, error) {
+	if blockNrOrHash == nil {
+		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &n
+	}
+	var (
+		err   error
+		block *types.Block
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		if number == rpc.PendingBlockNumber {
+			return nil, errors.New("tracing on top of pending is not supported")
+		}
+		block, err = api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if api.backend.ChainConfig().IsOptimismPreBedrock(block.Number()) {
+		return nil, errors.New("l2geth does not have a debug_traceCallMany method")
+	}
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	reexec := defaultTraceReexec
+	if config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	// The timeout applies to the whole series of calls
+	timeout := defaultTraceTimeout
+	if config.Timeout != nil {
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, block, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// The calls are grouped by the number of their simulated block, which is
+	// unique within the simulation
+	var (
+		tracers = make(map[uint64][]*Tracer)
+		results = make(map[uint64][]*txTraceResult)
+	)
+	newTracer := func(header *types.Header, tx *types.Transaction, index int) (*tracing.Hooks, error) {
+		txctx := &Context{
+			BlockNumber: header.Number,
+			TxIndex:     index,
+			TxHash:      tx.Hash(),
+		}
+		tracer, err := api.newTracer(txctx, config)
+		if err != nil {
+			return nil, err
+		}
+		number := header.Number.Uint64()
+		tracers[number] = append(tracers[number], tracer)
+		results[number] = append(results[number], &txTraceResult{TxHash: tx.Hash()})
+		return tracer.Hooks, nil
+	}
+	backend := &simulateBackend{Backend: api.backend, timeout: timeout, errors: api.errors}
+	headers, err := ethapi.SimulateWithTracer(ctx, backend, statedb, block.Header(), blocks, newTracer)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make([][]*txTraceResult, len(headers))
+	for i, header := range headers {
+		number := header.Number.Uint64()
+		for j, tracer := range tracers[number] {
+			res, err := tracer.GetResult()
+			if err != nil {
+				results[number][j].Error = err.Error()
+				continue
+			}
+			results[number][j].Result = res
+		}
+		grouped[i] = results[number]
+		if grouped[i] == nil {
+			grouped[i] = []*txTraceResult{}
+		}
+	}
+	return grouped, nil
+}
+
+// simulateBackend adapts the tracing backend to the needs of the call simulator.
+type simulateBackend struct {
+	Backend
+	timeout time.Duration
+	errors  *abi.ErrorRegistry
+}
+
+func (b *simulateBackend) CurrentHeader() *types.Header {
+	header, _ := b.HeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	return header
+}
+
+func (b *simulateBackend) RPCEVMTimeout() time.Duration {
+	return b.timeout
+}
+
+func (b *simulateBackend) ErrorRegistry() *abi.ErrorRegistry {
+	return b.errors
+}
+
+// newTracer creates the tracer requested by the configuration, defaulting to
+// the struct logger.
+func (api *API) newTracer(txctx *Context, config *TraceConfig) (*Tracer, error) {
+	if config.Tracer == nil {
+		logger := logger.NewStructLogger(config.Config)
+		return &Tracer{
+			Hooks:     logger.Hooks(),
+			GetResult: logger.GetResult,
+			Stop:      logger.Stop,
+		}, nil
+	}
+	if api.errors != nil && txctx != nil {
+		withErrors := *txctx
+		withErrors.ErrorRegistry = api.errors
+		txctx = &withErrors
+	}
+	return DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig, api.backend.ChainConfig())
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -1059,19 +1327,8 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	if config == nil {
 		config = &TraceConfig{}
 	}
-	// Default tracer is the struct logger
-	if config.Tracer == nil {
-		logger := logger.NewStructLogger(config.Config)
-		tracer = &Tracer{
-			Hooks:     logger.Hooks(),
-			GetResult: logger.GetResult,
-			Stop:      logger.Stop,
-		}
-	} else {
-		tracer, err = DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig, api.backend.ChainConfig())
-		if err != nil {
-			return nil, err
-		}
+	if tracer, err = api.newTracer(txctx, config); err != nil {
+		return nil, err
 	}
 	tracingStateDB := state.NewHookedState(statedb, tracer.Hooks)
 	evm := vm.NewEVM(vmctx, tracingStateDB, api.backend.ChainConfig(), vm.Config{Tracer: tracer.Hooks, NoBaseFee: true})
@@ -1113,6 +1370,10 @@ func APIs(backend Backend) []rpc.API {
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
+		},
 	}
 }
 
@@ -0,0 +1,386 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxTraceFilterBlocks is the maximum number of blocks trace_filter is allowed
+// to scan in a single request.
+const maxTraceFilterBlocks = 10000
+
+var errTraceFilterRange = fmt.Errorf("trace_filter block range exceeds %d blocks", maxTraceFilterBlocks)
+
+// Trace types accepted by the replay methods of the parity trace namespace.
+const (
+	traceTypeTrace     = "trace"
+	traceTypeStateDiff = "stateDiff"
+	traceTypeVMTrace   = "vmTrace"
+)
+
+// TraceAPI implements the parity (OpenEthereum) compatible trace namespace on
+// top of the native flatCallTracer and stateDiffTracer.
+type TraceAPI struct {
+	api *API
+}
+
+// NewTraceAPI creates a new API definition for the parity compatible tracing
+// methods of the Ethereum service.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{api: NewAPI(backend)}
+}
+
+// ReplayResult is the result of replaying a transaction with a set of trace
+// types. Trace types which were not requested are left null. VM traces are
+// not supported and always null.
+type ReplayResult struct {
+	Output          hexutil.Bytes   `json:"output"`
+	StateDiff       json.RawMessage `json:"stateDiff"`
+	Trace           json.RawMessage `json:"trace"`
+	VMTrace         json.RawMessage `json:"vmTrace"`
+	TransactionHash *common.Hash    `json:"transactionHash,omitempty"`
+}
+
+// replayConfig assembles the tracer configuration for the requested trace types.
+func replayConfig(traceTypes []string) (*TraceConfig, bool, bool, error) {
+	var wantTrace, wantStateDiff bool
+	for _, typ := range traceTypes {
+		switch typ {
+		case traceTypeTrace:
+			wantTrace = true
+		case traceTypeStateDiff:
+			wantStateDiff = true
+		case traceTypeVMTrace:
+		default:
+			return nil, false, false, fmt.Errorf("unknown trace type %q", typ)
+		}
+	}
+	// The call tracer is always run, the output of the transaction is taken
+	// from its top level frame.
+	tracers := map[string]json.RawMessage{
+		"flatCallTracer": json.RawMessage(`{}`),
+	}
+	if wantStateDiff {
+		tracers["stateDiffTracer"] = json.RawMessage(`{"parity":true}`)
+	}
+	cfg, err := json.Marshal(tracers)
+	if err != nil {
+		return nil, false, false, err
+	}
+	name := "muxTracer"
+	return &TraceConfig{Tracer: &name, TracerConfig: cfg}, wantTrace, wantStateDiff, nil
+}
+
+// replayResult assembles the replay result from the output of the mux tracer.
+func replayResult(raw interface{}, wantTrace, wantStateDiff bool) (*ReplayResult, error) {
+	blob, err := rawResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	var results map[string]json.RawMessage
+	if err := json.Unmarshal(blob, &results); err != nil {
+		return nil, err
+	}
+	var frames []struct {
+		Result *struct {
+			Output hexutil.Bytes `json:"output"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(results["flatCallTracer"], &frames); err != nil {
+		return nil, err
+	}
+	res := new(ReplayResult)
+	if len(frames) > 0 && frames[0].Result != nil {
+		res.Output = frames[0].Result.Output
+	}
+	if res.Output == nil {
+		res.Output = hexutil.Bytes{}
+	}
+	if wantTrace {
+		res.Trace = results["flatCallTracer"]
+	}
+	if wantStateDiff {
+		res.StateDiff = results["stateDiffTracer"]
+	}
+	return res, nil
+}
+
+// ReplayTransaction replays a mined transaction on top of the state it was
+// executed on and returns the requested trace types: "trace" for the flat call
+// traces and "stateDiff" for the modified state. "vmTrace" is accepted but not
+// supported.
+func (api *TraceAPI) ReplayTransaction(ctx context.Context, hash common.Hash, traceTypes []string) (*ReplayResult, error) {
+	config, wantTrace, wantStateDiff, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := api.api.TraceTransaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	return replayResult(raw, wantTrace, wantStateDiff)
+}
+
+// ReplayBlockTransactions replays all transactions of a block and returns the
+// requested trace types for each of them.
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*ReplayResult, error) {
+	config, wantTrace, wantStateDiff, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := api.api.TraceBlockByNumber(ctx, number, config)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*ReplayResult, 0, len(traces))
+	for _, trace := range traces {
+		if trace.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x: %s", trace.TxHash, trace.Error)
+		}
+		res, err := replayResult(trace.Result, wantTrace, wantStateDiff)
+		if err != nil {
+			return nil, err
+		}
+		hash := trace.TxHash
+		res.TransactionHash = &hash
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Call executes the given call on top of the requested block and returns the
+// requested trace types. The block defaults to the latest one.
+func (api *TraceAPI) Call(ctx context.Context, args ethapi.TransactionArgs, traceTypes []string, blockNrOrHash *rpc.BlockNumberOrHash) (*ReplayResult, error) {
+	config, wantTrace, wantStateDiff, err := replayConfig(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	raw, err := api.api.TraceCall(ctx, args, *blockNrOrHash, &TraceCallConfig{TraceConfig: *config})
+	if err != nil {
+		return nil, err
+	}
+	return replayResult(raw, wantTrace, wantStateDiff)
+}
+
+// Transaction returns the flat call traces of a mined transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	raw, err := api.api.TraceTransaction(ctx, hash, flatCallConfig())
+	if err != nil {
+		return nil, err
+	}
+	return decodeFrames(raw)
+}
+
+// Block returns the flat call traces of all transactions in a block.
+func (api *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	traces, err := api.api.TraceBlockByNumber(ctx, number, flatCallConfig())
+	if err != nil {
+		return nil, err
+	}
+	frames := make([]json.RawMessage, 0, len(traces))
+	for _, trace := range traces {
+		if trace.Error != "" {
+			return nil, fmt.Errorf("tracing transaction %#x: %s", trace.TxHash, trace.Error)
+		}
+		items, err := decodeFrames(trace.Result)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, items...)
+	}
+	return frames, nil
+}
+
+// TraceFilterArgs represents the arguments of trace_filter. As in parity,
+// After and Count are plain numbers.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// Filter returns the flat call traces of the given block range matching the
+// sender and recipient filters. A trace matches if its sender is in
+// FromAddress and its recipient is in ToAddress, an empty list matching any
+// address. Blocks are traced one by one, the first After matching traces are
+// skipped and tracing stops as soon as Count traces were collected, so pages
+// of a large range only cost the blocks up to the end of the page.
+//
+// ToBlock defaults to the latest block. Without a FromBlock the range covers
+// the last maxTraceFilterBlocks blocks up to ToBlock.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	to, err := api.resolveBlockNumber(ctx, args.ToBlock, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	var from uint64
+	if args.FromBlock == nil {
+		if to >= maxTraceFilterBlocks {
+			from = to - maxTraceFilterBlocks + 1
+		}
+	} else if from, err = api.resolveBlockNumber(ctx, args.FromBlock, rpc.EarliestBlockNumber); err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("fromBlock is after toBlock")
+	}
+	if to-from >= maxTraceFilterBlocks {
+		return nil, errTraceFilterRange
+	}
+	// The genesis block holds no transactions and is not traceable.
+	if from == 0 {
+		from = 1
+	}
+	var (
+		skip   uint64
+		frames = make([]json.RawMessage, 0)
+	)
+	if args.After != nil {
+		skip = *args.After
+	}
+	if args.Count != nil && *args.Count == 0 {
+		return frames, nil
+	}
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		traces, err := api.Block(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		for _, frame := range traces {
+			ok, err := matchFrame(frame, args.FromAddress, args.ToAddress)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			frames = append(frames, frame)
+			if args.Count != nil && uint64(len(frames)) >= *args.Count {
+				return frames, nil
+			}
+		}
+	}
+	return frames, nil
+}
+
+// resolveBlockNumber resolves a possibly symbolic block number to an absolute one.
+func (api *TraceAPI) resolveBlockNumber(ctx context.Context, number *rpc.BlockNumber, def rpc.BlockNumber) (uint64, error) {
+	if number == nil {
+		number = &def
+	}
+	if *number >= 0 {
+		return uint64(*number), nil
+	}
+	if *number == rpc.EarliestBlockNumber {
+		return 0, nil
+	}
+	header, err := api.api.backend.HeaderByNumber(ctx, *number)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block %v not found", *number)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// matchFrame reports whether the sender and recipient of a flat call frame are
+// in the given address lists. Contract creations match on the created address
+// and selfdestructs on the refund address.
+func matchFrame(frame json.RawMessage, from, to []common.Address) (bool, error) {
+	if len(from) == 0 && len(to) == 0 {
+		return true, nil
+	}
+	var f struct {
+		Action struct {
+			From          *common.Address `json:"from"`
+			To            *common.Address `json:"to"`
+			Address       *common.Address `json:"address"`
+			RefundAddress *common.Address `json:"refundAddress"`
+		} `json:"action"`
+		Result *struct {
+			Address *common.Address `json:"address"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(frame, &f); err != nil {
+		return false, err
+	}
+	sender, recipient := f.Action.From, f.Action.To
+	if f.Action.Address != nil {
+		sender, recipient = f.Action.Address, f.Action.RefundAddress
+	}
+	if recipient == nil && f.Result != nil {
+		recipient = f.Result.Address
+	}
+	match := func(addr *common.Address, list []common.Address) bool {
+		return len(list) == 0 || (addr != nil && slices.Contains(list, *addr))
+	}
+	return match(sender, from) && match(recipient, to), nil
+}
+
+// flatCallConfig returns the trace config running the flatCallTracer.
+func flatCallConfig() *TraceConfig {
+	name := "flatCallTracer"
+	return &TraceConfig{Tracer: &name}
+}
+
+// decodeFrames splits the output of the flatCallTracer into its frames.
+func decodeFrames(raw interface{}) ([]json.RawMessage, error) {
+	blob, err := rawResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	var frames []json.RawMessage
+	if err := json.Unmarshal(blob, &frames); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// rawResult returns the json encoding of a tracer result. Results of native
+// tracers are already encoded, results proxied from the historical backend
+// are not.
+func rawResult(raw interface{}) (json.RawMessage, error) {
+	if blob, ok := raw.(json.RawMessage); ok {
+		return blob, nil
+	}
+	return json.Marshal(raw)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestTraceFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = common.HexToAddress("0xa")
+		b = common.HexToAddress("0xb")
+		c = common.HexToAddress("0xc")
+	)
+	call := json.RawMessage(`{"action":{"from":"` + a.Hex() + `","to":"` + b.Hex() + `","callType":"call"},"type":"call"}`)
+	create := json.RawMessage(`{"action":{"from":"` + a.Hex() + `","init":"0x"},"result":{"address":"` + c.Hex() + `"},"type":"create"}`)
+	suicide := json.RawMessage(`{"action":{"address":"` + b.Hex() + `","refundAddress":"` + c.Hex() + `"},"type":"suicide"}`)
+
+	tests := []struct {
+		frame    json.RawMessage
+		from, to []common.Address
+		want     bool
+	}{
+		{call, nil, nil, true},
+		{call, []common.Address{a}, nil, true},
+		{call, []common.Address{b}, nil, false},
+		{call, nil, []common.Address{b}, true},
+		{call, []common.Address{a}, []common.Address{c}, false},
+		{call, []common.Address{c, a}, []common.Address{b}, true},
+		{create, nil, []common.Address{c}, true},
+		{create, []common.Address{c}, nil, false},
+		{suicide, []common.Address{b}, []common.Address{c}, true},
+		{suicide, []common.Address{a}, nil, false},
+	}
+	for i, tt := range tests {
+		have, err := matchFrame(tt.frame, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if have != tt.want {
+			t.Errorf("test %d: match mismatch, have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+func TestTraceReplayConfig(t *testing.T) {
+	t.Parallel()
+
+	config, wantTrace, wantStateDiff, err := replayConfig([]string{"trace", "vmTrace"})
+	if err != nil {
+		t.Fatalf("failed to assemble config: %v", err)
+	}
+	if !wantTrace || wantStateDiff {
+		t.Fatalf("trace types mismatch, have trace %v stateDiff %v", wantTrace, wantStateDiff)
+	}
+	if *config.Tracer != "muxTracer" || string(config.TracerConfig) != `{"flatCallTracer":{}}` {
+		t.Fatalf("unexpected config %s %s", *config.Tracer, config.TracerConfig)
+	}
+	if _, _, _, err := replayConfig([]string{"memory"}); err == nil {
+		t.Fatal("expected error for unknown trace type")
+	}
+
+	res, err := replayResult(json.RawMessage(`{"flatCallTracer":[{"result":{"output":"0x01"}}],"stateDiffTracer":{}}`), false, true)
+	if err != nil {
+		t.Fatalf("failed to assemble result: %v", err)
+	}
+	if len(res.Output) != 1 || res.Output[0] != 1 || res.Trace != nil || string(res.StateDiff) != `{}` {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestTraceFilterRange(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	backend := newTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewTraceAPI(backend)
+
+	var (
+		from    = rpc.BlockNumber(2)
+		to      = rpc.BlockNumber(1)
+		far     = rpc.BlockNumber(maxTraceFilterBlocks + 1)
+		latest  = rpc.LatestBlockNumber
+		none    = uint64(0)
+		filters = []TraceFilterArgs{
+			{FromBlock: &from, ToBlock: &to},
+			{FromBlock: &to, ToBlock: &far},
+		}
+	)
+	for i, args := range filters {
+		if _, err := api.Filter(context.Background(), args); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+	// Empty blocks hold no traces
+	frames, err := api.Filter(context.Background(), TraceFilterArgs{ToBlock: &latest})
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	if len(frames) != 0 {
+		t.Fatalf("unexpected traces %v", frames)
+	}
+	// An empty filter covers the most recent blocks
+	frames, err = api.Filter(context.Background(), TraceFilterArgs{})
+	if err != nil || len(frames) != 0 {
+		t.Fatalf("unexpected result %v, %v", frames, err)
+	}
+	frames, err = api.Filter(context.Background(), TraceFilterArgs{Count: &none})
+	if err != nil || len(frames) != 0 {
+		t.Fatalf("unexpected result %v, %v", frames, err)
+	}
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: &far, ToBlock: &far}); err == nil {
+		t.Fatal("expected error for missing block")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTraceFilterNestedCall(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		outer  = common.HexToAddress("0x1000")
+		inner  = common.HexToAddress("0x2000")
+		// CALL(gas, inner, 0, 0, 0, 0, 0)
+		outerCode = append(append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, inner.Bytes()...), 0x5a, 0xf1, 0x00)
+		// MSTORE8(0, 1) RETURN(0, 1)
+		innerCode = []byte{0x60, 0x01, 0x60, 0x00, 0x53, 0x60, 0x01, 0x60, 0x00, 0xf3}
+		genesis   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender: {Balance: big.NewInt(params.Ether)},
+				outer:  {Code: outerCode},
+				inner:  {Code: innerCode},
+			},
+		}
+		txHash common.Hash
+	)
+	backend := tracers.NewTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    0,
+			To:       &outer,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, key)
+		b.AddTx(tx)
+		txHash = tx.Hash()
+	})
+	api := tracers.NewTraceAPI(backend)
+
+	type frame struct {
+		Action struct {
+			From     common.Address `json:"from"`
+			To       common.Address `json:"to"`
+			CallType string         `json:"callType"`
+		} `json:"action"`
+		Result struct {
+			Output string `json:"output"`
+		} `json:"result"`
+		Subtraces    int    `json:"subtraces"`
+		TraceAddress []int  `json:"traceAddress"`
+		Type         string `json:"type"`
+	}
+	check := func(name string, frames []json.RawMessage) {
+		if len(frames) != 2 {
+			t.Fatalf("%s: trace count mismatch, have %d, want 2", name, len(frames))
+		}
+		want := []struct {
+			from, to     common.Address
+			output       string
+			subtraces    int
+			traceAddress []int
+		}{
+			{sender, outer, "0x", 1, []int{}},
+			{outer, inner, "0x01", 0, []int{0}},
+		}
+		for i, raw := range frames {
+			var have frame
+			if err := json.Unmarshal(raw, &have); err != nil {
+				t.Fatalf("%s: failed to decode trace %d: %v", name, i, err)
+			}
+			if have.Type != "call" || have.Action.CallType != "call" {
+				t.Errorf("%s: trace %d: type mismatch, have %s/%s", name, i, have.Type, have.Action.CallType)
+			}
+			if have.Action.From != want[i].from || have.Action.To != want[i].to {
+				t.Errorf("%s: trace %d: action mismatch, have %v->%v, want %v->%v", name, i, have.Action.From, have.Action.To, want[i].from, want[i].to)
+			}
+			if have.Result.Output != want[i].output {
+				t.Errorf("%s: trace %d: output mismatch, have %s, want %s", name, i, have.Result.Output, want[i].output)
+			}
+			if have.Subtraces != want[i].subtraces || !slices.Equal(have.TraceAddress, want[i].traceAddress) {
+				t.Errorf("%s: trace %d: position mismatch, have %d %v, want %d %v", name, i, have.Subtraces, have.TraceAddress, want[i].subtraces, want[i].traceAddress)
+			}
+		}
+	}
+	frames, err := api.Transaction(context.Background(), txHash)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	check("transaction", frames)
+
+	frames, err = api.Filter(context.Background(), tracers.TraceFilterArgs{})
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	check("filter", frames)
+
+	frames, err = api.Filter(context.Background(), tracers.TraceFilterArgs{ToAddress: []common.Address{inner}})
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	if len(frames) != 1 {
+		t.Fatalf("trace count mismatch, have %d, want 1", len(frames))
+	}
+}
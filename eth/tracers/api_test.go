@@ -47,6 +47,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/node"
@@ -221,6 +222,10 @@ func (b *testBackend) ChainDb() ethdb.Database {
 	return b.chaindb
 }
 
+func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return b.chain.SubscribeChainEvent(ch)
+}
+
 // teardown releases the associated resources.
 func (b *testBackend) teardown() {
 	b.chain.Stop()
@@ -1323,6 +1328,149 @@ func TestTraceChain(t *testing.T) {
 	}
 }
 
+func TestTraceRange(t *testing.T) {
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, 10, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var traced []uint64
+	err := api.TraceRange(context.Background(), 3, 6, nil, func(number uint64, hash common.Hash, result []byte) error {
+		var res blockTraceResult
+		if err := json.Unmarshal(result, &res); err != nil {
+			return err
+		}
+		if uint64(res.Block) != number || res.Hash != hash || len(res.Traces) != 1 {
+			t.Errorf("block %d: unexpected result %s", number, result)
+		}
+		traced = append(traced, number)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to trace range: %v", err)
+	}
+	if !slices.Equal(traced, []uint64{3, 4, 5, 6}) {
+		t.Fatalf("traced blocks mismatch: have %v", traced)
+	}
+	// Failures of the result handler abort tracing
+	traced = traced[:0]
+	failure := errors.New("write failed")
+	err = api.TraceRange(context.Background(), 1, 10, nil, func(number uint64, hash common.Hash, result []byte) error {
+		traced = append(traced, number)
+		if number == 2 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure {
+		t.Fatalf("error mismatch: have %v, want %v", err, failure)
+	}
+	if !slices.Equal(traced, []uint64{1, 2}) {
+		t.Fatalf("traced blocks mismatch: have %v", traced)
+	}
+	if err := api.TraceRange(context.Background(), 5, 4, nil, nil); err == nil {
+		t.Fatal("expected error for inverted range")
+	}
+}
+
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	backend := newTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var (
+		counter = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		number  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		// Increments slot 0 and returns the new value
+		counterCode = hexutil.Bytes(common.FromHex("0x6000546001018060005560005260206000f3"))
+		// BLOCKNUMBER PUSH1 MSTORE, returns the block number
+		numberCode = hexutil.Bytes(common.FromHex("0x4360005260206000f3"))
+		gas        = hexutil.Uint64(100000)
+	)
+	blocks := []ethapi.SimBlock{
+		{
+			StateOverrides: &override.StateOverride{
+				counter: override.OverrideAccount{Code: &counterCode},
+			},
+			Calls: []ethapi.TransactionArgs{
+				{From: &accounts[0].addr, To: &counter, Gas: &gas},
+				{From: &accounts[0].addr, To: &counter, Gas: &gas},
+			},
+		},
+		// An empty block, followed by a number gap filled with empty blocks
+		{},
+		{
+			BlockOverrides: &override.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(100))},
+			StateOverrides: &override.StateOverride{
+				number: override.OverrideAccount{Code: &numberCode},
+			},
+			Calls: []ethapi.TransactionArgs{
+				{From: &accounts[0].addr, To: &counter, Gas: &gas},
+				{From: &accounts[0].addr, To: &number, Gas: &gas},
+			},
+		},
+	}
+	results, err := api.TraceCallMany(context.Background(), blocks, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	// Every simulated block has an entry, from block 3 on top of the chain up
+	// to the requested block 100
+	want := make([][]uint64, 98)
+	for i := range want {
+		want[i] = []uint64{}
+	}
+	want[0], want[97] = []uint64{1, 2}, []uint64{3, 100}
+	if len(results) != len(want) {
+		t.Fatalf("block count mismatch: have %d, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i] == nil {
+			t.Fatalf("block %d: missing entry", i)
+		}
+		if len(results[i]) != len(want[i]) {
+			t.Fatalf("block %d: call count mismatch: have %d, want %d", i, len(results[i]), len(want[i]))
+		}
+		for j, res := range results[i] {
+			if res.Error != "" {
+				t.Fatalf("block %d call %d: trace failed: %v", i, j, res.Error)
+			}
+			var frame struct {
+				ReturnValue hexutil.Bytes `json:"returnValue"`
+			}
+			if err := json.Unmarshal(res.Result.(json.RawMessage), &frame); err != nil {
+				t.Fatalf("block %d call %d: failed to unmarshal trace: %v", i, j, err)
+			}
+			if have := new(big.Int).SetBytes(frame.ReturnValue).Uint64(); have != want[i][j] {
+				t.Errorf("block %d call %d: output mismatch: have %d, want %d", i, j, have, want[i][j])
+			}
+		}
+	}
+	// Empty simulations are rejected
+	if _, err := api.TraceCallMany(context.Background(), nil, nil, nil); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+}
+
 // newTestMergedBackend creates a post-merge chain
 func newTestMergedBackend(t *testing.T, n int, gspec *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
 	backend := &testBackend{
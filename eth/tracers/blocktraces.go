@@ -0,0 +1,132 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxBlockTracesSubscriptions is the maximum number of concurrent blockTraces
+	// subscriptions, as each of them traces every new block.
+	maxBlockTracesSubscriptions = 8
+
+	// blockTracesTimeout is the time allowed to trace a block for a blockTraces
+	// subscription.
+	blockTracesTimeout = time.Minute
+)
+
+// chainEventBackend is implemented by backends announcing the new canonical
+// heads.
+type chainEventBackend interface {
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// blockTracesNotification is an item of the blockTraces subscription, either
+// the traces of a new canonical block or a reorg announcement referencing the
+// last block shared by the old and new chain.
+type blockTracesNotification struct {
+	Type        string           `json:"type"`
+	BlockHash   common.Hash      `json:"blockHash"`
+	BlockNumber hexutil.Uint64   `json:"blockNumber"`
+	Traces      []*txTraceResult `json:"traces,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// BlockTraces creates a subscription that fires with the traces of every new
+// canonical block, produced by the configured tracer. Reorgs are announced with
+// the last block shared by the old and the new chain before the traces of the
+// new blocks. A block failing to be traced within blockTracesTimeout terminates
+// the subscription with an error item.
+func (api *API) BlockTraces(ctx context.Context, config *TraceConfig) (*rpc.Subscription, error) {
+	backend, ok := api.backend.(chainEventBackend)
+	if !ok {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	last, err := api.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if api.traceSubs.Add(1) > maxBlockTracesSubscriptions {
+		api.traceSubs.Add(-1)
+		return nil, errors.New("too many blockTraces subscriptions")
+	}
+	var (
+		rpcSub        = notifier.CreateSubscription()
+		wake, headSub = filters.SubscribeChainWakeups(backend.SubscribeChainEvent)
+	)
+	go func() {
+		defer api.traceSubs.Add(-1)
+		defer headSub.Unsubscribe()
+
+		deliver := func(header *types.Header) error {
+			ctx, cancel := context.WithTimeout(context.Background(), blockTracesTimeout)
+			defer cancel()
+
+			block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(header.Number.Int64()), header.Hash())
+			if err != nil {
+				return err
+			}
+			traces, err := api.traceBlockCached(ctx, block, config)
+			if err != nil {
+				return err
+			}
+			return notifier.Notify(rpcSub.ID, &blockTracesNotification{
+				Type:        filters.BlockNotificationBlock,
+				BlockHash:   header.Hash(),
+				BlockNumber: hexutil.Uint64(header.Number.Uint64()),
+				Traces:      traces,
+			})
+		}
+		reorg := func(ancestor *types.Header) error {
+			return notifier.Notify(rpcSub.ID, &blockTracesNotification{
+				Type:        filters.BlockNotificationReorg,
+				BlockHash:   ancestor.Hash(),
+				BlockNumber: hexutil.Uint64(ancestor.Number.Uint64()),
+			})
+		}
+		for {
+			select {
+			case <-wake:
+				var err error
+				if last, err = filters.FollowChain(context.Background(), api.backend, last, deliver, reorg, rpcSub.Err()); err != nil {
+					log.Debug("Block traces subscription failed", "err", err)
+					notifier.Notify(rpcSub.ID, &blockTracesNotification{Type: filters.BlockNotificationError, Error: err.Error()})
+					return
+				}
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
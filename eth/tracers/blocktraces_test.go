@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestBlockTracesSubscription(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+	}
+	signer := types.HomesteadSigner{}
+	// transfer adds n transfers to the block, the coinbase tells the chains apart
+	transfer := func(n int) func(int, *core.BlockGen) {
+		return func(i int, b *core.BlockGen) {
+			b.SetCoinbase(common.Address{byte(n)})
+			for j := 0; j < n; j++ {
+				tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(accounts[0].addr), accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+				b.AddTx(tx)
+			}
+		}
+	}
+	backend := newTestBackend(t, 2, genesis, transfer(1))
+	defer backend.teardown()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", NewAPI(backend)); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ch := make(chan *blockTracesNotification, 100)
+	sub, err := client.Subscribe(context.Background(), "debug", ch, "blockTraces", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	// expect reads the next notification and checks its type, block and traces
+	expect := func(typ string, block *types.Block, traces int) {
+		t.Helper()
+		select {
+		case n := <-ch:
+			if n.Type != typ || n.BlockHash != block.Hash() || uint64(n.BlockNumber) != block.NumberU64() {
+				t.Fatalf("want %s #%d, have %s #%d", typ, block.NumberU64(), n.Type, n.BlockNumber)
+			}
+			if len(n.Traces) != traces {
+				t.Fatalf("#%d: trace count mismatch, have %d, want %d", block.NumberU64(), len(n.Traces), traces)
+			}
+			for i, trace := range n.Traces {
+				var result struct {
+					Gas uint64 `json:"gas"`
+				}
+				blob, _ := json.Marshal(trace.Result)
+				if err := json.Unmarshal(blob, &result); err != nil || result.Gas != params.TxGas || trace.TxHash != block.Transactions()[i].Hash() {
+					t.Fatalf("#%d: unexpected trace %d: %s", block.NumberU64(), i, blob)
+				}
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %s #%d", typ, block.NumberU64())
+		}
+	}
+	// Extend the chain, the blocks before the subscription are not traced
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, backend.engine, 4, transfer(1))
+	if _, err := backend.chain.InsertChain(blocks[2:]); err != nil {
+		t.Fatal(err)
+	}
+	expect(filters.BlockNotificationBlock, blocks[2], 1)
+	expect(filters.BlockNotificationBlock, blocks[3], 1)
+
+	// Reorg to a longer chain forking after the first block
+	_, fork, _ := core.GenerateChainWithGenesis(genesis, backend.engine, 5, func(i int, b *core.BlockGen) {
+		if i == 0 {
+			transfer(1)(i, b)
+		} else {
+			transfer(2)(i, b)
+		}
+	})
+	if _, err := backend.chain.InsertChain(fork[1:]); err != nil {
+		t.Fatal(err)
+	}
+	expect(filters.BlockNotificationReorg, blocks[0], 0)
+	for _, block := range fork[1:] {
+		expect(filters.BlockNotificationBlock, block, 2)
+	}
+	// The number of concurrent subscriptions is capped
+	for i := 1; i < maxBlockTracesSubscriptions; i++ {
+		sub, err := client.Subscribe(context.Background(), "debug", make(chan *blockTracesNotification), "blockTraces", nil)
+		if err != nil {
+			t.Fatalf("subscription %d rejected: %v", i, err)
+		}
+		defer sub.Unsubscribe()
+	}
+	if _, err := client.Subscribe(context.Background(), "debug", make(chan *blockTracesNotification), "blockTraces", nil); err == nil {
+		t.Fatal("expected error for subscription beyond the limit")
+	}
+}
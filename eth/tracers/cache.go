@@ -0,0 +1,323 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	traceCacheHitMeter   = metrics.NewRegisteredMeter("trace/cache/hit", nil)
+	traceCacheMissMeter  = metrics.NewRegisteredMeter("trace/cache/miss", nil)
+	traceCacheEvictMeter = metrics.NewRegisteredMeter("trace/cache/evict", nil)
+	traceCacheDropMeter  = metrics.NewRegisteredMeter("trace/cache/reorg", nil)
+	traceCacheSizeGauge  = metrics.NewRegisteredGauge("trace/cache/size", nil)
+)
+
+// TraceCacheChain is the chain access needed by the trace cache to keep track
+// of reorgs.
+type TraceCacheChain interface {
+	GetCanonicalHash(number uint64) common.Hash
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// traceCacheEntry is an item of the trace cache LRU list.
+type traceCacheEntry struct {
+	key    string
+	number uint64
+	hash   common.Hash
+	size   uint64
+}
+
+// TraceCache is a persistent, size-bounded cache of block trace results, keyed
+// by block hash, tracer name and a hash of the tracer configuration. The least
+// recently used entries are evicted once the size limit is exceeded and the
+// entries of blocks dropped from the canonical chain by a reorg are removed.
+//
+// Only the key index is held in memory, the results are stored in a separate
+// database. The recency of entries is not persisted, after a restart entries
+// are evicted oldest block first.
+type TraceCache struct {
+	db    ethdb.KeyValueStore
+	chain TraceCacheChain
+	limit uint64 // Maximum total size of keys and values in bytes
+
+	lock    sync.Mutex
+	size    uint64
+	lru     *list.List                      // Cache entries, most recently used at the front
+	entries map[string]*list.Element        // Cache entries by database key
+	blocks  map[common.Hash]map[string]bool // Database keys of each cached block
+
+	sub  event.Subscription
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTraceCache opens a trace cache on top of the given database, loading the
+// index of the entries already stored in it. The cache watches the chain head
+// to drop the entries of reorged blocks until closed.
+func NewTraceCache(db ethdb.KeyValueStore, limit uint64, chain TraceCacheChain) (*TraceCache, error) {
+	c := &TraceCache{
+		db:      db,
+		chain:   chain,
+		limit:   limit,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		blocks:  make(map[common.Hash]map[string]bool),
+		quit:    make(chan struct{}),
+	}
+	it := db.NewIterator(nil, nil)
+	for it.Next() {
+		key := it.Key()
+		if len(key) < 8+common.HashLength {
+			continue
+		}
+		c.track(string(key), binary.BigEndian.Uint64(key[:8]), common.BytesToHash(key[8:8+common.HashLength]), uint64(len(key)+len(it.Value())))
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	c.evict()
+	c.lock.Unlock()
+
+	// Entries of the previous run may have been reorged out while the node
+	// was down.
+	c.invalidate()
+
+	heads := make(chan core.ChainHeadEvent, 16)
+	c.sub = chain.SubscribeChainHeadEvent(heads)
+	c.wg.Add(1)
+	go c.loop(heads)
+
+	log.Info("Opened trace cache", "entries", c.lru.Len(), "size", common.StorageSize(c.size), "limit", common.StorageSize(limit))
+	return c, nil
+}
+
+// Close stops watching the chain. The underlying database is not closed.
+func (c *TraceCache) Close() {
+	c.sub.Unsubscribe()
+	close(c.quit)
+	c.wg.Wait()
+}
+
+// loop watches the chain head and drops the entries of reorged blocks whenever
+// the new head does not extend the previous one.
+func (c *TraceCache) loop(heads chan core.ChainHeadEvent) {
+	defer c.wg.Done()
+
+	var last common.Hash
+	for {
+		select {
+		case ev := <-heads:
+			if last != (common.Hash{}) && ev.Header.ParentHash != last {
+				c.invalidate()
+			}
+			last = ev.Header.Hash()
+		case <-c.sub.Err():
+			return
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// traceCacheKey assembles the database key of a trace result. The key starts
+// with the block number and hash so the entries of a block are adjacent.
+func traceCacheKey(number uint64, hash common.Hash, tracer string, config common.Hash) []byte {
+	key := make([]byte, 0, 8+2*common.HashLength+len(tracer))
+	key = binary.BigEndian.AppendUint64(key, number)
+	key = append(key, hash.Bytes()...)
+	key = append(key, config.Bytes()...)
+	return append(key, tracer...)
+}
+
+// traceCacheConfig returns the tracer name and configuration hash identifying
+// the results of the given trace config. Timeout and Reexec do not influence
+// successful results, so they are left out. The fingerprint of the custom error
+// registry is mixed in, as it alters the decoded revert reasons.
+func traceCacheConfig(config *TraceConfig, registry common.Hash) (string, common.Hash, error) {
+	var (
+		name   = "structLogger"
+		logcfg *logger.Config
+		tracer json.RawMessage
+	)
+	if config != nil {
+		if config.Tracer != nil {
+			name = *config.Tracer
+		}
+		logcfg = config.Config
+		if len(config.TracerConfig) > 0 {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, config.TracerConfig); err != nil {
+				return "", common.Hash{}, err
+			}
+			tracer = buf.Bytes()
+		}
+	}
+	blob, err := json.Marshal(struct {
+		Logger   *logger.Config  `json:"logger"`
+		Tracer   json.RawMessage `json:"tracer"`
+		Registry common.Hash     `json:"registry"`
+	}{logcfg, tracer, registry})
+	if err != nil {
+		return "", common.Hash{}, err
+	}
+	return name, crypto.Keccak256Hash(blob), nil
+}
+
+// Get retrieves the cached trace results of a block.
+func (c *TraceCache) Get(number uint64, hash common.Hash, tracer string, config common.Hash) ([]byte, bool) {
+	key := traceCacheKey(number, hash, tracer, config)
+
+	c.lock.Lock()
+	elem, ok := c.entries[string(key)]
+	if ok {
+		c.lru.MoveToFront(elem)
+	}
+	c.lock.Unlock()
+
+	if !ok {
+		traceCacheMissMeter.Mark(1)
+		return nil, false
+	}
+	blob, err := c.db.Get(key)
+	if err != nil {
+		traceCacheMissMeter.Mark(1)
+		return nil, false
+	}
+	traceCacheHitMeter.Mark(1)
+	return blob, true
+}
+
+// Put stores the trace results of a block, evicting the least recently used
+// entries if the cache grows beyond its limit. Results of blocks which are not
+// canonical are not cached.
+//
+// The canonical check, the database write and the index update happen under
+// the cache lock, so a concurrent reorg invalidation or eviction can't leave
+// results in the database which the index does not know about.
+func (c *TraceCache) Put(number uint64, hash common.Hash, tracer string, config common.Hash, blob []byte) {
+	key := traceCacheKey(number, hash, tracer, config)
+	size := uint64(len(key) + len(blob))
+	if size > c.limit {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.chain.GetCanonicalHash(number) != hash {
+		return
+	}
+	if err := c.db.Put(key, blob); err != nil {
+		log.Warn("Failed to store trace results", "number", number, "hash", hash, "err", err)
+		return
+	}
+	if elem, ok := c.entries[string(key)]; ok {
+		c.remove(elem)
+	}
+	c.track(string(key), number, hash, size)
+	c.evict()
+}
+
+// track adds an entry to the in-memory index. The caller must hold the lock
+// or have exclusive access to the cache.
+func (c *TraceCache) track(key string, number uint64, hash common.Hash, size uint64) {
+	c.entries[key] = c.lru.PushFront(&traceCacheEntry{key: key, number: number, hash: hash, size: size})
+	if c.blocks[hash] == nil {
+		c.blocks[hash] = make(map[string]bool)
+	}
+	c.blocks[hash][key] = true
+	c.size += size
+	traceCacheSizeGauge.Update(int64(c.size))
+}
+
+// remove drops an entry from the in-memory index. The caller must hold the
+// lock and delete the entry from the database.
+func (c *TraceCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*traceCacheEntry)
+	delete(c.entries, entry.key)
+	delete(c.blocks[entry.hash], entry.key)
+	if len(c.blocks[entry.hash]) == 0 {
+		delete(c.blocks, entry.hash)
+	}
+	c.size -= entry.size
+	traceCacheSizeGauge.Update(int64(c.size))
+}
+
+// evict removes the least recently used entries until the cache fits within
+// its limit. The caller must hold the lock.
+func (c *TraceCache) evict() {
+	batch := c.db.NewBatch()
+	for c.size > c.limit {
+		elem := c.lru.Back()
+		batch.Delete([]byte(elem.Value.(*traceCacheEntry).key))
+		c.remove(elem)
+		traceCacheEvictMeter.Mark(1)
+	}
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to evict trace results", "err", err)
+	}
+}
+
+// invalidate removes the entries of all blocks which are no longer canonical.
+func (c *TraceCache) invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var (
+		batch   = c.db.NewBatch()
+		dropped int
+	)
+	for hash, keys := range c.blocks {
+		var number uint64
+		for key := range keys {
+			number = c.entries[key].Value.(*traceCacheEntry).number
+			break
+		}
+		if c.chain.GetCanonicalHash(number) == hash {
+			continue
+		}
+		for key := range keys {
+			batch.Delete([]byte(key))
+			c.remove(c.entries[key])
+		}
+		dropped++
+	}
+	if dropped == 0 {
+		return
+	}
+	if err := batch.Write(); err != nil {
+		log.Warn("Failed to drop reorged trace results", "err", err)
+	}
+	traceCacheDropMeter.Mark(int64(dropped))
+	log.Debug("Dropped trace results of reorged blocks", "blocks", dropped)
+}
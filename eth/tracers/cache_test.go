@@ -0,0 +1,209 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testCacheChain is a mock chain with a settable canonical chain.
+type testCacheChain struct {
+	lock      sync.Mutex
+	canonical map[uint64]common.Hash
+	feed      event.Feed
+}
+
+func (c *testCacheChain) GetCanonicalHash(number uint64) common.Hash {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.canonical[number]
+}
+
+func (c *testCacheChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func (c *testCacheChain) setCanonical(number uint64, hash common.Hash) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.canonical[number] = hash
+}
+
+func TestTraceCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = memorydb.New()
+		chain = &testCacheChain{canonical: map[uint64]common.Hash{1: {1}, 2: {2}, 3: {3}, 4: {4}}}
+		blob  = make([]byte, 100)
+		entry = uint64(len(traceCacheKey(0, common.Hash{}, "callTracer", common.Hash{})) + len(blob))
+	)
+	cache, err := NewTraceCache(db, 3*entry, chain)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	// Results of non-canonical blocks are not cached
+	cache.Put(1, common.Hash{0xff}, "callTracer", common.Hash{}, blob)
+	if _, ok := cache.Get(1, common.Hash{0xff}, "callTracer", common.Hash{}); ok {
+		t.Fatal("non-canonical block cached")
+	}
+	for i := uint64(1); i <= 3; i++ {
+		cache.Put(i, common.Hash{byte(i)}, "callTracer", common.Hash{}, blob)
+	}
+	if _, ok := cache.Get(1, common.Hash{1}, "callTracer", common.Hash{}); !ok {
+		t.Fatal("cached block missing")
+	}
+	if _, ok := cache.Get(1, common.Hash{1}, "prestateTracer", common.Hash{}); ok {
+		t.Fatal("results of other tracer returned")
+	}
+	// Adding a fourth entry evicts the least recently used one
+	cache.Put(4, common.Hash{4}, "callTracer", common.Hash{}, blob)
+	if _, ok := cache.Get(2, common.Hash{2}, "callTracer", common.Hash{}); ok {
+		t.Fatal("least recently used entry not evicted")
+	}
+	for _, i := range []uint64{1, 3, 4} {
+		if _, ok := cache.Get(i, common.Hash{byte(i)}, "callTracer", common.Hash{}); !ok {
+			t.Fatalf("entry %d missing", i)
+		}
+	}
+	// Reorg block 4 out of the chain
+	chain.feed.Send(core.ChainHeadEvent{Header: &types.Header{Number: big.NewInt(3)}})
+	chain.setCanonical(4, common.Hash{0x44})
+	chain.feed.Send(core.ChainHeadEvent{Header: &types.Header{Number: big.NewInt(4), ParentHash: common.Hash{0xaa}}})
+
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if _, ok := cache.Get(4, common.Hash{4}, "callTracer", common.Hash{}); !ok {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("reorged entry not dropped")
+		}
+	}
+	if has, _ := db.Has(traceCacheKey(4, common.Hash{4}, "callTracer", common.Hash{})); has {
+		t.Fatal("reorged entry not deleted from database")
+	}
+	cache.Close()
+
+	// Reopen the cache and check the entries are restored
+	cache, err = NewTraceCache(db, 3*entry, chain)
+	if err != nil {
+		t.Fatalf("failed to reopen cache: %v", err)
+	}
+	defer cache.Close()
+	for _, i := range []uint64{1, 3} {
+		if _, ok := cache.Get(i, common.Hash{byte(i)}, "callTracer", common.Hash{}); !ok {
+			t.Fatalf("entry %d missing after reopen", i)
+		}
+	}
+}
+
+func TestTraceCacheConfig(t *testing.T) {
+	t.Parallel()
+
+	name := "callTracer"
+	a, ha, err := traceCacheConfig(&TraceConfig{Tracer: &name, TracerConfig: []byte(`{"onlyTopCall": true}`)}, common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, hb, err := traceCacheConfig(&TraceConfig{Tracer: &name, TracerConfig: []byte(`{"onlyTopCall":true}`)}, common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != "callTracer" || a != b || ha != hb {
+		t.Fatalf("equivalent configs differ: %s %x, %s %x", a, ha, b, hb)
+	}
+	_, hc, _ := traceCacheConfig(&TraceConfig{Tracer: &name}, common.Hash{})
+	if hc == ha {
+		t.Fatal("different configs hash equal")
+	}
+	_, hd, _ := traceCacheConfig(&TraceConfig{Tracer: &name}, common.Hash{0x01})
+	if hd == hc {
+		t.Fatal("different error registries hash equal")
+	}
+	if d, _, _ := traceCacheConfig(nil, common.Hash{}); d != "structLogger" {
+		t.Fatalf("default tracer name mismatch: %s", d)
+	}
+}
+
+// testCacheBackend is a test backend offering a trace cache.
+type testCacheBackend struct {
+	*testBackend
+	cache *TraceCache
+}
+
+func (b *testCacheBackend) TraceCache() *TraceCache { return b.cache }
+
+func TestTraceBlockCached(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &accounts[1].addr,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	defer backend.teardown()
+
+	cache, err := NewTraceCache(memorydb.New(), 1024*1024, backend.chain)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	defer cache.Close()
+	api := NewAPI(&testCacheBackend{backend, cache})
+
+	want, err := api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	block := backend.chain.GetBlockByNumber(1)
+	name, config, _ := traceCacheConfig(nil, common.Hash{})
+	if _, ok := cache.Get(1, block.Hash(), name, config); !ok {
+		t.Fatal("block trace not cached")
+	}
+	have, err := api.TraceBlockByHash(context.Background(), block.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(have) != len(want) || have[0].TxHash != want[0].TxHash || string(have[0].Result.(json.RawMessage)) != string(want[0].Result.(json.RawMessage)) {
+		t.Fatalf("cached result mismatch: have %v, want %v", have, want)
+	}
+}
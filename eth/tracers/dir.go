@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/params"
@@ -32,6 +33,8 @@ type Context struct {
 	BlockNumber *big.Int    // Number of the block the tx is contained within (zero if dangling tx or call)
 	TxIndex     int         // Index of the transaction within a block (zero if dangling tx or call)
 	TxHash      common.Hash // Hash of the transaction being traced (zero if dangling call)
+
+	ErrorRegistry *abi.ErrorRegistry // Custom errors to decode revert reasons with (nil if unavailable)
 }
 
 // Tracer represents the set of methods that must be exposed by a tracer
@@ -0,0 +1,241 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+type streamBlock struct {
+	Number       uint64      `json:"number"`
+	Hash         common.Hash `json:"hash"`
+	Transactions []struct {
+		Hash  common.Hash `json:"hash"`
+		Index int         `json:"index"`
+		Calls struct {
+			From common.Address    `json:"from"`
+			To   common.Address    `json:"to"`
+			Type string            `json:"type"`
+			Logs []json.RawMessage `json:"logs"`
+		} `json:"calls"`
+		StateDiff struct {
+			StateDiff      map[common.Address]json.RawMessage `json:"stateDiff"`
+			BalanceChanges []json.RawMessage                  `json:"balanceChanges"`
+		} `json:"stateDiff"`
+	} `json:"transactions"`
+}
+
+var (
+	streamKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	streamSender = crypto.PubkeyToAddress(streamKey.PublicKey)
+	streamTarget = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+)
+
+// streamTestChain generates a chain with a value transfer in each block.
+func streamTestChain(n int) (*core.Genesis, []*types.Block) {
+	config := *params.AllEthashProtocolChanges
+	gspec := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			streamSender: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), n, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &streamTarget,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), signer, streamKey)
+		b.AddTx(tx)
+	})
+	return gspec, blocks
+}
+
+// runStreamTracer inserts the blocks into a new chain traced by the stream
+// tracer with the given config.
+func runStreamTracer(t *testing.T, config string, gspec *core.Genesis, blocks []*types.Block) {
+	tracer, err := tracers.LiveDirectory.New("stream", json.RawMessage(config))
+	if err != nil {
+		t.Fatalf("failed to create stream tracer: %v", err)
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(rawdb.PathScheme), gspec, nil, beacon.New(ethash.NewFaker()), vm.Config{Tracer: tracer}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	chain.Stop()
+}
+
+func readStreamBlocks(t *testing.T, r io.Reader, delimited bool, n int) []streamBlock {
+	var (
+		reader = bufio.NewReader(r)
+		blocks []streamBlock
+	)
+	for n < 0 || len(blocks) < n {
+		var blob []byte
+		if delimited {
+			size, err := binary.ReadUvarint(reader)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read frame size: %v", err)
+			}
+			blob = make([]byte, size)
+			if _, err := io.ReadFull(reader, blob); err != nil {
+				t.Fatalf("failed to read frame: %v", err)
+			}
+		} else {
+			line, err := reader.ReadBytes('\n')
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("failed to read line: %v", err)
+			}
+			blob = line
+		}
+		var block streamBlock
+		if err := json.Unmarshal(blob, &block); err != nil {
+			t.Fatalf("failed to unmarshal block: %v", err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func checkStreamBlocks(t *testing.T, blocks []streamBlock, from uint64, chain []*types.Block) {
+	t.Helper()
+
+	if want := len(chain) - int(from) + 1; len(blocks) != want {
+		t.Fatalf("streamed block count mismatch: have %d, want %d", len(blocks), want)
+	}
+	for i, block := range blocks {
+		want := chain[int(from)-1+i]
+		if block.Number != want.NumberU64() || block.Hash != want.Hash() {
+			t.Fatalf("block %d: have %d %x, want %d %x", i, block.Number, block.Hash, want.NumberU64(), want.Hash())
+		}
+		if len(block.Transactions) != 1 {
+			t.Fatalf("block %d: have %d transactions, want 1", i, len(block.Transactions))
+		}
+		tx := block.Transactions[0]
+		if tx.Hash != want.Transactions()[0].Hash() || tx.Calls.From != streamSender || tx.Calls.To != streamTarget || tx.Calls.Type != "CALL" {
+			t.Fatalf("block %d: unexpected call frame %+v", i, tx)
+		}
+		if _, ok := tx.StateDiff.StateDiff[streamTarget]; !ok {
+			t.Fatalf("block %d: recipient missing from state diff", i)
+		}
+		if len(tx.StateDiff.BalanceChanges) == 0 {
+			t.Fatalf("block %d: balance changes missing", i)
+		}
+	}
+}
+
+func TestStreamTracerFile(t *testing.T) {
+	gspec, blocks := streamTestChain(3)
+
+	for _, format := range []string{"jsonl", "delimited"} {
+		dir := t.TempDir()
+		config := fmt.Sprintf(`{"path":%q,"format":%q,"buffer":1}`, dir, format)
+		runStreamTracer(t, config, gspec, blocks[:2])
+
+		// Restart with resume, the already streamed blocks must not be repeated
+		config = fmt.Sprintf(`{"path":%q,"format":%q,"resume":true}`, dir, format)
+		runStreamTracer(t, config, gspec, blocks)
+
+		name := "stream.jsonl"
+		if format == "delimited" {
+			name = "stream.bin"
+		}
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to open stream file: %v", err)
+		}
+		checkStreamBlocks(t, readStreamBlocks(t, file, format == "delimited", -1), 1, blocks)
+		file.Close()
+	}
+}
+
+func TestStreamTracerFrom(t *testing.T) {
+	gspec, blocks := streamTestChain(3)
+
+	dir := t.TempDir()
+	runStreamTracer(t, fmt.Sprintf(`{"path":%q,"from":2}`, dir), gspec, blocks)
+
+	file, err := os.Open(filepath.Join(dir, "stream.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to open stream file: %v", err)
+	}
+	defer file.Close()
+	checkStreamBlocks(t, readStreamBlocks(t, file, false, -1), 2, blocks)
+}
+
+func TestStreamTracerSocket(t *testing.T) {
+	gspec, blocks := streamTestChain(3)
+
+	sock := filepath.Join(t.TempDir(), "stream.sock")
+	tracer, err := tracers.LiveDirectory.New("stream", json.RawMessage(fmt.Sprintf(`{"socket":%q}`, sock)))
+	if err != nil {
+		t.Fatalf("failed to create stream tracer: %v", err)
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(rawdb.PathScheme), gspec, nil, beacon.New(ethash.NewFaker()), vm.Config{Tracer: tracer}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Connect after the blocks were processed and resume after the first one
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		t.Fatalf("failed to connect to stream: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte("1\n")); err != nil {
+		t.Fatalf("failed to send resume block: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	checkStreamBlocks(t, readStreamBlocks(t, conn, false, 2), 2, blocks)
+}
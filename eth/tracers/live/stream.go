@@ -0,0 +1,652 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	// Force-load the native tracers, the stream tracer runs them per transaction
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("stream", newStreamTracer)
+}
+
+// Record framings supported by the stream tracer.
+const (
+	streamFormatJSONL     = "jsonl"     // One JSON record per line
+	streamFormatDelimited = "delimited" // JSON records prefixed by their uvarint length, as in protobuf delimited streams
+)
+
+const (
+	defaultStreamBuffer = 64 // Default number of blocks buffered ahead of the sink
+
+	// streamResumeTimeout is the time socket clients are given to send the
+	// number of the last block they processed.
+	streamResumeTimeout = 5 * time.Second
+
+	// streamWriteTimeout is the time a socket client is given to accept a record
+	// (or the whole backlog when resuming) before it's dropped, so a stalled
+	// client can't hold up block processing.
+	streamWriteTimeout = 5 * time.Second
+)
+
+// streamTx is the execution data of a transaction.
+type streamTx struct {
+	Hash      common.Hash     `json:"hash"`
+	Index     int             `json:"index"`
+	Calls     json.RawMessage `json:"calls"`     // Output of the callTracer, including logs
+	StateDiff json.RawMessage `json:"stateDiff"` // Output of the stateDiffTracer
+}
+
+// streamBlock is a record of the stream, the execution data of a block.
+type streamBlock struct {
+	Number       uint64      `json:"number"`
+	Hash         common.Hash `json:"hash"`
+	ParentHash   common.Hash `json:"parentHash"`
+	Transactions []*streamTx `json:"transactions"`
+}
+
+// streamRecord is an encoded record waiting to be written to the sink.
+type streamRecord struct {
+	number uint64
+	frame  []byte
+}
+
+// streamSink is the destination of the encoded records.
+type streamSink interface {
+	write(rec streamRecord) error
+	close() error
+}
+
+type streamTracerConfig struct {
+	Path    string `json:"path"`    // Path to the directory where the stream file will be stored
+	Socket  string `json:"socket"`  // Path of a Unix socket to serve the stream on, instead of a file
+	Format  string `json:"format"`  // Framing of the records, "jsonl" (default) or "delimited"
+	MaxSize int    `json:"maxSize"` // MaxSize is the maximum size in megabytes of the stream file before it gets rotated. It defaults to 100 megabytes.
+	Buffer  int    `json:"buffer"`  // Number of blocks buffered ahead of the sink, defaults to 64
+	Drop    bool   `json:"drop"`    // If true, blocks are dropped instead of stalling block processing when the buffer is full
+	From    uint64 `json:"from"`    // First block to stream
+	Resume  bool   `json:"resume"`  // If true, streaming continues after the last block found in the stream file
+}
+
+// streamTracer is a live tracer writing the call frames, logs and state diffs
+// of every processed block to a rotating file or a Unix socket, so indexers
+// can consume execution data without re-tracing over RPC.
+//
+// Records are handed to the sink through a bounded buffer. Once it is full,
+// block processing is stalled until the sink catches up, unless dropping is
+// enabled. Socket clients send the number of the last block they processed
+// as a decimal line after connecting, and the buffered blocks after it are
+// replayed to them. If the block is older than the buffered ones, the client
+// is sent an error record instead and disconnected.
+type streamTracer struct {
+	config      streamTracerConfig
+	chainConfig *params.ChainConfig
+	sink        streamSink
+	skip        uint64 // Blocks up to and including this one are not streamed
+
+	block    *streamBlock
+	tx       *streamTx
+	call     *tracers.Tracer
+	diff     *tracers.Tracer
+	txIndex  int
+	lagging  bool // Whether the sink lagging behind was already reported
+	records  chan streamRecord
+	finished chan struct{}
+}
+
+func newStreamTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config streamTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	switch config.Format {
+	case "":
+		config.Format = streamFormatJSONL
+	case streamFormatJSONL, streamFormatDelimited:
+	default:
+		return nil, fmt.Errorf("unknown stream format %q", config.Format)
+	}
+	if config.Buffer <= 0 {
+		config.Buffer = defaultStreamBuffer
+	}
+	t := &streamTracer{
+		config:   config,
+		records:  make(chan streamRecord, config.Buffer),
+		finished: make(chan struct{}),
+	}
+	switch {
+	case config.Socket != "":
+		sink, err := newSocketSink(config.Socket, config.Format, config.Buffer)
+		if err != nil {
+			return nil, err
+		}
+		t.sink = sink
+
+	case config.Path != "":
+		name := "stream.jsonl"
+		if config.Format == streamFormatDelimited {
+			name = "stream.bin"
+		}
+		logger := &lumberjack.Logger{
+			Filename: filepath.Join(config.Path, name),
+		}
+		if config.MaxSize > 0 {
+			logger.MaxSize = config.MaxSize
+		}
+		if config.Resume {
+			last, err := lastStreamedBlock(logger.Filename, config.Format)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resume stream: %v", err)
+			}
+			t.skip = last
+			log.Info("Resuming block stream", "after", last)
+		}
+		t.sink = &fileSink{logger: logger}
+
+	default:
+		return nil, errors.New("stream tracer output path or socket is required")
+	}
+	if config.From > 0 && config.From-1 > t.skip {
+		t.skip = config.From - 1
+	}
+	go t.loop()
+
+	return &tracing.Hooks{
+		OnBlockchainInit: t.onBlockchainInit,
+		OnBlockStart:     t.onBlockStart,
+		OnBlockEnd:       t.onBlockEnd,
+		OnTxStart:        t.onTxStart,
+		OnTxEnd:          t.onTxEnd,
+		OnEnter:          t.onEnter,
+		OnExit:           t.onExit,
+		OnLog:            t.onLog,
+		OnBalanceChange:  t.onBalanceChange,
+		OnNonceChangeV2:  t.onNonceChange,
+		OnCodeChange:     t.onCodeChange,
+		OnStorageChange:  t.onStorageChange,
+		OnClose:          t.onClose,
+	}, nil
+}
+
+// loop writes the buffered records to the sink.
+func (t *streamTracer) loop() {
+	defer close(t.finished)
+
+	for rec := range t.records {
+		if err := t.sink.write(rec); err != nil {
+			log.Warn("Failed to write block to stream", "number", rec.number, "err", err)
+		}
+	}
+}
+
+func (t *streamTracer) onBlockchainInit(chainConfig *params.ChainConfig) {
+	t.chainConfig = chainConfig
+}
+
+func (t *streamTracer) onBlockStart(ev tracing.BlockEvent) {
+	t.block, t.tx, t.call, t.diff = nil, nil, nil, nil
+	t.txIndex = 0
+
+	if t.skip > 0 && ev.Block.NumberU64() <= t.skip {
+		return
+	}
+	t.block = &streamBlock{
+		Number:       ev.Block.NumberU64(),
+		Hash:         ev.Block.Hash(),
+		ParentHash:   ev.Block.ParentHash(),
+		Transactions: make([]*streamTx, 0, len(ev.Block.Transactions())),
+	}
+}
+
+func (t *streamTracer) onBlockEnd(err error) {
+	block := t.block
+	t.block = nil
+
+	// Invalid blocks are not streamed
+	if block == nil || err != nil {
+		return
+	}
+	blob, err := json.Marshal(block)
+	if err != nil {
+		log.Warn("Failed to encode streamed block", "number", block.Number, "err", err)
+		return
+	}
+	rec := streamRecord{number: block.Number, frame: encodeStreamFrame(t.config.Format, blob)}
+
+	select {
+	case t.records <- rec:
+		t.lagging = false
+		return
+	default:
+	}
+	if t.config.Drop {
+		log.Warn("Stream buffer full, dropping block", "number", block.Number)
+		return
+	}
+	if !t.lagging {
+		log.Warn("Stream sink lagging, stalling block processing", "number", block.Number)
+		t.lagging = true
+	}
+	t.records <- rec
+}
+
+func (t *streamTracer) onTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	if t.block == nil {
+		return
+	}
+	ctx := &tracers.Context{
+		BlockHash:   t.block.Hash,
+		BlockNumber: new(big.Int).SetUint64(t.block.Number),
+		TxIndex:     t.txIndex,
+		TxHash:      tx.Hash(),
+	}
+	call, err := tracers.DefaultDirectory.New("callTracer", ctx, json.RawMessage(`{"withLog":true}`), t.chainConfig)
+	if err != nil {
+		log.Warn("Failed to create stream call tracer", "err", err)
+		return
+	}
+	diff, err := tracers.DefaultDirectory.New("stateDiffTracer", ctx, nil, t.chainConfig)
+	if err != nil {
+		log.Warn("Failed to create stream state diff tracer", "err", err)
+		return
+	}
+	t.tx = &streamTx{Hash: tx.Hash(), Index: t.txIndex}
+	t.call, t.diff = call, diff
+	t.txIndex++
+
+	for _, tracer := range []*tracers.Tracer{t.call, t.diff} {
+		if tracer.OnTxStart != nil {
+			tracer.OnTxStart(env, tx, from)
+		}
+	}
+}
+
+func (t *streamTracer) onTxEnd(receipt *types.Receipt, err error) {
+	if t.tx == nil {
+		return
+	}
+	for _, tracer := range []*tracers.Tracer{t.call, t.diff} {
+		if tracer.OnTxEnd != nil {
+			tracer.OnTxEnd(receipt, err)
+		}
+	}
+	// Transactions failing validation invalidate the whole block
+	if err == nil {
+		if t.tx.Calls, err = t.call.GetResult(); err != nil {
+			log.Warn("Failed to collect streamed call frames", "tx", t.tx.Hash, "err", err)
+		}
+		if t.tx.StateDiff, err = t.diff.GetResult(); err != nil {
+			log.Warn("Failed to collect streamed state diff", "tx", t.tx.Hash, "err", err)
+		}
+		t.block.Transactions = append(t.block.Transactions, t.tx)
+	}
+	t.tx, t.call, t.diff = nil, nil, nil
+}
+
+func (t *streamTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.tx == nil {
+		return
+	}
+	for _, tracer := range []*tracers.Tracer{t.call, t.diff} {
+		if tracer.OnEnter != nil {
+			tracer.OnEnter(depth, typ, from, to, input, gas, value)
+		}
+	}
+}
+
+func (t *streamTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.tx == nil {
+		return
+	}
+	for _, tracer := range []*tracers.Tracer{t.call, t.diff} {
+		if tracer.OnExit != nil {
+			tracer.OnExit(depth, output, gasUsed, err, reverted)
+		}
+	}
+}
+
+func (t *streamTracer) onLog(l *types.Log) {
+	if t.tx == nil {
+		return
+	}
+	for _, tracer := range []*tracers.Tracer{t.call, t.diff} {
+		if tracer.OnLog != nil {
+			tracer.OnLog(l)
+		}
+	}
+}
+
+func (t *streamTracer) onBalanceChange(addr common.Address, prev, cur *big.Int, reason tracing.BalanceChangeReason) {
+	if t.tx != nil && t.diff.OnBalanceChange != nil {
+		t.diff.OnBalanceChange(addr, prev, cur, reason)
+	}
+}
+
+func (t *streamTracer) onNonceChange(addr common.Address, prev, cur uint64, reason tracing.NonceChangeReason) {
+	if t.tx != nil && t.diff.OnNonceChangeV2 != nil {
+		t.diff.OnNonceChangeV2(addr, prev, cur, reason)
+	}
+}
+
+func (t *streamTracer) onCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if t.tx != nil && t.diff.OnCodeChange != nil {
+		t.diff.OnCodeChange(addr, prevCodeHash, prevCode, codeHash, code)
+	}
+}
+
+func (t *streamTracer) onStorageChange(addr common.Address, slot common.Hash, prev, cur common.Hash) {
+	if t.tx != nil && t.diff.OnStorageChange != nil {
+		t.diff.OnStorageChange(addr, slot, prev, cur)
+	}
+}
+
+func (t *streamTracer) onClose() {
+	close(t.records)
+	<-t.finished
+
+	if err := t.sink.close(); err != nil {
+		log.Warn("Failed to close block stream", "err", err)
+	}
+}
+
+// encodeStreamFrame frames an encoded record according to the stream format.
+func encodeStreamFrame(format string, blob []byte) []byte {
+	if format == streamFormatDelimited {
+		frame := binary.AppendUvarint(make([]byte, 0, len(blob)+binary.MaxVarintLen64), uint64(len(blob)))
+		return append(frame, blob...)
+	}
+	return append(blob, '\n')
+}
+
+// lastStreamedBlock returns the number of the last block in the stream file,
+// or zero if there is none.
+func lastStreamedBlock(path string, format string) (uint64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var (
+		last   uint64
+		reader = bufio.NewReader(file)
+	)
+	for {
+		var blob []byte
+		if format == streamFormatDelimited {
+			size, err := binary.ReadUvarint(reader)
+			if err == io.EOF {
+				return last, nil
+			}
+			if err != nil {
+				return last, nil // Truncated frame, resume after the last complete one
+			}
+			blob = make([]byte, size)
+			if _, err := io.ReadFull(reader, blob); err != nil {
+				return last, nil
+			}
+		} else {
+			line, err := reader.ReadBytes('\n')
+			if err == io.EOF {
+				return last, nil // Possibly a truncated line, resume after the last complete one
+			}
+			if err != nil {
+				return 0, err
+			}
+			blob = line
+		}
+		var block struct {
+			Number uint64 `json:"number"`
+		}
+		if err := json.Unmarshal(blob, &block); err != nil {
+			return 0, fmt.Errorf("corrupt stream record: %v", err)
+		}
+		last = block.Number
+	}
+}
+
+// fileSink writes the records to a rotating file.
+type fileSink struct {
+	logger *lumberjack.Logger
+}
+
+func (s *fileSink) write(rec streamRecord) error {
+	_, err := s.logger.Write(rec.frame)
+	return err
+}
+
+func (s *fileSink) close() error {
+	return s.logger.Close()
+}
+
+// streamError is the record sent to a socket client before it is disconnected
+// because its stream can't be continued.
+type streamError struct {
+	Error  string `json:"error"`
+	Oldest uint64 `json:"oldest"` // Number of the oldest block still retained
+}
+
+// socketSink serves the records to a single client connected to a Unix socket.
+// The most recent records are retained so reconnecting clients can resume
+// where they left off. Clients resuming from a block older than the retained
+// records are sent a streamError and disconnected, as are clients not keeping
+// up within streamWriteTimeout.
+type socketSink struct {
+	listener net.Listener
+	format   string
+	limit    int // Number of records retained for resuming clients
+
+	lock     sync.Mutex
+	conn     net.Conn // Client receiving new records
+	resuming net.Conn // Client being sent the backlog, before becoming conn
+	backlog  []streamRecord
+	written  uint64 // Number of records written to the sink, the last one being the newest in backlog
+	wg       sync.WaitGroup
+}
+
+func newSocketSink(path string, format string, limit int) (*socketSink, error) {
+	// Remove a stale socket file of a previous run
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := &socketSink{listener: listener, format: format, limit: limit}
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+// accept serves incoming clients, replacing the current one if any.
+func (s *socketSink) accept() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		// Read the number of the last block processed by the client
+		var (
+			after  uint64
+			resume bool
+		)
+		conn.SetReadDeadline(time.Now().Add(streamResumeTimeout))
+		if line, err := bufio.NewReader(conn).ReadString('\n'); err == nil {
+			if line = strings.TrimSpace(line); line != "" {
+				if after, err = strconv.ParseUint(line, 10, 64); err != nil {
+					log.Warn("Invalid stream resume block", "block", line)
+					conn.Close()
+					continue
+				}
+				resume = true
+			}
+		}
+		conn.SetReadDeadline(time.Time{})
+
+		if err := s.resume(conn, after, resume); err != nil {
+			log.Warn("Dropped stream client while resuming", "after", after, "err", err)
+			conn.Close()
+		}
+	}
+}
+
+// resume sends the retained records after the given block to a new client and
+// then makes it the receiver of new records. The records are copied under the
+// lock and written outside of it, so a slow client doesn't stall the sink.
+// Records arriving in the meantime are sent in further rounds, until the
+// client has caught up.
+func (s *socketSink) resume(conn net.Conn, after uint64, resume bool) error {
+	s.lock.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	// The client misses blocks if it's behind the retained records
+	if resume && len(s.backlog) > 0 && s.backlog[0].number > after+1 {
+		oldest := s.backlog[0].number
+		s.lock.Unlock()
+
+		err := fmt.Errorf("blocks after %d are no longer retained", after)
+		blob, _ := json.Marshal(&streamError{Error: err.Error(), Oldest: oldest})
+		conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		conn.Write(encodeStreamFrame(s.format, blob))
+		return err
+	}
+	s.resuming = conn
+	sent := s.written - uint64(len(s.backlog))
+	for _, rec := range s.backlog {
+		if rec.number > after {
+			break
+		}
+		sent++
+	}
+	s.lock.Unlock()
+
+	conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	for {
+		s.lock.Lock()
+		pending, err := s.pending(conn, sent)
+		if err != nil || len(pending) == 0 {
+			if err == nil {
+				s.conn = conn
+			}
+			s.resuming = nil
+			s.lock.Unlock()
+			return err
+		}
+		s.lock.Unlock()
+
+		for _, rec := range pending {
+			if _, err := conn.Write(rec.frame); err != nil {
+				s.lock.Lock()
+				if s.resuming == conn {
+					s.resuming = nil
+				}
+				s.lock.Unlock()
+				return err
+			}
+		}
+		sent += uint64(len(pending))
+	}
+}
+
+// pending returns a copy of the records a resuming client hasn't been sent
+// yet, given the number of records sent to it so far. The caller must hold
+// the lock.
+func (s *socketSink) pending(conn net.Conn, sent uint64) ([]streamRecord, error) {
+	if s.resuming != conn {
+		return nil, errors.New("stream closed")
+	}
+	start := s.written - uint64(len(s.backlog))
+	if sent < start {
+		return nil, errors.New("client fell behind while resuming")
+	}
+	return slices.Clone(s.backlog[sent-start:]), nil
+}
+
+func (s *socketSink) write(rec streamRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.backlog = append(s.backlog, rec)
+	if len(s.backlog) > s.limit {
+		s.backlog = s.backlog[len(s.backlog)-s.limit:]
+	}
+	s.written++
+	if s.conn == nil {
+		return nil
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+	if _, err := s.conn.Write(rec.frame); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *socketSink) close() error {
+	err := s.listener.Close()
+
+	// Interrupt a client being resumed, so the accept loop can exit
+	s.lock.Lock()
+	if s.resuming != nil {
+		s.resuming.Close()
+		s.resuming = nil
+	}
+	s.lock.Unlock()
+	s.wg.Wait()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestSocketSink starts a socket sink retaining limit records and streams
+// the given blocks to it.
+func newTestSocketSink(t *testing.T, limit int, blocks ...uint64) (*socketSink, string) {
+	path := filepath.Join(t.TempDir(), "stream.sock")
+	sink, err := newSocketSink(path, streamFormatJSONL, limit)
+	if err != nil {
+		t.Fatalf("failed to open socket sink: %v", err)
+	}
+	t.Cleanup(func() { sink.close() })
+
+	for _, number := range blocks {
+		writeTestRecord(t, sink, number, 0)
+	}
+	return sink, path
+}
+
+// writeTestRecord writes the record of a block, padded to the given size.
+func writeTestRecord(t *testing.T, sink *socketSink, number uint64, size int) {
+	blob, _ := json.Marshal(struct {
+		Number  uint64 `json:"number"`
+		Padding []byte `json:"padding,omitempty"`
+	}{number, make([]byte, size)})
+	if err := sink.write(streamRecord{number: number, frame: encodeStreamFrame(streamFormatJSONL, blob)}); err != nil {
+		t.Fatalf("failed to write block %d: %v", number, err)
+	}
+}
+
+// dialTestSocketSink connects to a socket sink, resuming after the given block.
+func dialTestSocketSink(t *testing.T, path string, after uint64) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := fmt.Fprintf(conn, "%d\n", after); err != nil {
+		t.Fatalf("failed to send resume block: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn, bufio.NewReader(conn)
+}
+
+// readTestRecord reads the next record from the stream.
+func readTestRecord(t *testing.T, reader *bufio.Reader) map[string]interface{} {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("failed to read record: %v", err)
+	}
+	var rec map[string]interface{}
+	if err := json.Unmarshal(line, &rec); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	return rec
+}
+
+// Tests that a resuming client is sent the retained records after its last
+// block, followed by the new ones.
+func TestSocketSinkResume(t *testing.T) {
+	sink, path := newTestSocketSink(t, 4, 1, 2, 3, 4, 5, 6)
+
+	_, reader := dialTestSocketSink(t, path, 3)
+	for _, want := range []uint64{4, 5, 6} {
+		if have := readTestRecord(t, reader)["number"]; have != float64(want) {
+			t.Fatalf("block mismatch, have %v, want %d", have, want)
+		}
+	}
+	writeTestRecord(t, sink, 7, 0)
+	if have := readTestRecord(t, reader)["number"]; have != float64(7) {
+		t.Fatalf("block mismatch, have %v, want 7", have)
+	}
+}
+
+// Tests that a client resuming from a block older than the retained records is
+// sent an error instead of a stream with a gap.
+func TestSocketSinkResumeGap(t *testing.T) {
+	_, path := newTestSocketSink(t, 4, 1, 2, 3, 4, 5, 6)
+
+	_, reader := dialTestSocketSink(t, path, 1)
+	rec := readTestRecord(t, reader)
+	if rec["error"] == nil || rec["oldest"] != float64(3) {
+		t.Fatalf("unexpected record %v", rec)
+	}
+	if _, err := reader.ReadBytes('\n'); err == nil {
+		t.Fatal("expected the client to be disconnected")
+	}
+	// Resuming right before the oldest retained block has no gap
+	_, reader = dialTestSocketSink(t, path, 2)
+	if have := readTestRecord(t, reader)["number"]; have != float64(3) {
+		t.Fatalf("block mismatch, have %v, want 3", have)
+	}
+}
+
+// Tests that a client slowly receiving the backlog doesn't stall the writes to
+// the sink, and is sent the records written in the meantime.
+func TestSocketSinkResumeSlowClient(t *testing.T) {
+	sink, path := newTestSocketSink(t, 8)
+	for number := uint64(1); number <= 4; number++ {
+		writeTestRecord(t, sink, number, 1024*1024)
+	}
+	_, reader := dialTestSocketSink(t, path, 0)
+
+	// Wait until the backlog is being sent, it doesn't fit the socket buffers
+	for {
+		sink.lock.Lock()
+		resuming := sink.resuming != nil
+		sink.lock.Unlock()
+		if resuming {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	start := time.Now()
+	writeTestRecord(t, sink, 5, 0)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("write stalled by resuming client for %v", elapsed)
+	}
+	for want := uint64(1); want <= 5; want++ {
+		if have := readTestRecord(t, reader)["number"]; have != float64(want) {
+			t.Fatalf("block mismatch, have %v, want %d", have, want)
+		}
+	}
+}
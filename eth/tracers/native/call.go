@@ -73,7 +73,7 @@ func (f callFrame) failed() bool {
 	return len(f.Error) > 0 && f.revertedSnapshot
 }
 
-func (f *callFrame) processOutput(output []byte, err error, reverted bool) {
+func (f *callFrame) processOutput(output []byte, err error, reverted bool, errs *abi.ErrorRegistry) {
 	output = common.CopyBytes(output)
 	// Clear error if tx wasn't reverted. This happened
 	// for pre-homestead contract storage OOG.
@@ -96,7 +96,7 @@ func (f *callFrame) processOutput(output []byte, err error, reverted bool) {
 	if len(output) < 4 {
 		return
 	}
-	if unpacked, err := abi.UnpackRevert(output); err == nil {
+	if unpacked, err := errs.UnpackRevert(output); err == nil {
 		f.RevertReason = unpacked
 	}
 }
@@ -113,6 +113,7 @@ type callFrameMarshaling struct {
 type callTracer struct {
 	callstack []callFrame
 	config    callTracerConfig
+	errors    *abi.ErrorRegistry // Custom errors to decode revert reasons with
 	gasLimit  uint64
 	depth     int
 	interrupt atomic.Bool // Atomic flag to signal execution interruption
@@ -151,7 +152,11 @@ func newCallTracerObject(ctx *tracers.Context, cfg json.RawMessage) (*callTracer
 	}
 	// First callframe contains tx context info
 	// and is populated on start and end.
-	return &callTracer{callstack: make([]callFrame, 0, 1), config: config}, nil
+	t := &callTracer{callstack: make([]callFrame, 0, 1), config: config}
+	if ctx != nil {
+		t.errors = ctx.ErrorRegistry
+	}
+	return t, nil
 }
 
 // OnEnter is called when EVM enters a new scope (via call, create or selfdestruct).
@@ -203,7 +208,7 @@ func (t *callTracer) OnExit(depth int, output []byte, gasUsed uint64, err error,
 	size -= 1
 
 	call.GasUsed = gasUsed
-	call.processOutput(output, err, reverted)
+	call.processOutput(output, err, reverted, t.errors)
 	// Nest call into parent.
 	t.callstack[size-1].Calls = append(t.callstack[size-1].Calls, call)
 }
@@ -212,7 +217,7 @@ func (t *callTracer) captureEnd(output []byte, gasUsed uint64, err error, revert
 	if len(t.callstack) != 1 {
 		return
 	}
-	t.callstack[0].processOutput(output, err, reverted)
+	t.callstack[0].processOutput(output, err, reverted, t.errors)
 }
 
 func (t *callTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallTracerRevertRegistry(t *testing.T) {
+	registry := abi.NewErrorRegistry()
+	require.NoError(t, registry.AddSignature("InsufficientBalance(uint256,uint256)"))
+
+	var (
+		from   = common.HexToAddress("0xa")
+		to     = common.HexToAddress("0xb")
+		revert = append(crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4], append(common.BigToHash(big.NewInt(1)).Bytes(), common.BigToHash(big.NewInt(2)).Bytes()...)...)
+	)
+	for _, tt := range []struct {
+		registry *abi.ErrorRegistry
+		want     string
+	}{
+		{nil, ""},
+		{registry, "InsufficientBalance(1, 2)"},
+	} {
+		tracer, err := tracers.DefaultDirectory.New("callTracer", &tracers.Context{ErrorRegistry: tt.registry}, nil, params.MainnetChainConfig)
+		require.NoError(t, err)
+
+		tracer.OnTxStart(&tracing.VMContext{}, types.NewTx(&types.LegacyTx{Gas: 100000}), from)
+		tracer.OnEnter(0, byte(vm.CALL), from, to, nil, 100000, big.NewInt(0))
+		tracer.OnExit(0, revert, 5000, vm.ErrExecutionReverted, true)
+		tracer.OnTxEnd(&types.Receipt{GasUsed: 26000}, nil)
+
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+		var frame struct {
+			Output       string `json:"output"`
+			RevertReason string `json:"revertReason"`
+		}
+		require.NoError(t, json.Unmarshal(res, &frame))
+		require.Equal(t, tt.want, frame.RevertReason)
+		require.NotEmpty(t, frame.Output)
+	}
+}
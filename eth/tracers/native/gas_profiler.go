@@ -0,0 +1,315 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("gasProfiler", newGasProfiler, false)
+}
+
+// gasProfileOpcode is the aggregated gas usage of a single instruction.
+type gasProfileOpcode struct {
+	Address common.Address `json:"address"` // Address of the executed code
+	PC      uint64         `json:"pc"`
+	Op      string         `json:"op"`
+	Count   uint64         `json:"count"`
+	Gas     uint64         `json:"gas"`
+}
+
+// gasProfileCall is the aggregated gas usage of a call path.
+type gasProfileCall struct {
+	Path    string `json:"path"`
+	Count   uint64 `json:"count"`
+	Gas     uint64 `json:"gas"`     // Gas used including the nested calls
+	SelfGas uint64 `json:"selfGas"` // Gas used excluding the nested calls
+}
+
+// gasProfileResult is the output of the gasProfiler.
+type gasProfileResult struct {
+	GasUsed uint64             `json:"gasUsed"`
+	Opcodes []gasProfileOpcode `json:"opcodes"`
+	Calls   []gasProfileCall   `json:"calls"`
+	Folded  string             `json:"folded"`
+}
+
+// gasProfileKey identifies an instruction of a contract.
+type gasProfileKey struct {
+	addr common.Address
+	pc   uint64
+	op   vm.OpCode
+}
+
+// gasProfileFrame is a call frame on the profiler call stack.
+type gasProfileFrame struct {
+	path     string         // Folded call path of the frame
+	code     common.Address // Address of the executed code
+	opGas    uint64         // Gas charged by the instructions of the frame
+	childGas uint64         // Gas used by the nested calls
+}
+
+// gasProfileCallOp is a call instruction whose charged gas still includes the
+// gas forwarded to the callee.
+type gasProfileCallOp struct {
+	op     *gasProfileOpcode
+	folded string
+	frame  *gasProfileFrame
+	value  bool // Whether a stipend is added to the forwarded gas
+}
+
+// gasProfiler aggregates the gas used by a transaction per instruction and per
+// call path. Gas forwarded by the call instructions is attributed to the callee,
+// so the gas of every instruction only covers its own cost. The call paths are
+// built from the code address and the 4byte selector of each call frame.
+//
+// Besides the JSON summary, the result contains the profile in the folded stack
+// format, which can be turned into a flamegraph by e.g. flamegraph.pl or
+// speedscope. Each line holds a call path, optionally followed by an opcode, and
+// the gas used by it. Gas used by a call frame which is not charged by any of
+// its instructions, like the execution of precompiles, is reported on the call
+// path itself.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "gasProfiler"})
+//	{
+//	  gasUsed: 43724,
+//	  opcodes: [{address: "0x...", pc: 121, op: "SSTORE", count: 1, gas: 20000}, ...],
+//	  calls: [{path: "0x...:0xa9059cbb", count: 1, gas: 22604, selfGas: 22604}],
+//	  folded: "0x...:0xa9059cbb;SSTORE 20000\n..."
+//	}
+type gasProfiler struct {
+	gasUsed uint64
+	opcodes map[gasProfileKey]*gasProfileOpcode
+	calls   map[string]*gasProfileCall
+	folded  map[string]uint64
+	frames  []*gasProfileFrame
+	pending *gasProfileCallOp // Last call instruction, until the callee is entered
+
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newGasProfiler returns a native go tracer which profiles the gas usage of a
+// transaction.
+func newGasProfiler(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := new(gasProfiler)
+	t.reset()
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnTxEnd:   t.OnTxEnd,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// reset clears the collected profile.
+func (t *gasProfiler) reset() {
+	t.gasUsed = 0
+	t.opcodes = make(map[gasProfileKey]*gasProfileOpcode)
+	t.calls = make(map[string]*gasProfileCall)
+	t.folded = make(map[string]uint64)
+	t.frames = nil
+	t.pending = nil
+}
+
+// OnTxStart starts a new profile, the tracer may be reused when benchmarking.
+func (t *gasProfiler) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.reset()
+}
+
+// OnTxEnd records the gas used by the transaction.
+func (t *gasProfiler) OnTxEnd(receipt *types.Receipt, err error) {
+	if receipt != nil {
+		t.gasUsed = receipt.GasUsed
+	}
+}
+
+// OnEnter pushes a call frame onto the stack and moves the gas forwarded to the
+// callee out of the calling instruction.
+func (t *gasProfiler) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	label := to.Hex()
+	switch op := vm.OpCode(typ); {
+	case op == vm.CREATE || op == vm.CREATE2:
+		label += ":constructor"
+	case len(input) >= 4:
+		label += ":" + hexutil.Encode(input[:4])
+	}
+	frame := &gasProfileFrame{path: label, code: to}
+	if len(t.frames) > 0 {
+		frame.path = t.frames[len(t.frames)-1].path + ";" + label
+	}
+	if call := t.pending; call != nil {
+		forwarded := gas
+		if call.value && forwarded >= params.CallStipend {
+			forwarded -= params.CallStipend
+		}
+		forwarded = min(forwarded, call.op.Gas, call.frame.opGas, t.folded[call.folded])
+		call.op.Gas -= forwarded
+		call.frame.opGas -= forwarded
+		t.folded[call.folded] -= forwarded
+		t.pending = nil
+	}
+	t.frames = append(t.frames, frame)
+}
+
+// OnExit pops a call frame from the stack and accounts its gas usage to the
+// call path.
+func (t *gasProfiler) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	t.pending = nil
+
+	var self uint64
+	if gasUsed > frame.childGas {
+		self = gasUsed - frame.childGas
+	}
+	if self > frame.opGas {
+		t.folded[frame.path] += self - frame.opGas
+	}
+	call := t.calls[frame.path]
+	if call == nil {
+		call = &gasProfileCall{Path: frame.path}
+		t.calls[frame.path] = call
+	}
+	call.Count++
+	call.Gas += gasUsed
+	call.SelfGas += self
+
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].childGas += gasUsed
+	}
+}
+
+// OnOpcode accounts the gas charged by an instruction.
+func (t *gasProfiler) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	var (
+		frame = t.frames[len(t.frames)-1]
+		op    = vm.OpCode(opcode)
+		key   = gasProfileKey{addr: frame.code, pc: pc, op: op}
+	)
+	stat := t.opcodes[key]
+	if stat == nil {
+		stat = &gasProfileOpcode{Address: frame.code, PC: pc, Op: op.String()}
+		t.opcodes[key] = stat
+	}
+	stat.Count++
+	stat.Gas += cost
+	frame.opGas += cost
+
+	folded := frame.path + ";" + op.String()
+	t.folded[folded] += cost
+
+	// The cost of the call instructions includes the gas forwarded to the
+	// callee, it's corrected once the callee is entered.
+	t.pending = nil
+	if err == nil {
+		switch op {
+		case vm.CALL, vm.CALLCODE:
+			value := scope.StackData()
+			t.pending = &gasProfileCallOp{op: stat, folded: folded, frame: frame, value: len(value) >= 3 && !value[len(value)-3].IsZero()}
+		case vm.DELEGATECALL, vm.STATICCALL:
+			t.pending = &gasProfileCallOp{op: stat, folded: folded, frame: frame}
+		}
+	}
+}
+
+// GetResult returns the json-encoded gas profile, the instructions and call
+// paths are sorted by gas used in descending order.
+func (t *gasProfiler) GetResult() (json.RawMessage, error) {
+	res := gasProfileResult{
+		GasUsed: t.gasUsed,
+		Opcodes: make([]gasProfileOpcode, 0, len(t.opcodes)),
+		Calls:   make([]gasProfileCall, 0, len(t.calls)),
+	}
+	for _, op := range t.opcodes {
+		res.Opcodes = append(res.Opcodes, *op)
+	}
+	slices.SortFunc(res.Opcodes, func(a, b gasProfileOpcode) int {
+		if c := cmp.Compare(b.Gas, a.Gas); c != 0 {
+			return c
+		}
+		if c := a.Address.Cmp(b.Address); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PC, b.PC)
+	})
+	for _, call := range t.calls {
+		res.Calls = append(res.Calls, *call)
+	}
+	slices.SortFunc(res.Calls, func(a, b gasProfileCall) int {
+		if c := cmp.Compare(b.Gas, a.Gas); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	stacks := make([]string, 0, len(t.folded))
+	for stack, gas := range t.folded {
+		if gas > 0 {
+			stacks = append(stacks, stack)
+		}
+	}
+	slices.Sort(stacks)
+
+	var folded strings.Builder
+	for _, stack := range stacks {
+		fmt.Fprintf(&folded, "%s %d\n", stack, t.folded[stack])
+	}
+	res.Folded = folded.String()
+
+	blob, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return blob, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfiler) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// profilerScope is an opcode context exposing only a stack.
+type profilerScope struct {
+	tracing.OpContext
+	stack []uint256.Int
+}
+
+func (s *profilerScope) StackData() []uint256.Int { return s.stack }
+
+type gasProfile struct {
+	GasUsed uint64 `json:"gasUsed"`
+	Opcodes []struct {
+		Address common.Address `json:"address"`
+		PC      uint64         `json:"pc"`
+		Op      string         `json:"op"`
+		Count   uint64         `json:"count"`
+		Gas     uint64         `json:"gas"`
+	} `json:"opcodes"`
+	Calls []struct {
+		Path    string `json:"path"`
+		Count   uint64 `json:"count"`
+		Gas     uint64 `json:"gas"`
+		SelfGas uint64 `json:"selfGas"`
+	} `json:"calls"`
+	Folded string `json:"folded"`
+}
+
+func TestGasProfiler(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("gasProfiler", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		sender     = common.HexToAddress("0x5e")
+		caller     = common.HexToAddress("0xa")
+		callee     = common.HexToAddress("0xb")
+		ecrecover  = common.BytesToAddress([]byte{1})
+		selector   = []byte{0x12, 0x34, 0x56, 0x78}
+		valueCall  = &profilerScope{stack: []uint256.Int{{}, {}, {}, {}, *uint256.NewInt(1), *uint256.NewInt(0xb), *uint256.NewInt(50000)}}
+		staticCall = &profilerScope{stack: []uint256.Int{{}, {}, {}, {}, *uint256.NewInt(1), *uint256.NewInt(3000)}}
+	)
+	tracer.OnTxStart(&tracing.VMContext{}, types.NewTx(&types.LegacyTx{}), sender)
+	tracer.OnEnter(0, byte(vm.CALL), sender, caller, append(selector, 0xff), 100000, big.NewInt(0))
+	tracer.OnOpcode(0, byte(vm.PUSH1), 100000, 3, nil, nil, 1, nil)
+	tracer.OnOpcode(0, byte(vm.PUSH1), 99997, 3, nil, nil, 1, nil)
+
+	// Value call forwarding 50000 gas plus the stipend
+	tracer.OnOpcode(10, byte(vm.CALL), 99994, 2600+9000+50000, valueCall, nil, 1, nil)
+	tracer.OnEnter(1, byte(vm.CALL), caller, callee, nil, 50000+params.CallStipend, big.NewInt(1))
+	tracer.OnOpcode(0, byte(vm.SSTORE), 52300, 20000, nil, nil, 2, nil)
+	tracer.OnExit(1, nil, 20000, nil, false)
+
+	// Precompile executions are charged on the call path
+	tracer.OnOpcode(20, byte(vm.STATICCALL), 70000, 100+3000, staticCall, nil, 1, nil)
+	tracer.OnEnter(1, byte(vm.STATICCALL), caller, ecrecover, nil, 3000, nil)
+	tracer.OnExit(1, nil, 3000, nil, false)
+	tracer.OnExit(0, nil, 6+11600+100+23000, nil, false)
+	tracer.OnTxEnd(&types.Receipt{GasUsed: 21000 + 6 + 11600 + 100 + 23000}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var profile gasProfile
+	require.NoError(t, json.Unmarshal(res, &profile))
+
+	require.Equal(t, uint64(21000+6+11600+100+23000), profile.GasUsed)
+
+	require.Len(t, profile.Opcodes, 4)
+	require.Equal(t, callee, profile.Opcodes[0].Address)
+	require.Equal(t, "SSTORE", profile.Opcodes[0].Op)
+	require.Equal(t, uint64(20000), profile.Opcodes[0].Gas)
+	require.Equal(t, "CALL", profile.Opcodes[1].Op)
+	require.Equal(t, uint64(11600), profile.Opcodes[1].Gas)
+	require.Equal(t, "STATICCALL", profile.Opcodes[2].Op)
+	require.Equal(t, uint64(100), profile.Opcodes[2].Gas)
+	require.Equal(t, "PUSH1", profile.Opcodes[3].Op)
+	require.Equal(t, uint64(2), profile.Opcodes[3].Count)
+	require.Equal(t, uint64(6), profile.Opcodes[3].Gas)
+
+	root := caller.Hex() + ":0x12345678"
+	require.Len(t, profile.Calls, 3)
+	require.Equal(t, root, profile.Calls[0].Path)
+	require.Equal(t, uint64(34706), profile.Calls[0].Gas)
+	require.Equal(t, uint64(11706), profile.Calls[0].SelfGas)
+	require.Equal(t, root+";"+callee.Hex(), profile.Calls[1].Path)
+	require.Equal(t, uint64(20000), profile.Calls[1].SelfGas)
+	require.Equal(t, root+";"+ecrecover.Hex(), profile.Calls[2].Path)
+	require.Equal(t, uint64(3000), profile.Calls[2].SelfGas)
+
+	want := []string{
+		root + ";" + ecrecover.Hex() + " 3000",
+		root + ";" + callee.Hex() + ";SSTORE 20000",
+		root + ";CALL 11600",
+		root + ";PUSH1 6",
+		root + ";STATICCALL 100",
+	}
+	have := strings.Split(strings.TrimSuffix(profile.Folded, "\n"), "\n")
+	require.ElementsMatch(t, want, have)
+
+	// The folded stacks add up to the gas used by the execution
+	var total uint64
+	for _, line := range have {
+		gas, ok := new(big.Int).SetString(line[strings.LastIndexByte(line, ' ')+1:], 10)
+		require.True(t, ok)
+		total += gas.Uint64()
+	}
+	require.Equal(t, profile.Calls[0].Gas, total)
+}
@@ -0,0 +1,509 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("stateDiffTracer", newStateDiffTracer, false)
+}
+
+// Causes a balance change can be attributed to by the stateDiffTracer.
+const (
+	causeTransfer       = "transfer"       // Value transfer between accounts, including selfdestructs
+	causeBurn           = "burn"           // Ether burnt by selfdestructing to itself
+	causeGasBuy         = "gasBuy"         // Upfront gas payment by the sender
+	causeGasRefund      = "gasRefund"      // Refund of unused gas to the sender
+	causeSponsorPayment = "sponsorPayment" // Upfront gas payment by a meta transaction sponsor
+	causeSponsorRefund  = "sponsorRefund"  // Refund of unused gas to a meta transaction sponsor
+	causeSequencerFee   = "sequencerFee"   // Priority fee credited to the block coinbase
+	causeBaseFeeVault   = "baseFeeVault"   // Base fee credited to the base fee vault
+	causeL1FeeVault     = "l1FeeVault"     // L1 data fee credited to the L1 fee vault
+	causeDepositMint    = "depositMint"    // Native tokens minted by a deposit transaction
+	causeBVMETHMint     = "bvmEthMint"     // BVM_ETH minted by a deposit transaction
+	causeBVMETHTransfer = "bvmEthTransfer" // BVM_ETH moved between accounts
+	causeWithdrawal     = "withdrawal"     // Beacon chain withdrawal
+	causeBlockReward    = "blockReward"    // Block or uncle mining reward
+	causeGenesis        = "genesis"        // Genesis allocation
+	causeOther          = "other"          // Any other, unattributed balance change
+)
+
+// stateDiffValue is the before and after value of a modified state item.
+type stateDiffValue[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// stateDiffAccount is the modified state of a single account.
+type stateDiffAccount struct {
+	Balance *stateDiffValue[*hexutil.Big]               `json:"balance,omitempty"`
+	Nonce   *stateDiffValue[hexutil.Uint64]             `json:"nonce,omitempty"`
+	Code    *stateDiffValue[hexutil.Bytes]              `json:"code,omitempty"`
+	Storage map[common.Hash]stateDiffValue[common.Hash] `json:"storage,omitempty"`
+	Born    bool                                        `json:"born,omitempty"`
+	Died    bool                                        `json:"died,omitempty"`
+}
+
+// balanceChange is a single balance change attributed to its cause. Native
+// token changes leave Token unset, BVM_ETH changes set it to the BVM_ETH
+// contract. Delta is signed, decreases are encoded as "-0x...".
+type balanceChange struct {
+	Address common.Address  `json:"address"`
+	Token   *common.Address `json:"token,omitempty"`
+	Cause   string          `json:"cause"`
+	Delta   *hexutil.Big    `json:"delta"`
+}
+
+// stateDiffResult is the default output of the stateDiffTracer.
+type stateDiffResult struct {
+	StateDiff      map[common.Address]*stateDiffAccount `json:"stateDiff"`
+	BalanceChanges []balanceChange                      `json:"balanceChanges"`
+	L1Fee          *hexutil.Big                         `json:"l1Fee,omitempty"`
+}
+
+// diffState tracks the pre and post state of an account touched by the
+// transaction. Storage only tracks the modified slots.
+type diffState struct {
+	preBalance, postBalance *big.Int
+	preNonce, postNonce     uint64
+	preCode, postCode       []byte
+	preStorage, postStorage map[common.Hash]common.Hash
+}
+
+func (s *diffState) empty(pre bool) bool {
+	if pre {
+		return s.preBalance.Sign() == 0 && s.preNonce == 0 && len(s.preCode) == 0
+	}
+	return s.postBalance.Sign() == 0 && s.postNonce == 0 && len(s.postCode) == 0
+}
+
+type stateDiffTracerConfig struct {
+	Parity bool `json:"parity"` // If true, the state diff is returned in the parity trace_replayTransaction format
+}
+
+// stateDiffTracer records the storage level before and after state of every
+// account modified by a transaction, and attributes each balance change to its
+// cause using the balance change reasons of the state hooks and the Mantle fee
+// recipients.
+//
+// On Mantle the L1 data fee is charged as part of the gas used, so it ends up
+// in the base fee vault and the coinbase together with the L2 fees rather than
+// being credited to the L1 fee vault. The L1 fee of the receipt is reported in
+// the l1Fee field for reference.
+//
+// Changes made by reverted call frames are undone in the state diff and are
+// left out of the balance changes.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "stateDiffTracer"})
+//	{
+//	  stateDiff: {
+//	    "0x...": {balance: {from: "0x1", to: "0x0"}, nonce: {from: "0x0", to: "0x1"}}
+//	  },
+//	  balanceChanges: [{address: "0x...", cause: "gasBuy", delta: "-0x1"}],
+//	  l1Fee: "0x0"
+//	}
+type stateDiffTracer struct {
+	env     *tracing.VMContext
+	from    common.Address
+	config  stateDiffTracerConfig
+	states  map[common.Address]*diffState
+	order   []common.Address // Touched accounts in order of first modification
+	changes []balanceChange
+	frames  []int // Number of balance changes when each call frame on the stack was entered
+	l1Fee   *big.Int
+
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newStateDiffTracer returns a native go tracer which records the state diff
+// of a transaction along with the cause of each balance change.
+func newStateDiffTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	var config stateDiffTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &stateDiffTracer{
+		config:  config,
+		states:  make(map[common.Address]*diffState),
+		changes: make([]balanceChange, 0),
+	}
+	hooks, err := tracing.WrapWithJournal(&tracing.Hooks{
+		OnTxStart:       t.OnTxStart,
+		OnTxEnd:         t.OnTxEnd,
+		OnEnter:         t.OnEnter,
+		OnExit:          t.OnExit,
+		OnBalanceChange: t.OnBalanceChange,
+		OnNonceChangeV2: t.OnNonceChangeV2,
+		OnCodeChange:    t.OnCodeChange,
+		OnStorageChange: t.OnStorageChange,
+		OnLog:           t.OnLog,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracers.Tracer{
+		Hooks:     hooks,
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// OnTxStart captures the environment of the transaction.
+func (t *stateDiffTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.env = env
+	t.from = from
+}
+
+// OnTxEnd captures the L1 fee of the transaction and marks the accounts which
+// were deleted at the end of it.
+func (t *stateDiffTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if t.interrupt.Load() {
+		return
+	}
+	if receipt != nil && receipt.L1Fee != nil {
+		t.l1Fee = new(big.Int).Set(receipt.L1Fee)
+	}
+	if t.env == nil || t.env.StateDB == nil {
+		return
+	}
+	for _, addr := range t.order {
+		if t.env.StateDB.Exist(addr) {
+			continue
+		}
+		s := t.states[addr]
+		s.postBalance = new(big.Int)
+		s.postNonce = 0
+		s.postCode = nil
+		for slot := range s.postStorage {
+			s.postStorage[slot] = common.Hash{}
+		}
+	}
+}
+
+// OnEnter records the number of balance changes made before the call frame,
+// so the ones made within can be dropped if it reverts.
+func (t *stateDiffTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.frames = append(t.frames, len(t.changes))
+}
+
+// OnExit drops the balance changes of a reverted call frame.
+func (t *stateDiffTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if reverted {
+		t.changes = t.changes[:start]
+	}
+}
+
+// lookup returns the tracked state of an account, loading its pre state from
+// the state database on first access.
+func (t *stateDiffTracer) lookup(addr common.Address) *diffState {
+	if s, ok := t.states[addr]; ok {
+		return s
+	}
+	s := &diffState{
+		preBalance:  new(big.Int),
+		preStorage:  make(map[common.Hash]common.Hash),
+		postStorage: make(map[common.Hash]common.Hash),
+	}
+	if t.env != nil && t.env.StateDB != nil {
+		s.preBalance = t.env.StateDB.GetBalance(addr).ToBig()
+		s.preNonce = t.env.StateDB.GetNonce(addr)
+		s.preCode = common.CopyBytes(t.env.StateDB.GetCode(addr))
+	}
+	s.postBalance = new(big.Int).Set(s.preBalance)
+	s.postNonce = s.preNonce
+	s.postCode = s.preCode
+
+	t.states[addr] = s
+	t.order = append(t.order, addr)
+	return s
+}
+
+// OnBalanceChange tracks the balance of the account and attributes the change
+// to its cause.
+func (t *stateDiffTracer) OnBalanceChange(addr common.Address, prev, cur *big.Int, reason tracing.BalanceChangeReason) {
+	if t.interrupt.Load() {
+		return
+	}
+	s, known := t.states[addr]
+	if !known {
+		s = t.lookup(addr)
+		s.preBalance = new(big.Int).Set(prev)
+	}
+	s.postBalance = new(big.Int).Set(cur)
+
+	// Reverts are undone state changes, the original change was already dropped
+	// together with the reverted call frame.
+	if reason == tracing.BalanceChangeRevert {
+		return
+	}
+	t.changes = append(t.changes, balanceChange{
+		Address: addr,
+		Cause:   t.cause(addr, reason),
+		Delta:   (*hexutil.Big)(new(big.Int).Sub(cur, prev)),
+	})
+}
+
+// cause maps a balance change reason to its cause.
+func (t *stateDiffTracer) cause(addr common.Address, reason tracing.BalanceChangeReason) string {
+	switch reason {
+	case tracing.BalanceChangeTransfer, tracing.BalanceIncreaseSelfdestruct, tracing.BalanceDecreaseSelfdestruct:
+		return causeTransfer
+	case tracing.BalanceDecreaseSelfdestructBurn:
+		return causeBurn
+	case tracing.BalanceDecreaseGasBuy:
+		if addr != t.from {
+			return causeSponsorPayment
+		}
+		return causeGasBuy
+	case tracing.BalanceIncreaseGasReturn:
+		if addr != t.from {
+			return causeSponsorRefund
+		}
+		return causeGasRefund
+	case tracing.BalanceIncreaseRewardTransactionFee:
+		switch {
+		case t.env != nil && addr == t.env.Coinbase:
+			return causeSequencerFee
+		case addr == params.OptimismBaseFeeRecipient:
+			return causeBaseFeeVault
+		case addr == params.OptimismL1FeeRecipient:
+			return causeL1FeeVault
+		}
+		return causeSequencerFee
+	case tracing.BalanceMint:
+		return causeDepositMint
+	case tracing.BalanceIncreaseWithdrawal:
+		return causeWithdrawal
+	case tracing.BalanceIncreaseRewardMineBlock, tracing.BalanceIncreaseRewardMineUncle:
+		return causeBlockReward
+	case tracing.BalanceIncreaseGenesisBalance:
+		return causeGenesis
+	}
+	return causeOther
+}
+
+// OnNonceChangeV2 tracks the nonce of the account.
+func (t *stateDiffTracer) OnNonceChangeV2(addr common.Address, prev, cur uint64, reason tracing.NonceChangeReason) {
+	if t.interrupt.Load() {
+		return
+	}
+	s, known := t.states[addr]
+	if !known {
+		s = t.lookup(addr)
+		s.preNonce = prev
+	}
+	s.postNonce = cur
+}
+
+// OnCodeChange tracks the code of the account.
+func (t *stateDiffTracer) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if t.interrupt.Load() {
+		return
+	}
+	s, known := t.states[addr]
+	if !known {
+		s = t.lookup(addr)
+		s.preCode = common.CopyBytes(prevCode)
+	}
+	s.postCode = common.CopyBytes(code)
+}
+
+// OnStorageChange tracks the modified storage slots of the account.
+func (t *stateDiffTracer) OnStorageChange(addr common.Address, slot common.Hash, prev, cur common.Hash) {
+	if t.interrupt.Load() {
+		return
+	}
+	s := t.lookup(addr)
+	if _, ok := s.preStorage[slot]; !ok {
+		s.preStorage[slot] = prev
+	}
+	s.postStorage[slot] = cur
+}
+
+// OnLog attributes the BVM_ETH mints and transfers, which move balances kept
+// in the storage of the BVM_ETH contract.
+func (t *stateDiffTracer) OnLog(log *types.Log) {
+	if t.interrupt.Load() || log.Address != core.BVM_ETH_ADDR || len(log.Topics) == 0 || len(log.Data) != 32 {
+		return
+	}
+	token := core.BVM_ETH_ADDR
+	amount := new(big.Int).SetBytes(log.Data)
+
+	switch topics := log.Topics; {
+	case topics[0] == bvmETHMintEventTopic && len(topics) == 2:
+		t.changes = append(t.changes, balanceChange{
+			Address: common.BytesToAddress(topics[1][:]),
+			Token:   &token,
+			Cause:   causeBVMETHMint,
+			Delta:   (*hexutil.Big)(amount),
+		})
+	case topics[0] == transferEventTopic && len(topics) == 3:
+		from, to := common.BytesToAddress(topics[1][:]), common.BytesToAddress(topics[2][:])
+		if from != (common.Address{}) {
+			t.changes = append(t.changes, balanceChange{
+				Address: from,
+				Token:   &token,
+				Cause:   causeBVMETHTransfer,
+				Delta:   (*hexutil.Big)(new(big.Int).Neg(amount)),
+			})
+		}
+		if to != (common.Address{}) {
+			t.changes = append(t.changes, balanceChange{
+				Address: to,
+				Token:   &token,
+				Cause:   causeBVMETHTransfer,
+				Delta:   (*hexutil.Big)(amount),
+			})
+		}
+	}
+}
+
+// GetResult returns the json-encoded state diff and balance changes, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *stateDiffTracer) GetResult() (json.RawMessage, error) {
+	var (
+		res json.RawMessage
+		err error
+	)
+	if t.config.Parity {
+		res, err = json.Marshal(t.parityDiff())
+	} else {
+		result := stateDiffResult{
+			StateDiff:      t.diff(),
+			BalanceChanges: t.changes,
+		}
+		if t.l1Fee != nil {
+			result.L1Fee = (*hexutil.Big)(t.l1Fee)
+		}
+		res, err = json.Marshal(result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *stateDiffTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// diff assembles the modified state of all touched accounts, leaving out the
+// unchanged fields and accounts.
+func (t *stateDiffTracer) diff() map[common.Address]*stateDiffAccount {
+	diff := make(map[common.Address]*stateDiffAccount)
+	for _, addr := range t.order {
+		s := t.states[addr]
+		acc := &stateDiffAccount{
+			Born: s.empty(true) && !s.empty(false),
+			Died: !s.empty(true) && s.empty(false),
+		}
+		modified := false
+		if s.preBalance.Cmp(s.postBalance) != 0 {
+			acc.Balance = &stateDiffValue[*hexutil.Big]{From: (*hexutil.Big)(s.preBalance), To: (*hexutil.Big)(s.postBalance)}
+			modified = true
+		}
+		if s.preNonce != s.postNonce {
+			acc.Nonce = &stateDiffValue[hexutil.Uint64]{From: hexutil.Uint64(s.preNonce), To: hexutil.Uint64(s.postNonce)}
+			modified = true
+		}
+		if !bytes.Equal(s.preCode, s.postCode) {
+			acc.Code = &stateDiffValue[hexutil.Bytes]{From: s.preCode, To: s.postCode}
+			modified = true
+		}
+		for slot, pre := range s.preStorage {
+			if post := s.postStorage[slot]; pre != post {
+				if acc.Storage == nil {
+					acc.Storage = make(map[common.Hash]stateDiffValue[common.Hash])
+				}
+				acc.Storage[slot] = stateDiffValue[common.Hash]{From: pre, To: post}
+				modified = true
+			}
+		}
+		if modified {
+			diff[addr] = acc
+		}
+	}
+	return diff
+}
+
+// parityDiff assembles the state diff in the format of parity's
+// trace_replayTransaction, where each field is marked as unchanged ("="),
+// added ({"+": value}), removed ({"-": value}) or modified
+// ({"*": {"from": value, "to": value}}).
+func (t *stateDiffTracer) parityDiff() map[common.Address]map[string]any {
+	var (
+		diff = make(map[common.Address]map[string]any)
+		src  = t.diff()
+	)
+	for addr, acc := range src {
+		var (
+			s     = t.states[addr]
+			entry = make(map[string]any)
+		)
+		mark := func(changed bool, pre, post any) any {
+			switch {
+			case acc.Born:
+				return map[string]any{"+": post}
+			case acc.Died:
+				return map[string]any{"-": pre}
+			case !changed:
+				return "="
+			}
+			return map[string]any{"*": map[string]any{"from": pre, "to": post}}
+		}
+		entry["balance"] = mark(acc.Balance != nil, (*hexutil.Big)(s.preBalance), (*hexutil.Big)(s.postBalance))
+		entry["nonce"] = mark(acc.Nonce != nil, hexutil.Uint64(s.preNonce), hexutil.Uint64(s.postNonce))
+		entry["code"] = mark(acc.Code != nil, hexutil.Bytes(s.preCode), hexutil.Bytes(s.postCode))
+
+		storage := make(map[common.Hash]any)
+		for slot, item := range acc.Storage {
+			storage[slot] = mark(true, item.From, item.To)
+		}
+		entry["storage"] = storage
+		diff[addr] = entry
+	}
+	return diff
+}
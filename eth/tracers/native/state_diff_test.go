@@ -0,0 +1,179 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	diffAlice    = common.HexToAddress("0xa1")
+	diffBob      = common.HexToAddress("0xb0b")
+	diffSponsor  = common.HexToAddress("0x5905")
+	diffContract = common.HexToAddress("0xc0de")
+	diffCoinbase = common.HexToAddress("0xc0ffee")
+)
+
+// runStateDiffTracer drives the stateDiffTracer through a meta transaction
+// calling a contract, which in turn does a reverted call to bob.
+func runStateDiffTracer(t *testing.T, config json.RawMessage) json.RawMessage {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	require.NoError(t, err)
+	statedb.SetBalance(diffAlice, uint256.NewInt(100), tracing.BalanceChangeUnspecified)
+	statedb.SetNonce(diffAlice, 1, tracing.NonceChangeUnspecified)
+	statedb.SetBalance(diffSponsor, uint256.NewInt(50), tracing.BalanceChangeUnspecified)
+	for _, addr := range []common.Address{diffBob, diffContract, diffCoinbase, params.OptimismBaseFeeRecipient} {
+		statedb.CreateAccount(addr)
+	}
+	tracer, err := tracers.DefaultDirectory.New("stateDiffTracer", &tracers.Context{}, config, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		hooks = tracer.Hooks
+		num   = big.NewInt
+		mint  = crypto.Keccak256Hash([]byte("Mint(address,uint256)"))
+	)
+	hooks.OnTxStart(&tracing.VMContext{Coinbase: diffCoinbase, StateDB: statedb}, types.NewTx(&types.LegacyTx{}), diffAlice)
+
+	// Upfront gas payment split between the sender and the sponsor
+	hooks.OnBalanceChange(diffAlice, num(100), num(90), tracing.BalanceDecreaseGasBuy)
+	hooks.OnBalanceChange(diffSponsor, num(50), num(45), tracing.BalanceDecreaseGasBuy)
+	hooks.OnNonceChangeV2(diffAlice, 1, 2, tracing.NonceChangeEoACall)
+	hooks.OnLog(&types.Log{Address: core.BVM_ETH_ADDR, Topics: []common.Hash{mint, diffAlice.Hash()}, Data: common.LeftPadBytes([]byte{9}, 32)})
+
+	hooks.OnEnter(0, byte(vm.CALL), diffAlice, diffContract, nil, 0, num(5))
+	hooks.OnBalanceChange(diffAlice, num(90), num(85), tracing.BalanceChangeTransfer)
+	hooks.OnBalanceChange(diffContract, num(0), num(5), tracing.BalanceChangeTransfer)
+	hooks.OnStorageChange(diffContract, common.Hash{1}, common.Hash{}, common.Hash{0x11})
+
+	hooks.OnEnter(1, byte(vm.CALL), diffContract, diffBob, nil, 0, num(2))
+	hooks.OnBalanceChange(diffContract, num(5), num(3), tracing.BalanceChangeTransfer)
+	hooks.OnBalanceChange(diffBob, num(0), num(2), tracing.BalanceChangeTransfer)
+	hooks.OnStorageChange(diffContract, common.Hash{2}, common.Hash{}, common.Hash{0x22})
+	hooks.OnExit(1, nil, 0, vm.ErrExecutionReverted, true)
+
+	hooks.OnExit(0, nil, 0, nil, false)
+
+	// Refund and fee payments
+	hooks.OnBalanceChange(diffAlice, num(85), num(88), tracing.BalanceIncreaseGasReturn)
+	hooks.OnBalanceChange(diffCoinbase, num(0), num(4), tracing.BalanceIncreaseRewardTransactionFee)
+	hooks.OnBalanceChange(params.OptimismBaseFeeRecipient, num(0), num(3), tracing.BalanceIncreaseRewardTransactionFee)
+	hooks.OnTxEnd(&types.Receipt{L1Fee: num(2)}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res
+}
+
+func TestStateDiffTracer(t *testing.T) {
+	type diffValue struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	type diffAccount struct {
+		Balance *diffValue                `json:"balance"`
+		Nonce   *diffValue                `json:"nonce"`
+		Code    *diffValue                `json:"code"`
+		Storage map[common.Hash]diffValue `json:"storage"`
+		Born    bool                      `json:"born"`
+		Died    bool                      `json:"died"`
+	}
+	type balanceChange struct {
+		Address common.Address  `json:"address"`
+		Token   *common.Address `json:"token"`
+		Cause   string          `json:"cause"`
+		Delta   string          `json:"delta"`
+	}
+	var have struct {
+		StateDiff      map[common.Address]diffAccount `json:"stateDiff"`
+		BalanceChanges []balanceChange                `json:"balanceChanges"`
+		L1Fee          *hexutil.Big                   `json:"l1Fee"`
+	}
+	require.NoError(t, json.Unmarshal(runStateDiffTracer(t, nil), &have))
+
+	require.Equal(t, map[common.Address]diffAccount{
+		diffAlice: {
+			Balance: &diffValue{From: "0x64", To: "0x58"},
+			Nonce:   &diffValue{From: "0x1", To: "0x2"},
+		},
+		diffSponsor: {
+			Balance: &diffValue{From: "0x32", To: "0x2d"},
+		},
+		diffContract: {
+			Balance: &diffValue{From: "0x0", To: "0x5"},
+			Storage: map[common.Hash]diffValue{
+				{1}: {From: common.Hash{}.Hex(), To: common.Hash{0x11}.Hex()},
+			},
+			Born: true,
+		},
+		diffCoinbase: {
+			Balance: &diffValue{From: "0x0", To: "0x4"},
+			Born:    true,
+		},
+		params.OptimismBaseFeeRecipient: {
+			Balance: &diffValue{From: "0x0", To: "0x3"},
+			Born:    true,
+		},
+	}, have.StateDiff)
+
+	bvmETH := core.BVM_ETH_ADDR
+	change := func(addr common.Address, cause string, delta int64) balanceChange {
+		return balanceChange{Address: addr, Cause: cause, Delta: hexutil.EncodeBig(big.NewInt(delta))}
+	}
+	require.Equal(t, []balanceChange{
+		change(diffAlice, "gasBuy", -10),
+		change(diffSponsor, "sponsorPayment", -5),
+		{Address: diffAlice, Token: &bvmETH, Cause: "bvmEthMint", Delta: "0x9"},
+		change(diffAlice, "transfer", -5),
+		change(diffContract, "transfer", 5),
+		change(diffAlice, "gasRefund", 3),
+		change(diffCoinbase, "sequencerFee", 4),
+		change(params.OptimismBaseFeeRecipient, "baseFeeVault", 3),
+	}, have.BalanceChanges)
+	require.Equal(t, big.NewInt(2), have.L1Fee.ToInt())
+}
+
+func TestStateDiffTracerParity(t *testing.T) {
+	var have map[common.Address]map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(runStateDiffTracer(t, json.RawMessage(`{"parity":true}`)), &have))
+
+	require.Len(t, have, 5)
+	require.JSONEq(t, `{"*":{"from":"0x64","to":"0x58"}}`, string(have[diffAlice]["balance"]))
+	require.JSONEq(t, `{"*":{"from":"0x1","to":"0x2"}}`, string(have[diffAlice]["nonce"]))
+	require.JSONEq(t, `"="`, string(have[diffAlice]["code"]))
+	require.JSONEq(t, `{}`, string(have[diffAlice]["storage"]))
+
+	require.JSONEq(t, `{"+":"0x5"}`, string(have[diffContract]["balance"]))
+	require.JSONEq(t, `{"+":"0x0"}`, string(have[diffContract]["nonce"]))
+	require.JSONEq(t, `{"+":"0x"}`, string(have[diffContract]["code"]))
+	require.JSONEq(t, `{"`+common.Hash{1}.Hex()+`":{"+":"`+common.Hash{0x11}.Hex()+`"}}`, string(have[diffContract]["storage"]))
+}
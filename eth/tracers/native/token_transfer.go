@@ -0,0 +1,286 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("tokenTransferTracer", newTokenTransferTracer, false)
+}
+
+// Token standards reported by the tokenTransferTracer.
+const (
+	standardNative  = "native"  // Native token value transfers and deposit mints
+	standardBVMETH  = "bvmeth"  // Mantle BVM_ETH transfers and mints
+	standardERC20   = "erc20"   // ERC-20 Transfer events
+	standardERC721  = "erc721"  // ERC-721 Transfer events
+	standardERC1155 = "erc1155" // ERC-1155 TransferSingle and TransferBatch events
+)
+
+var (
+	// keccak256("Transfer(address,address,uint256)")
+	transferEventTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	// keccak256("TransferSingle(address,address,address,uint256,uint256)")
+	transferSingleEventTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+	// keccak256("TransferBatch(address,address,address,uint256[],uint256[])")
+	transferBatchEventTopic = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+	// keccak256("Mint(address,uint256)"), emitted by the BVM_ETH contract
+	bvmETHMintEventTopic = common.HexToHash("0x0f6798a560793a54c3bcfe86a93cde1e73087d944c0ea20544137d4121396885")
+)
+
+// tokenTransfer is a normalized record of a token movement.
+type tokenTransfer struct {
+	Token    common.Address  `json:"token"` // Zero address for the native token
+	Standard string          `json:"standard"`
+	From     common.Address  `json:"from"` // Zero address for mints
+	To       common.Address  `json:"to"`   // Zero address for burns
+	Operator *common.Address `json:"operator,omitempty"`
+	Amount   *hexutil.Big    `json:"amount,omitempty"`
+	TokenID  *hexutil.Big    `json:"tokenId,omitempty"`
+	Depth    int             `json:"depth"`
+	Reverted bool            `json:"reverted"`
+}
+
+// tokenTransferTracer collects the native token, BVM_ETH, ERC-20, ERC-721 and
+// ERC-1155 transfers of a transaction into a flat list, in execution order.
+// Native transfers are taken from the call value of each call frame and from
+// deposit mints, token transfers are decoded from the standard events. Any
+// transfer done within a reverted call frame is flagged as reverted.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "tokenTransferTracer"})
+//	[{
+//	  token: "0x0000000000000000000000000000000000000000",
+//	  standard: "native",
+//	  from: "0x...",
+//	  to: "0x...",
+//	  amount: "0xde0b6b3a7640000",
+//	  depth: 0,
+//	  reverted: false
+//	}]
+type tokenTransferTracer struct {
+	transfers []tokenTransfer
+	frames    []int       // Index of the first transfer made by each call frame on the stack
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newTokenTransferTracer returns a native go tracer which collects the token
+// transfers of a transaction.
+func newTokenTransferTracer(ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	t := &tokenTransferTracer{
+		transfers: make([]tokenTransfer, 0),
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnEnter:         t.OnEnter,
+			OnExit:          t.OnExit,
+			OnLog:           t.OnLog,
+			OnBalanceChange: t.OnBalanceChange,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+// depth returns the depth of the currently executing call frame.
+func (t *tokenTransferTracer) depth() int {
+	if len(t.frames) == 0 {
+		return 0
+	}
+	return len(t.frames) - 1
+}
+
+// OnEnter records the value transferred by a new call frame.
+func (t *tokenTransferTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.frames = append(t.frames, len(t.transfers))
+
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+	if op := vm.OpCode(typ); op == vm.DELEGATECALL || op == vm.STATICCALL || op == vm.CALLCODE {
+		return
+	}
+	t.transfers = append(t.transfers, tokenTransfer{
+		Standard: standardNative,
+		From:     from,
+		To:       to,
+		Amount:   (*hexutil.Big)(new(big.Int).Set(value)),
+		Depth:    depth,
+	})
+}
+
+// OnExit flags all transfers of a reverted call frame as reverted.
+func (t *tokenTransferTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if reverted {
+		for i := start; i < len(t.transfers); i++ {
+			t.transfers[i].Reverted = true
+		}
+	}
+}
+
+// OnBalanceChange records the native tokens minted by deposit transactions.
+func (t *tokenTransferTracer) OnBalanceChange(addr common.Address, prev, cur *big.Int, reason tracing.BalanceChangeReason) {
+	if t.interrupt.Load() || reason != tracing.BalanceMint {
+		return
+	}
+	t.transfers = append(t.transfers, tokenTransfer{
+		Standard: standardNative,
+		To:       addr,
+		Amount:   (*hexutil.Big)(new(big.Int).Sub(cur, prev)),
+		Depth:    t.depth(),
+	})
+}
+
+// OnLog decodes the token transfer events.
+func (t *tokenTransferTracer) OnLog(log *types.Log) {
+	if t.interrupt.Load() || len(log.Topics) == 0 {
+		return
+	}
+	transfer := tokenTransfer{
+		Token: log.Address,
+		Depth: t.depth(),
+	}
+	switch topics := log.Topics; {
+	case log.Address == core.BVM_ETH_ADDR && topics[0] == bvmETHMintEventTopic && len(topics) == 2 && len(log.Data) == 32:
+		transfer.Standard = standardBVMETH
+		transfer.To = common.BytesToAddress(topics[1][:])
+		transfer.Amount = (*hexutil.Big)(new(big.Int).SetBytes(log.Data))
+
+	case topics[0] == transferEventTopic && len(topics) == 3 && len(log.Data) == 32:
+		transfer.Standard = standardERC20
+		if log.Address == core.BVM_ETH_ADDR {
+			transfer.Standard = standardBVMETH
+		}
+		transfer.From = common.BytesToAddress(topics[1][:])
+		transfer.To = common.BytesToAddress(topics[2][:])
+		transfer.Amount = (*hexutil.Big)(new(big.Int).SetBytes(log.Data))
+
+	case topics[0] == transferEventTopic && len(topics) == 4 && len(log.Data) == 0:
+		transfer.Standard = standardERC721
+		transfer.From = common.BytesToAddress(topics[1][:])
+		transfer.To = common.BytesToAddress(topics[2][:])
+		transfer.TokenID = (*hexutil.Big)(topics[3].Big())
+
+	case topics[0] == transferSingleEventTopic && len(topics) == 4 && len(log.Data) == 64:
+		transfer.Standard = standardERC1155
+		operator := common.BytesToAddress(topics[1][:])
+		transfer.Operator = &operator
+		transfer.From = common.BytesToAddress(topics[2][:])
+		transfer.To = common.BytesToAddress(topics[3][:])
+		transfer.TokenID = (*hexutil.Big)(new(big.Int).SetBytes(log.Data[:32]))
+		transfer.Amount = (*hexutil.Big)(new(big.Int).SetBytes(log.Data[32:]))
+
+	case topics[0] == transferBatchEventTopic && len(topics) == 4:
+		ids, amounts, ok := decodeTransferBatch(log.Data)
+		if !ok {
+			return
+		}
+		transfer.Standard = standardERC1155
+		operator := common.BytesToAddress(topics[1][:])
+		transfer.Operator = &operator
+		transfer.From = common.BytesToAddress(topics[2][:])
+		transfer.To = common.BytesToAddress(topics[3][:])
+		for i := range ids {
+			item := transfer
+			item.TokenID = (*hexutil.Big)(ids[i])
+			item.Amount = (*hexutil.Big)(amounts[i])
+			t.transfers = append(t.transfers, item)
+		}
+		return
+
+	default:
+		return
+	}
+	t.transfers = append(t.transfers, transfer)
+}
+
+// GetResult returns the json-encoded list of token transfers, and any error
+// arising from the encoding or forceful termination (via `Stop`).
+func (t *tokenTransferTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.transfers)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *tokenTransferTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// decodeTransferBatch decodes the ABI encoded (uint256[] ids, uint256[] values)
+// data of an ERC-1155 TransferBatch event.
+func decodeTransferBatch(data []byte) ([]*big.Int, []*big.Int, bool) {
+	ids, ok := decodeUint256Array(data, 0)
+	if !ok {
+		return nil, nil, false
+	}
+	amounts, ok := decodeUint256Array(data, 32)
+	if !ok || len(ids) != len(amounts) {
+		return nil, nil, false
+	}
+	return ids, amounts, true
+}
+
+// decodeUint256Array decodes a dynamic uint256 array whose offset is stored in
+// the head word at pos.
+func decodeUint256Array(data []byte, pos int) ([]*big.Int, bool) {
+	if len(data) < pos+32 {
+		return nil, false
+	}
+	offset := new(big.Int).SetBytes(data[pos : pos+32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return nil, false
+	}
+	start := int(offset.Uint64())
+	size := new(big.Int).SetBytes(data[start : start+32])
+	if !size.IsUint64() || size.Uint64() > uint64(len(data)-start-32)/32 {
+		return nil, false
+	}
+	items := make([]*big.Int, size.Uint64())
+	for i := range items {
+		word := start + 32 + 32*i
+		items[i] = new(big.Int).SetBytes(data[word : word+32])
+	}
+	return items, true
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenTransfer struct {
+	Token    common.Address  `json:"token"`
+	Standard string          `json:"standard"`
+	From     common.Address  `json:"from"`
+	To       common.Address  `json:"to"`
+	Operator *common.Address `json:"operator"`
+	Amount   *hexutil.Big    `json:"amount"`
+	TokenID  *hexutil.Big    `json:"tokenId"`
+	Depth    int             `json:"depth"`
+	Reverted bool            `json:"reverted"`
+}
+
+func TestTokenTransferTracer(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("tokenTransferTracer", &tracers.Context{}, nil, params.MainnetChainConfig)
+	require.NoError(t, err)
+
+	var (
+		alice    = common.HexToAddress("0xa1")
+		bob      = common.HexToAddress("0xb0b")
+		erc20    = common.HexToAddress("0x20")
+		nft      = common.HexToAddress("0x721")
+		multi    = common.HexToAddress("0x1155")
+		transfer = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+		single   = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+		batch    = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+		word     = func(v int64) []byte { return common.LeftPadBytes(big.NewInt(v).Bytes(), 32) }
+	)
+	// Deposit mint and BVM_ETH transfer happen before the top level call
+	tracer.OnBalanceChange(alice, big.NewInt(1), big.NewInt(11), tracing.BalanceMint)
+	tracer.OnLog(&types.Log{Address: core.BVM_ETH_ADDR, Topics: []common.Hash{transfer, alice.Hash(), bob.Hash()}, Data: word(5)})
+
+	tracer.OnEnter(0, byte(vm.CALL), alice, erc20, nil, 0, big.NewInt(7))
+	tracer.OnLog(&types.Log{Address: erc20, Topics: []common.Hash{transfer, alice.Hash(), bob.Hash()}, Data: word(100)})
+
+	// Nested reverted call moving an NFT and some value
+	tracer.OnEnter(1, byte(vm.CALL), erc20, nft, nil, 0, big.NewInt(3))
+	tracer.OnLog(&types.Log{Address: nft, Topics: []common.Hash{transfer, alice.Hash(), bob.Hash(), common.BigToHash(big.NewInt(42))}})
+	tracer.OnExit(1, nil, 0, vm.ErrExecutionReverted, true)
+
+	// Delegate calls carry no value of their own
+	tracer.OnEnter(1, byte(vm.DELEGATECALL), erc20, multi, nil, 0, big.NewInt(7))
+	tracer.OnLog(&types.Log{Address: multi, Topics: []common.Hash{single, erc20.Hash(), alice.Hash(), bob.Hash()}, Data: append(word(1), word(2)...)})
+
+	data := append(word(64), word(160)...)
+	data = append(data, append(word(2), append(word(3), word(4)...)...)...)
+	data = append(data, append(word(2), append(word(30), word(40)...)...)...)
+	tracer.OnLog(&types.Log{Address: multi, Topics: []common.Hash{batch, erc20.Hash(), alice.Hash(), bob.Hash()}, Data: data})
+	tracer.OnExit(1, nil, 0, nil, false)
+
+	// Unrelated events are ignored
+	tracer.OnLog(&types.Log{Address: erc20, Topics: []common.Hash{common.HexToHash("0x01")}})
+	tracer.OnExit(0, nil, 0, nil, false)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var have []tokenTransfer
+	require.NoError(t, json.Unmarshal(res, &have))
+
+	num := func(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+	want := []tokenTransfer{
+		{Standard: "native", To: alice, Amount: num(10)},
+		{Token: core.BVM_ETH_ADDR, Standard: "bvmeth", From: alice, To: bob, Amount: num(5)},
+		{Standard: "native", From: alice, To: erc20, Amount: num(7)},
+		{Token: erc20, Standard: "erc20", From: alice, To: bob, Amount: num(100)},
+		{Standard: "native", From: erc20, To: nft, Amount: num(3), Depth: 1, Reverted: true},
+		{Token: nft, Standard: "erc721", From: alice, To: bob, TokenID: num(42), Depth: 1, Reverted: true},
+		{Token: multi, Standard: "erc1155", Operator: &erc20, From: alice, To: bob, TokenID: num(1), Amount: num(2), Depth: 1},
+		{Token: multi, Standard: "erc1155", Operator: &erc20, From: alice, To: bob, TokenID: num(3), Amount: num(30), Depth: 1},
+		{Token: multi, Standard: "erc1155", Operator: &erc20, From: alice, To: bob, TokenID: num(4), Amount: num(40), Depth: 1},
+	}
+	require.Equal(t, want, have)
+}
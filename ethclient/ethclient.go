@@ -43,7 +43,15 @@ func Dial(rawurl string) (*Client, error) {
 
 // DialContext connects a client to the given URL with context.
 func DialContext(ctx context.Context, rawurl string) (*Client, error) {
-	c, err := rpc.DialContext(ctx, rawurl)
+	return DialOptions(ctx, rawurl)
+}
+
+// DialOptions connects a client to the given URL with context and options. Over
+// HTTP, the responses are requested in binary encoding, servers not supporting
+// it answer in JSON.
+func DialOptions(ctx context.Context, rawurl string, options ...rpc.ClientOption) (*Client, error) {
+	options = append([]rpc.ClientOption{rpc.WithBinaryEncoding()}, options...)
+	c, err := rpc.DialOptions(ctx, rawurl, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -24,6 +24,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -242,6 +243,44 @@ func generateTestChain(genesis *core.Genesis, length int) []*types.Block {
 	return append([]*types.Block{genesis.ToBlock()}, blocks...)
 }
 
+type chainIDService struct{}
+
+func (chainIDService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(5000))
+}
+
+// Tests that the client requests the HTTP responses in binary encoding.
+func TestEthClientBinaryEncoding(t *testing.T) {
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", chainIDService{}); err != nil {
+		t.Fatal(err)
+	}
+	var accept string
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		server.ServeHTTP(w, r)
+	}))
+	defer httpsrv.Close()
+
+	client, err := ethclient.Dial(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	id, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Uint64() != 5000 {
+		t.Fatalf("wrong chain id %v", id)
+	}
+	if !strings.HasPrefix(accept, "application/cbor") {
+		t.Fatalf("binary encoding not requested, accept header %q", accept)
+	}
+}
+
 func TestEthClientHistoricalBackend(t *testing.T) {
 	backend, _, err := newTestBackend(t, nil, true)
 	if err != nil {
@@ -139,6 +139,72 @@ func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash })
 	return state.GetState(a.address, args.Slot), nil
 }
 
+// AccountTransactions is a page of the transactions an account took part in.
+type AccountTransactions struct {
+	transactions []*Transaction
+	cursor       *hexutil.Bytes
+}
+
+func (a *AccountTransactions) Transactions(ctx context.Context) []*Transaction {
+	return a.transactions
+}
+
+func (a *AccountTransactions) Cursor(ctx context.Context) *hexutil.Bytes {
+	return a.cursor
+}
+
+func (a *Account) Transactions(ctx context.Context, args struct {
+	FromBlock *Long
+	ToBlock   *Long
+	Cursor    *hexutil.Bytes
+}) (*AccountTransactions, error) {
+	var from, to *uint64
+	if args.FromBlock != nil {
+		if *args.FromBlock < 0 {
+			return nil, errors.New("invalid fromBlock")
+		}
+		n := uint64(*args.FromBlock)
+		from = &n
+	}
+	if args.ToBlock != nil {
+		if *args.ToBlock < 0 {
+			return nil, errors.New("invalid toBlock")
+		}
+		n := uint64(*args.ToBlock)
+		to = &n
+	}
+	var cursor []byte
+	if args.Cursor != nil {
+		cursor = *args.Cursor
+	}
+	entries, next, err := ethapi.TransactionsByAddress(ctx, a.r.backend, a.address, from, to, cursor)
+	if err != nil {
+		return nil, err
+	}
+	res := &AccountTransactions{transactions: make([]*Transaction, 0, len(entries))}
+	if next != nil {
+		res.cursor = (*hexutil.Bytes)(&next)
+	}
+	var block *Block
+	for _, entry := range entries {
+		if block == nil || block.hash != entry.BlockHash {
+			numberOrHash := rpc.BlockNumberOrHashWithHash(entry.BlockHash, true)
+			block = &Block{
+				r:            a.r,
+				numberOrHash: &numberOrHash,
+				hash:         entry.BlockHash,
+			}
+		}
+		res.transactions = append(res.transactions, &Transaction{
+			r:     a.r,
+			hash:  entry.TxHash,
+			block: block,
+			index: uint64(entry.TxIndex),
+		})
+	}
+	return res, nil
+}
+
 // Log represents an individual log message. All arguments are mandatory.
 type Log struct {
 	r           *Resolver
@@ -229,6 +295,13 @@ func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, *Block)
 	if t.tx != nil {
 		return t.tx, t.block
 	}
+	// Transactions referenced by position are resolved from their block
+	if t.block != nil {
+		if block, _ := t.block.resolve(ctx); block != nil && t.index < uint64(len(block.Transactions())) {
+			t.tx = block.Transactions()[t.index]
+			return t.tx, t.block
+		}
+	}
 	// Try to return an already finalized transaction
 	found, tx, blockHash, _, index := t.r.backend.GetTransaction(t.hash)
 	if found {
@@ -649,6 +722,122 @@ func (t *Transaction) RawReceipt(ctx context.Context) (hexutil.Bytes, error) {
 	return receipt.MarshalBinary()
 }
 
+// depositTx returns the transaction if it is a deposit, nil otherwise.
+func (t *Transaction) depositTx(ctx context.Context) *types.Transaction {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || !tx.IsDepositTx() {
+		return nil
+	}
+	return tx
+}
+
+func (t *Transaction) SourceHash(ctx context.Context) *common.Hash {
+	tx := t.depositTx(ctx)
+	if tx == nil {
+		return nil
+	}
+	hash := tx.SourceHash()
+	return &hash
+}
+
+func (t *Transaction) Mint(ctx context.Context) *hexutil.Big {
+	if tx := t.depositTx(ctx); tx != nil {
+		return (*hexutil.Big)(tx.Mint())
+	}
+	return nil
+}
+
+func (t *Transaction) EthValue(ctx context.Context) *hexutil.Big {
+	if tx := t.depositTx(ctx); tx != nil {
+		return (*hexutil.Big)(tx.ETHValue())
+	}
+	return nil
+}
+
+func (t *Transaction) EthTxValue(ctx context.Context) *hexutil.Big {
+	if tx := t.depositTx(ctx); tx != nil {
+		return (*hexutil.Big)(tx.ETHTxValue())
+	}
+	return nil
+}
+
+func (t *Transaction) IsSystemTx(ctx context.Context) *bool {
+	tx := t.depositTx(ctx)
+	if tx == nil {
+		return nil
+	}
+	isSystemTx := tx.IsSystemTx()
+	return &isSystemTx
+}
+
+// getL1CostReceipt returns the receipt of the transaction if it is charged for
+// its L1 data, nil otherwise.
+func (t *Transaction) getL1CostReceipt(ctx context.Context) (*types.Receipt, error) {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || tx.IsDepositTx() || t.r.backend.ChainConfig().Optimism == nil {
+		return nil, nil
+	}
+	return t.getReceipt(ctx)
+}
+
+func (t *Transaction) L1Fee(ctx context.Context) (*hexutil.Big, error) {
+	receipt, err := t.getL1CostReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(receipt.L1Fee), nil
+}
+
+func (t *Transaction) L1GasUsed(ctx context.Context) (*hexutil.Big, error) {
+	receipt, err := t.getL1CostReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(receipt.L1GasUsed), nil
+}
+
+func (t *Transaction) TokenRatio(ctx context.Context) (*hexutil.Big, error) {
+	receipt, err := t.getL1CostReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(receipt.TokenRatio), nil
+}
+
+func (t *Transaction) MetaTx(ctx context.Context) (*MetaTx, error) {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || tx.Type() != types.DynamicFeeTxType {
+		return nil, nil
+	}
+	params, err := types.DecodeMetaTxParams(tx.Data())
+	if err != nil || params == nil {
+		return nil, err
+	}
+	return &MetaTx{r: t.r, params: params}, nil
+}
+
+// MetaTx represents the gas fee sponsorship of a meta transaction.
+type MetaTx struct {
+	r      *Resolver
+	params *types.MetaTxParams
+}
+
+func (m *MetaTx) Sponsor(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		r:             m.r,
+		address:       m.params.GasFeeSponsor,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (m *MetaTx) SponsorPercent(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(m.params.SponsorPercent)
+}
+
+func (m *MetaTx) ExpireHeight(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(m.params.ExpireHeight)
+}
+
 type BlockType int
 
 // Block represents an Ethereum block.
@@ -1386,6 +1575,100 @@ func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hex
 	return hash, err
 }
 
+// preconfChanSize is the size of the channel buffering the preconfirmation
+// results of a subscription.
+const preconfChanSize = 4096
+
+// PreconfLog represents an event log predicted by a preconfirmation.
+type PreconfLog struct {
+	log *core.Log
+}
+
+func (l *PreconfLog) Address(ctx context.Context) common.Address {
+	return l.log.Address
+}
+
+func (l *PreconfLog) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *PreconfLog) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// PreconfResult represents the outcome of the preconfirmation of a transaction.
+type PreconfResult struct {
+	r     *Resolver
+	event core.NewPreconfTxEvent
+}
+
+func (p *PreconfResult) Transaction(ctx context.Context) *Transaction {
+	return &Transaction{r: p.r, hash: p.event.TxHash}
+}
+
+func (p *PreconfResult) Status(ctx context.Context) string {
+	return string(p.event.Status)
+}
+
+func (p *PreconfResult) Reason(ctx context.Context) *string {
+	if p.event.Reason == "" {
+		return nil
+	}
+	return &p.event.Reason
+}
+
+func (p *PreconfResult) PredictedBlockNumber(ctx context.Context) hexutil.Uint64 {
+	return p.event.PredictedL2BlockNumber
+}
+
+func (p *PreconfResult) Logs(ctx context.Context) []*PreconfLog {
+	logs := make([]*PreconfLog, 0, len(p.event.Receipt.Logs))
+	for _, log := range p.event.Receipt.Logs {
+		logs = append(logs, &PreconfLog{log: log})
+	}
+	return logs
+}
+
+// PreconfResults streams the preconfirmation results of the transactions
+// entering the pool until the subscription is cancelled.
+func (r *Resolver) PreconfResults(ctx context.Context, args struct{ Hashes *[]common.Hash }) (<-chan *PreconfResult, error) {
+	var wanted map[common.Hash]bool
+	if args.Hashes != nil {
+		wanted = make(map[common.Hash]bool, len(*args.Hashes))
+		for _, hash := range *args.Hashes {
+			wanted[hash] = true
+		}
+	}
+	var (
+		events  = make(chan core.NewPreconfTxEvent, preconfChanSize)
+		sub     = r.backend.SubscribeNewPreconfTxEvent(events)
+		results = make(chan *PreconfResult)
+	)
+	go func() {
+		defer close(results)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				if wanted != nil && !wanted[ev.TxHash] {
+					continue
+				}
+				select {
+				case results <- &PreconfResult{r: r, event: ev}:
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
 // FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
 type FilterCriteria struct {
 	FromBlock *Long             // beginning of the queried range, nil means genesis block
@@ -17,12 +17,14 @@
 package graphql
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -38,8 +40,13 @@ import (
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/graph-gophers/graphql-go"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -240,6 +247,11 @@ func TestGraphQLBlockSerializationEIP2718(t *testing.T) {
 			want: `{"data":{"block":{"number":"0x1","transactions":[{"from":{"address":"0x71562b71999873db5b286df957af199ec94617f7"},"to":{"address":"0x0000000000000000000000000000000000000dad"},"value":"0x64","hash":"0xd864c9d7d37fade6b70164740540c06dd58bb9c3f6b46101908d6339db6a6a7b","type":"0x0","accessList":[],"index":"0x0"},{"from":{"address":"0x71562b71999873db5b286df957af199ec94617f7"},"to":{"address":"0x0000000000000000000000000000000000000dad"},"value":"0x32","hash":"0x19b35f8187b4e15fb59a9af469dca5dfa3cd363c11d372058c12f6482477b474","type":"0x1","accessList":[{"address":"0x0000000000000000000000000000000000000dad","storageKeys":["0x0000000000000000000000000000000000000000000000000000000000000000"]}],"index":"0x1"}]}}}`,
 			code: 200,
 		},
+		{ // Rollup fields are null for regular transactions
+			body: `{"query": "{block {transactions { sourceHash mint ethValue ethTxValue isSystemTx l1Fee l1GasUsed tokenRatio metaTx { sponsorPercent }}}}"}`,
+			want: `{"data":{"block":{"transactions":[{"sourceHash":null,"mint":null,"ethValue":null,"ethTxValue":null,"isSystemTx":null,"l1Fee":null,"l1GasUsed":null,"tokenRatio":null,"metaTx":null},{"sourceHash":null,"mint":null,"ethValue":null,"ethTxValue":null,"isSystemTx":null,"l1Fee":null,"l1GasUsed":null,"tokenRatio":null,"metaTx":null}]}}}`,
+			code: 200,
+		},
 	} {
 		resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", strings.NewReader(tt.body))
 		if err != nil {
@@ -430,6 +442,249 @@ func TestWithdrawals(t *testing.T) {
 	}
 }
 
+// preconfBackend is a backend only serving the preconfirmation results.
+type preconfBackend struct {
+	ethapi.Backend
+	feed event.Feed
+}
+
+func (b *preconfBackend) SubscribeNewPreconfTxEvent(ch chan<- core.NewPreconfTxEvent) event.Subscription {
+	return b.feed.Subscribe(ch)
+}
+
+// mantleTxBackend is a backend only serving the transactions of a single block.
+type mantleTxBackend struct {
+	ethapi.Backend
+	block    *types.Block
+	receipts types.Receipts
+}
+
+func (b *mantleTxBackend) ChainConfig() *params.ChainConfig {
+	return params.OptimismTestConfig
+}
+
+func (b *mantleTxBackend) GetTransaction(hash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64) {
+	for i, tx := range b.block.Transactions() {
+		if tx.Hash() == hash {
+			return true, tx, b.block.Hash(), b.block.NumberU64(), uint64(i)
+		}
+	}
+	return false, nil, common.Hash{}, 0, 0
+}
+
+func (b *mantleTxBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+func (b *mantleTxBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	return b.block, nil
+}
+
+func (b *mantleTxBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
+	return b.receipts, nil
+}
+
+// Tests the resolvers of the Mantle specific transaction fields.
+func TestGraphQLMantleTransactionFields(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		signer  = types.LatestSignerForChainID(params.OptimismTestConfig.ChainID)
+		to      = common.Address{0x01}
+		sponsor = common.Address{0x02}
+	)
+	deposit := types.NewTx(&types.DepositTx{
+		SourceHash:          common.Hash{0xaa},
+		From:                common.Address{0x03},
+		To:                  &to,
+		Mint:                big.NewInt(100),
+		Value:               big.NewInt(0),
+		Gas:                 50000,
+		IsSystemTransaction: true,
+		EthValue:            big.NewInt(200),
+		EthTxValue:          big.NewInt(300),
+	})
+	transfer, _ := types.SignNewTx(key, signer, &types.LegacyTx{To: &to, Gas: params.TxGas, GasPrice: big.NewInt(1)})
+
+	metaParams, _ := rlp.EncodeToBytes(&types.MetaTxParams{
+		ExpireHeight:   100,
+		SponsorPercent: 50,
+		GasFeeSponsor:  sponsor,
+		V:              new(big.Int),
+		R:              new(big.Int),
+		S:              new(big.Int),
+	})
+	metaTx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		Nonce:     1,
+		To:        &to,
+		Gas:       100000,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		Data:      append(common.CopyBytes(types.MetaTxPrefix), metaParams...),
+	})
+	backend := &mantleTxBackend{
+		block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{
+			Transactions: types.Transactions{deposit, transfer, metaTx},
+		}),
+		receipts: types.Receipts{
+			{},
+			{L1Fee: big.NewInt(1000), L1GasUsed: big.NewInt(2000), TokenRatio: big.NewInt(3000)},
+			{L1Fee: big.NewInt(4000), L1GasUsed: big.NewInt(5000), TokenRatio: big.NewInt(6000)},
+		},
+	}
+	gql := graphql.MustParseSchema(schema, &Resolver{backend: backend})
+
+	for i, tt := range []struct {
+		tx   common.Hash
+		want string
+	}{
+		{
+			tx:   deposit.Hash(),
+			want: `{"transaction":{"sourceHash":"0xaa00000000000000000000000000000000000000000000000000000000000000","mint":"0x64","ethValue":"0xc8","ethTxValue":"0x12c","isSystemTx":true,"l1Fee":null,"l1GasUsed":null,"tokenRatio":null,"metaTx":null}}`,
+		},
+		{
+			tx:   transfer.Hash(),
+			want: `{"transaction":{"sourceHash":null,"mint":null,"ethValue":null,"ethTxValue":null,"isSystemTx":null,"l1Fee":"0x3e8","l1GasUsed":"0x7d0","tokenRatio":"0xbb8","metaTx":null}}`,
+		},
+		{
+			tx:   metaTx.Hash(),
+			want: `{"transaction":{"sourceHash":null,"mint":null,"ethValue":null,"ethTxValue":null,"isSystemTx":null,"l1Fee":"0xfa0","l1GasUsed":"0x1388","tokenRatio":"0x1770","metaTx":{"sponsor":{"address":"0x0200000000000000000000000000000000000000"},"sponsorPercent":"0x32","expireHeight":"0x64"}}}`,
+		},
+	} {
+		query := fmt.Sprintf(`{ transaction(hash: "%s") { sourceHash mint ethValue ethTxValue isSystemTx l1Fee l1GasUsed tokenRatio metaTx { sponsor { address } sponsorPercent expireHeight } } }`, tt.tx.Hex())
+		res := gql.Exec(context.Background(), query, "", nil)
+		if len(res.Errors) != 0 {
+			t.Fatalf("test %d: query failed: %v", i, res.Errors)
+		}
+		if have := string(res.Data); have != tt.want {
+			t.Errorf("test %d: response mismatch\nhave: %s\nwant: %s", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that subscriptions are streamed as server-sent events.
+func TestGraphQLPreconfSubscription(t *testing.T) {
+	stack := createNode(t)
+	defer stack.Close()
+
+	backend := new(preconfBackend)
+	handler, err := newHandler(stack, backend, nil, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	wanted := common.Hash{0x02}
+	body := fmt.Sprintf(`{"query": "subscription { preconfResults(hashes: [\"%s\"]) { transaction { hash } status reason predictedBlockNumber logs { address topics data }}}"}`, wanted.Hex())
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("could not post: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("wrong content type %q", ct)
+	}
+	// Wait for the subscription before sending the results
+	for i := 0; backend.feed.Send(core.NewPreconfTxEvent{TxHash: common.Hash{0x01}}) == 0; i++ {
+		if i == 100 {
+			t.Fatal("subscription not created")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	backend.feed.Send(core.NewPreconfTxEvent{
+		TxHash:                 wanted,
+		Status:                 core.PreconfStatusSuccess,
+		PredictedL2BlockNumber: 7,
+		Receipt: core.PreconfTxReceipt{Logs: []*core.Log{{
+			Address: common.Address{0xaa},
+			Topics:  []common.Hash{{0xbb}},
+			Data:    []byte{0xcc},
+		}}},
+	})
+	reader := bufio.NewReader(resp.Body)
+	for _, want := range []string{
+		"event: next",
+		fmt.Sprintf(`data: {"data":{"preconfResults":{"transaction":{"hash":"%s"},"status":"success","reason":null,"predictedBlockNumber":"0x7","logs":[{"address":"0xaa00000000000000000000000000000000000000","topics":["0xbb00000000000000000000000000000000000000000000000000000000000000"],"data":"0xcc"}]}}}`, wanted.Hex()),
+	} {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("could not read event: %v", err)
+		}
+		if have := strings.TrimSuffix(line, "\n"); have != want {
+			t.Fatalf("wrong event line\nhave: %s\nwant: %s", have, want)
+		}
+	}
+}
+
+// Tests that queries requested as event streams are answered as regular requests.
+func TestGraphQLEventStreamQuery(t *testing.T) {
+	stack := createNode(t)
+	defer stack.Close()
+
+	handler, err := newHandler(stack, new(preconfBackend), nil, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query": "{ __typename }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("could not post: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("wrong content type %q", ct)
+	}
+}
+
+// Tests that only the subscriptions selected from documents are streamed.
+func TestGraphQLEventStreamOperation(t *testing.T) {
+	stack := createNode(t)
+	defer stack.Close()
+
+	handler, err := newHandler(stack, new(preconfBackend), nil, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	document := `# subscription\nquery A { block(hash: \"{\") { number } } subscription B { preconfResults { status } }`
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{`{"query": "subscription { preconfResults { status } }"}`, "text/event-stream"},
+		{`{"query": "fragment F on PreconfResult { status } subscription { preconfResults { ...F } }"}`, "text/event-stream"},
+		{fmt.Sprintf(`{"query": "%s", "operationName": "B"}`, document), "text/event-stream"},
+		{fmt.Sprintf(`{"query": "%s", "operationName": "A"}`, document), "application/json"},
+		{fmt.Sprintf(`{"query": "%s"}`, document), "application/json"},
+		{`{"query": "subscription { preconfResults { status } }", "operationName": "B"}`, "application/json"},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			cancel()
+			t.Fatalf("test %d: could not post: %v", i, err)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != tt.want {
+			t.Errorf("test %d: wrong content type: have %q, want %q", i, ct, tt.want)
+		}
+		cancel()
+		resp.Body.Close()
+	}
+}
+
 func createNode(t *testing.T) *node.Node {
 	stack, err := node.New(&node.Config{
 		HTTPHost:     "127.0.0.1",
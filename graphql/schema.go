@@ -36,6 +36,7 @@ const schema string = `
     schema {
         query: Query
         mutation: Mutation
+        subscription: Subscription
     }
 
     # Account is an Ethereum account at a particular block.
@@ -54,6 +55,19 @@ const schema string = `
         # Storage provides access to the storage of a contract account, indexed
         # by its 32 byte slot identifier.
         storage(slot: Bytes32!): Bytes32!
+        # Transactions returns a page of the transactions this account took part
+        # in as the sender, the recipient, the created contract or a participant
+        # of an internal call, between the given blocks. Requires the address
+        # index to be enabled.
+        transactions(fromBlock: Long, toBlock: Long, cursor: Bytes): AccountTransactions!
+    }
+
+    # AccountTransactions is a page of the transactions an account took part in.
+    type AccountTransactions {
+        # Transactions is the list of transactions in the page, in chain order.
+        transactions: [Transaction!]!
+        # Cursor resumes the query after this page, null on the last page.
+        cursor: Bytes
     }
 
     # Log is an Ethereum event log.
@@ -170,6 +184,44 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+
+        # SourceHash uniquely identifies the origin of a deposit transaction.
+        # This is null for other transactions.
+        sourceHash: Bytes32
+        # Mint is the amount of MNT minted to the sender of a deposit
+        # transaction, in wei. This is null for other transactions.
+        mint: BigInt
+        # EthValue is the amount of BVM_ETH minted to the sender of a deposit
+        # transaction. This is null for other transactions.
+        ethValue: BigInt
+        # EthTxValue is the amount of BVM_ETH transferred to the recipient of a
+        # deposit transaction. This is null for other transactions.
+        ethTxValue: BigInt
+        # IsSystemTx is true for system deposit transactions, which do not
+        # consume gas. This is null for other transactions.
+        isSystemTx: Boolean
+        # L1Fee is the fee charged for posting the transaction data to L1, in
+        # wei. This is null for deposits and transactions not yet mined.
+        l1Fee: BigInt
+        # L1GasUsed is the amount of L1 gas accounted for the transaction data.
+        # This is null for deposits and transactions not yet mined.
+        l1GasUsed: BigInt
+        # TokenRatio is the ratio of ETH to MNT price applied to the L1 fee.
+        # This is null for deposits and transactions not yet mined.
+        tokenRatio: BigInt
+        # MetaTx describes the sponsorship of the gas fee of a meta transaction.
+        # This is null for other transactions.
+        metaTx: MetaTx
+    }
+
+    # MetaTx is the gas fee sponsorship of a meta transaction.
+    type MetaTx {
+        # Sponsor is the account paying the sponsored part of the gas fee.
+        sponsor(block: Long): Account!
+        # SponsorPercent is the percentage of the gas fee paid by the sponsor.
+        sponsorPercent: Long!
+        # ExpireHeight is the last block the sponsorship is valid in.
+        expireHeight: Long!
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -388,4 +440,36 @@ const schema string = `
         # SendRawTransaction sends an RLP-encoded transaction to the network.
         sendRawTransaction(data: Bytes!): Bytes32!
     }
+
+    # PreconfLog is an event log predicted by a preconfirmation.
+    type PreconfLog {
+        # Address is the address of the contract which generated this log.
+        address: Address!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+    }
+
+    # PreconfResult is the outcome of the preconfirmation of a transaction.
+    type PreconfResult {
+        # Transaction is the preconfirmed transaction.
+        transaction: Transaction!
+        # Status is one of success, failed, timeout or waiting.
+        status: String!
+        # Reason explains a failed preconfirmation.
+        reason: String
+        # PredictedBlockNumber is the block the transaction is expected in.
+        predictedBlockNumber: Long!
+        # Logs is the list of logs the transaction is expected to emit.
+        logs: [PreconfLog!]!
+    }
+
+    # Subscriptions are served as server-sent events to requests accepting
+    # text/event-stream.
+    type Subscription {
+        # PreconfResults streams the preconfirmation results of the transactions
+        # entering the pool, optionally restricted to the given hashes.
+        preconfResults(hashes: [Bytes32!]): PreconfResult!
+    }
 `
@@ -19,8 +19,10 @@ package graphql
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,8 +34,12 @@ import (
 	gqlErrors "github.com/graph-gophers/graphql-go/errors"
 )
 
+// maxEventStreams is the maximum number of subscriptions streamed at once.
+const maxEventStreams = 128
+
 type handler struct {
-	Schema *graphql.Schema
+	Schema  *graphql.Schema
+	streams chan struct{} // Semaphore limiting the streamed subscriptions
 }
 
 func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -46,8 +52,8 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
 	var (
+		stream    = strings.Contains(r.Header.Get("Accept"), "text/event-stream")
 		ctx       = r.Context()
 		responded sync.Once
 		timer     *time.Timer
@@ -92,6 +98,12 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		timer.Stop()
 	}
 	responded.Do(func() {
+		// Only subscriptions are streamed, which the executor rejects without
+		// running them. Other operations are subject to the request timeout.
+		if stream && isSubscription(response) {
+			h.serveEventStream(w, r, params.Query, params.OperationName, params.Variables)
+			return
+		}
 		responseJSON, err := json.Marshal(response)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -105,6 +117,52 @@ func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// serveEventStream executes a subscription, streaming every response as a
+// server-sent event until the subscription ends or the client disconnects.
+func (h handler) serveEventStream(w http.ResponseWriter, r *http.Request, query string, operationName string, variables map[string]interface{}) {
+	select {
+	case h.streams <- struct{}{}:
+		defer func() { <-h.streams }()
+	default:
+		http.Error(w, "too many subscriptions", http.StatusServiceUnavailable)
+		return
+	}
+	responses, err := h.Schema.Subscribe(r.Context(), query, operationName, variables)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Subscriptions outlive the write timeout of the server.
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	for response := range responses {
+		responseJSON, err := json.Marshal(response)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: next\ndata: %s\n\n", responseJSON); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+	fmt.Fprint(w, "event: complete\ndata:\n\n")
+	rc.Flush()
+}
+
+// isSubscription returns whether the executor rejected the operation for being
+// a subscription, which are only served by Subscribe.
+func isSubscription(response *graphql.Response) bool {
+	return len(response.Errors) == 1 && response.Errors[0].Message == "graphql-ws protocol header is missing"
+}
+
 // New constructs a new GraphQL service instance.
 func New(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) error {
 	_, err := newHandler(stack, backend, filterSystem, cors, vhosts)
@@ -120,7 +178,7 @@ func newHandler(stack *node.Node, backend ethapi.Backend, filterSystem *filters.
 	if err != nil {
 		return nil, err
 	}
-	h := handler{Schema: s}
+	h := handler{Schema: s, streams: make(chan struct{}, maxEventStreams)}
 	handler := node.NewHTTPHandlerStack(h, cors, vhosts, nil)
 
 	stack.RegisterHandler("GraphQL UI", "/graphql/ui", GraphiQL{})
@@ -18,23 +18,27 @@ package ethapi
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	gomath "math"
 	"math/big"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -680,17 +684,37 @@ func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rp
 	if err != nil {
 		return nil, err
 	}
+	result, err := MarshalBlockReceipts(block, receipts, api.b.ChainConfig())
+	if err != nil {
+		return nil, err
+	}
+	// Add the revert reasons of failed transactions if enabled
+	failed := slices.ContainsFunc(receipts, func(r *types.Receipt) bool { return r.Status == types.ReceiptStatusFailed })
+	if failed {
+		if reverts := blockRevertData(ctx, api.b, block.Hash()); len(reverts) == len(result) {
+			registry := errorRegistry(api.b)
+			for i := range result {
+				addRevertReason(result[i], reverts[i], registry)
+			}
+		}
+	}
+	return result, nil
+}
+
+// MarshalBlockReceipts converts the receipts of a block into their RPC
+// representation.
+func MarshalBlockReceipts(block *types.Block, receipts types.Receipts, config *params.ChainConfig) ([]map[string]interface{}, error) {
 	txs := block.Transactions()
 	if len(txs) != len(receipts) {
 		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
 	}
 
 	// Derive the sender.
-	signer := types.MakeSigner(api.b.ChainConfig(), block.Number(), block.Time())
+	signer := types.MakeSigner(config, block.Number(), block.Time())
 
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
-		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i, api.b.ChainConfig())
+		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i, config)
 	}
 
 	return result, nil
@@ -903,7 +927,7 @@ func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockN
 		return nil, err
 	}
 	if errors.Is(result.Err, vm.ErrExecutionReverted) {
-		return nil, newRevertError(result.Revert())
+		return nil, newRevertError(result.Revert(), errorRegistry(api.b))
 	}
 	return result.Return(), result.Err
 }
@@ -947,6 +971,41 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 	return sim.execute(ctx, opts.BlockStateCalls)
 }
 
+// SimulateWithTracer executes series of calls packed into blocks on top of the
+// given state, the same way as eth_simulateV1 does. The tracing hooks created
+// by newTracer are attached to each call in turn, which allows the caller to
+// collect a trace per simulated call. The state is modified in place. The
+// headers of the simulated blocks are returned, including the empty blocks
+// inserted to fill the gaps between the requested block numbers.
+func SimulateWithTracer(ctx context.Context, b SimulateBackend, state *state.StateDB, base *types.Header, blocks []SimBlock, newTracer SimCallTracer) ([]*types.Header, error) {
+	if len(blocks) == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if len(blocks) > maxSimulateBlocks {
+		return nil, &clientLimitExceededError{message: "too many blocks"}
+	}
+	gasCap := b.RPCGasCap()
+	if gasCap == 0 {
+		gasCap = gomath.MaxUint64
+	}
+	sim := &simulator{
+		b:           b,
+		state:       state,
+		base:        base,
+		chainConfig: b.ChainConfig(),
+		gp:          new(core.GasPool).AddGas(gasCap),
+		callTracer:  newTracer,
+	}
+	results, err := sim.execute(ctx, blocks)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]*types.Header, len(results))
+	for i, result := range results {
+		headers[i] = result.Block.Header()
+	}
+	return headers, nil
+}
+
 // DoEstimateGas returns the lowest possible gas limit that allows the transaction to run
 // successfully at block `blockNrOrHash`. It returns error if the transaction would revert, or if
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
@@ -1006,7 +1065,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	estimate, revert, err := gasestimator.Estimate(ctx, call, opts, gasCap)
 	if err != nil {
 		if errors.Is(err, vm.ErrExecutionReverted) {
-			return 0, newRevertError(revert)
+			return 0, newRevertError(revert, errorRegistry(b))
 		}
 		return 0, err
 	}
@@ -1551,6 +1610,140 @@ func (api *TransactionAPI) GetRawTransactionByBlockHashAndIndex(ctx context.Cont
 	return nil
 }
 
+// maxAddressTransactions is the number of transactions returned per page by
+// eth_getTransactionsByAddress.
+const maxAddressTransactions = 100
+
+// TransactionsByAddress retrieves a page of the transactions the address took
+// part in between the given blocks, both inclusive and defaulting to the range
+// covered by the address index. The page resumes at the given cursor if any,
+// the cursor of the next page is returned if there are more transactions. Pages
+// may come short of the limit when reorged entries are skipped.
+func TransactionsByAddress(ctx context.Context, b interface{}, address common.Address, from, to *uint64, cursor []byte) ([]rawdb.AddressIndexEntry, []byte, error) {
+	ib, ok := b.(addressIndexBackend)
+	if !ok {
+		return nil, nil, errors.New("address index is not supported")
+	}
+	tail, head, ok, err := ib.AddressIndexRange(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, errors.New("address index is not available yet")
+	}
+	var (
+		number = tail
+		end    = head
+		index  uint32
+	)
+	if from != nil {
+		if *from < tail {
+			return nil, nil, fmt.Errorf("transactions before block #%d are not indexed", tail)
+		}
+		number = *from
+	}
+	if to != nil {
+		end = min(*to, head)
+	}
+	if cursor != nil {
+		if len(cursor) != 12 {
+			return nil, nil, errors.New("invalid cursor")
+		}
+		next := binary.BigEndian.Uint64(cursor)
+		if next < number {
+			return nil, nil, errors.New("cursor out of range")
+		}
+		number, index = next, binary.BigEndian.Uint32(cursor[8:])
+	}
+	if number > end {
+		return nil, nil, nil
+	}
+	entries, last, err := ib.AddressTransactions(ctx, address, number, index, end, maxAddressTransactions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if last == nil {
+		return entries, nil, nil
+	}
+	// Resume after the last scanned entry rather than the last returned one, so
+	// skipped non-canonical entries don't end the pagination early.
+	next := binary.BigEndian.AppendUint64(nil, last.BlockNumber)
+	next = binary.BigEndian.AppendUint32(next, last.TxIndex)
+	return entries, next, nil
+}
+
+// AddressTransactionsResult is a page of the transactions an address took part in.
+type AddressTransactionsResult struct {
+	Transactions []*RPCTransaction `json:"transactions"`
+	Cursor       *hexutil.Bytes    `json:"cursor"`
+}
+
+// GetTransactionsByAddress returns the transactions the given address took part
+// in between the given blocks, as the sender, the recipient, the created contract
+// or a participant of an internal call. The results are paginated, the returned
+// cursor resumes the query where the page ended, it is nil on the last page.
+func (api *TransactionAPI) GetTransactionsByAddress(ctx context.Context, address common.Address, fromBlock, toBlock *rpc.BlockNumber, cursor *hexutil.Bytes) (*AddressTransactionsResult, error) {
+	var from, to *uint64
+	if fromBlock != nil && *fromBlock != rpc.EarliestBlockNumber {
+		if *fromBlock < 0 {
+			return nil, errors.New("fromBlock must be a block number or earliest")
+		}
+		n := uint64(*fromBlock)
+		from = &n
+	}
+	// The latest and pending blocks are served up to the index head
+	if toBlock != nil {
+		switch *toBlock {
+		case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		case rpc.EarliestBlockNumber:
+			to = new(uint64)
+		case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+			header, err := api.b.HeaderByNumber(ctx, *toBlock)
+			if err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, fmt.Errorf("%s block not found", *toBlock)
+			}
+			n := header.Number.Uint64()
+			to = &n
+		default:
+			if *toBlock < 0 {
+				return nil, fmt.Errorf("invalid toBlock %d", *toBlock)
+			}
+			n := uint64(*toBlock)
+			to = &n
+		}
+	}
+	var next []byte
+	if cursor != nil {
+		next = *cursor
+	}
+	entries, next, err := TransactionsByAddress(ctx, api.b, address, from, to, next)
+	if err != nil {
+		return nil, err
+	}
+	result := &AddressTransactionsResult{Transactions: make([]*RPCTransaction, 0, len(entries))}
+	if next != nil {
+		result.Cursor = (*hexutil.Bytes)(&next)
+	}
+	var block *types.Block
+	for _, entry := range entries {
+		if block == nil || block.Hash() != entry.BlockHash {
+			if block, err = api.b.BlockByHash(ctx, entry.BlockHash); err != nil {
+				return nil, err
+			}
+			if block == nil {
+				return nil, fmt.Errorf("block %#x not found", entry.BlockHash)
+			}
+		}
+		if tx := newRPCTransactionFromBlockIndex(ctx, block, uint64(entry.TxIndex), api.b.ChainConfig(), api.b); tx != nil {
+			result.Transactions = append(result.Transactions, tx)
+		}
+	}
+	return result, nil
+}
+
 // GetTransactionCount returns the number of transactions the given address has sent for the given block number
 func (api *TransactionAPI) GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
 	// Ask transaction pool for the nonce which includes pending transactions
@@ -1657,7 +1850,42 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 
 	// Derive the sender.
 	signer := types.MakeSigner(api.b.ChainConfig(), header.Number, header.Time)
-	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index), api.b.ChainConfig()), nil
+	fields := marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index), api.b.ChainConfig())
+
+	// Add the revert reason of failed transactions if enabled
+	if receipt.Status == types.ReceiptStatusFailed {
+		if reverts := blockRevertData(ctx, api.b, blockHash); index < uint64(len(reverts)) {
+			addRevertReason(fields, reverts[index], errorRegistry(api.b))
+		}
+	}
+	return fields, nil
+}
+
+// blockRevertData returns the revert data of the transactions of a block, or nil
+// if the backend doesn't add revert reasons to receipts.
+func blockRevertData(ctx context.Context, b Backend, blockHash common.Hash) [][]byte {
+	rb, ok := b.(revertReasonBackend)
+	if !ok || !rb.ReceiptRevertReasons() {
+		return nil
+	}
+	reverts, err := rb.RevertData(ctx, blockHash)
+	if err != nil {
+		log.Debug("Failed to retrieve revert data", "block", blockHash, "err", err)
+		return nil
+	}
+	return reverts
+}
+
+// addRevertReason adds the data a failed transaction reverted with to its
+// marshalled receipt, along with the decoded revert reason.
+func addRevertReason(fields map[string]interface{}, revert []byte, registry *abi.ErrorRegistry) {
+	if len(revert) == 0 {
+		return
+	}
+	fields["revertData"] = hexutil.Bytes(revert)
+	if reason, err := registry.UnpackRevert(revert); err == nil {
+		fields["revertReason"] = reason
+	}
 }
 
 // marshalReceipt marshals a transaction receipt into a JSON object.
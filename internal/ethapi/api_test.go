@@ -962,7 +962,7 @@ func TestEstimateGas(t *testing.T) {
 				},
 			},
 			blockOverrides: override.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(11))},
-			expectErr:      newRevertError(packRevert("block 11")),
+			expectErr:      newRevertError(packRevert("block 11"), nil),
 		},
 		// Should be able to send to an EIP-7702 delegated account.
 		{
@@ -1373,6 +1373,74 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestCallMany(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		counter  = common.HexToAddress("0xc0ffee")
+		reverter = common.HexToAddress("0xbad")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		overrides = override.StateOverride{
+			// Increments slot 0 and returns the new value
+			counter: override.OverrideAccount{Code: hex2Bytes("6000546001018060005560005260206000f3")},
+			// Reverts without data
+			reverter: override.OverrideAccount{Code: hex2Bytes("60006000fd")},
+		}
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+	calls := []TransactionArgs{
+		{From: &accounts[0].addr, To: &counter},
+		{From: &accounts[0].addr, To: &counter},
+		{From: &accounts[0].addr, To: &reverter},
+		{From: &accounts[1].addr, To: &accounts[0].addr, Value: (*hexutil.Big)(big.NewInt(params.Ether))},
+		{From: &accounts[0].addr, To: &counter},
+	}
+	want := func(n uint64) string {
+		return hexutil.Encode(common.BigToHash(new(big.Int).SetUint64(n)).Bytes())
+	}
+	for _, carry := range []bool{false, true} {
+		results, err := api.CallMany(context.Background(), calls, &latest, &overrides, &callManyOpts{CarryState: carry})
+		if err != nil {
+			t.Fatalf("carryState %v: unexpected error: %v", carry, err)
+		}
+		if len(results) != len(calls) {
+			t.Fatalf("carryState %v: wrong result count %d", carry, len(results))
+		}
+		counts := []uint64{1, 1, 1}
+		if carry {
+			counts = []uint64{1, 2, 3}
+		}
+		for i, call := range []int{0, 1, 4} {
+			if res := results[call]; res.Error != nil || res.ReturnValue.String() != want(counts[i]) || res.GasUsed == 0 {
+				t.Errorf("carryState %v: wrong result of call %d: %+v", carry, call, res)
+			}
+		}
+		if res := results[2]; res.Error == nil || res.Error.Code != errCodeReverted {
+			t.Errorf("carryState %v: wrong result of reverting call: %+v", carry, res)
+		}
+		if res := results[3]; res.Error == nil || res.Error.Code != errCodeInsufficientFunds {
+			t.Errorf("carryState %v: wrong result of unfunded call: %+v", carry, res)
+		}
+	}
+	// The number of calls is capped
+	if _, err := api.CallMany(context.Background(), nil, &latest, nil, nil); err == nil {
+		t.Fatal("expected error for empty calls")
+	}
+	tooMany := make([]TransactionArgs, maxCallManyCalls+1)
+	if _, err := api.CallMany(context.Background(), tooMany, &latest, nil, nil); err == nil {
+		t.Fatal("expected error for too many calls")
+	}
+}
+
 func TestSimulateV1(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -1481,7 +1549,7 @@ func TestSimulateV1(t *testing.T) {
 	}
 	var testSuite = []struct {
 		name             string
-		blocks           []simBlock
+		blocks           []SimBlock
 		tag              rpc.BlockNumberOrHash
 		includeTransfers *bool
 		validation       *bool
@@ -1494,7 +1562,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "simple",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[0].addr: override.OverrideAccount{Balance: newRPCBalance(big.NewInt(1000))},
 				},
@@ -1537,7 +1605,7 @@ func TestSimulateV1(t *testing.T) {
 			// State build-up over blocks.
 			name: "simple-multi-block",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[0].addr: override.OverrideAccount{Balance: newRPCBalance(big.NewInt(2000))},
 				},
@@ -1598,7 +1666,7 @@ func TestSimulateV1(t *testing.T) {
 			// insufficient funds
 			name: "insufficient-funds",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				Calls: []TransactionArgs{{
 					From:  &randomAccounts[0].addr,
 					To:    &randomAccounts[1].addr,
@@ -1611,7 +1679,7 @@ func TestSimulateV1(t *testing.T) {
 			// EVM error
 			name: "evm-error",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: override.OverrideAccount{Code: hex2Bytes("f3")},
 				},
@@ -1638,7 +1706,7 @@ func TestSimulateV1(t *testing.T) {
 			// Block overrides should work, each call is simulated on a different block number
 			name: "block-overrides",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				BlockOverrides: &override.BlockOverrides{
 					Number:       (*hexutil.Big)(big.NewInt(11)),
 					FeeRecipient: &cac,
@@ -1696,7 +1764,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "block-number-order",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				BlockOverrides: &override.BlockOverrides{
 					Number: (*hexutil.Big)(big.NewInt(12)),
 				},
@@ -1728,7 +1796,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "storage-contract",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: override.OverrideAccount{
 						Code: hex2Bytes("608060405234801561001057600080fd5b50600436106100365760003560e01c80632e64cec11461003b5780636057361d14610059575b600080fd5b610043610075565b60405161005091906100d9565b60405180910390f35b610073600480360381019061006e919061009d565b61007e565b005b60008054905090565b8060008190555050565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220404e37f487a89a932dca5e77faaf6ca2de3b991f93d230604b1b8daaef64766264736f6c63430008070033"),
@@ -1770,7 +1838,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "logs",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: override.OverrideAccount{
 						// Yul code:
@@ -1812,7 +1880,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "ecrecover-override",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: override.OverrideAccount{
 						// Yul code that returns ecrecover(0, 0, 0, 0).
@@ -1877,7 +1945,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "precompile-move",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					sha256Address: override.OverrideAccount{
 						// Yul code that returns the calldata.
@@ -1932,7 +2000,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "transfer-logs",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[0].addr: override.OverrideAccount{
 						Balance: newRPCBalance(big.NewInt(100)),
@@ -1995,7 +2063,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "selfdestruct",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				Calls: []TransactionArgs{{
 					From: &accounts[0].addr,
 					To:   &cac,
@@ -2060,7 +2128,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "validation-checks",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				Calls: []TransactionArgs{{
 					From:  &accounts[2].addr,
 					To:    &cac,
@@ -2075,7 +2143,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "validation-checks-from-contract",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: override.OverrideAccount{
 						Balance: newRPCBalance(big.NewInt(2098640803896784)),
@@ -2110,7 +2178,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "validation-checks-success",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				BlockOverrides: &override.BlockOverrides{
 					BaseFeePerGas: (*hexutil.Big)(big.NewInt(1)),
 				},
@@ -2143,7 +2211,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "clear-storage",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: {
 						Code: newBytes(genesis.Alloc[bab].Code),
@@ -2212,7 +2280,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "blockhash-opcode",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				BlockOverrides: &override.BlockOverrides{
 					Number: (*hexutil.Big)(big.NewInt(12)),
 				},
@@ -2327,7 +2395,7 @@ func TestSimulateV1(t *testing.T) {
 		{
 			name: "basefee-non-validation",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: {
 						// Yul code:
@@ -2435,7 +2503,7 @@ func TestSimulateV1(t *testing.T) {
 		}, {
 			name: "basefee-validation-mode",
 			tag:  latest,
-			blocks: []simBlock{{
+			blocks: []SimBlock{{
 				StateOverrides: &override.StateOverride{
 					randomAccounts[2].addr: {
 						// Yul code:
@@ -2587,7 +2655,7 @@ func TestSimulateV1ChainLinkage(t *testing.T) {
 			Input: uint256ToBytes(uint256.NewInt(baseHeader.Number.Uint64() + 2)),
 			Gas:   newUint64(1000000),
 		}
-		blocks = []simBlock{
+		blocks = []SimBlock{
 			{Calls: []TransactionArgs{call1}},
 			{Calls: []TransactionArgs{call2}},
 			{Calls: []TransactionArgs{call3a, call3b}},
@@ -2649,7 +2717,7 @@ func TestSimulateV1TxSender(t *testing.T) {
 		fullTx:         true,
 	}
 
-	results, err := sim.execute(ctx, []simBlock{
+	results, err := sim.execute(ctx, []SimBlock{
 		{Calls: []TransactionArgs{
 			{From: &sender, To: &recipient, Value: (*hexutil.Big)(big.NewInt(1000))},
 			{From: &sender2, To: &recipient, Value: (*hexutil.Big)(big.NewInt(2000))},
@@ -3868,3 +3936,66 @@ func TestCreateAccessListWithStateOverrides(t *testing.T) {
 	}}
 	require.Equal(t, expected, result.Accesslist)
 }
+
+// addressIndexTestBackend serves an address index covering blocks [0, 10] and
+// records the range of the scans.
+type addressIndexTestBackend struct {
+	Backend
+	safe *types.Header
+	to   *uint64
+}
+
+func (b *addressIndexTestBackend) ChainConfig() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (b *addressIndexTestBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number == rpc.SafeBlockNumber {
+		return b.safe, nil
+	}
+	return nil, fmt.Errorf("unexpected header request %v", number)
+}
+
+func (b *addressIndexTestBackend) AddressIndexRange(ctx context.Context) (uint64, uint64, bool, error) {
+	return 0, 10, true, nil
+}
+
+func (b *addressIndexTestBackend) AddressTransactions(ctx context.Context, address common.Address, number uint64, index uint32, to uint64, limit int) ([]rawdb.AddressIndexEntry, *rawdb.AddressIndexEntry, error) {
+	b.to = &to
+	return nil, nil, nil
+}
+
+func TestGetTransactionsByAddressRange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend = &addressIndexTestBackend{safe: &types.Header{Number: big.NewInt(5)}}
+		api     = NewTransactionAPI(backend, nil)
+	)
+	tests := []struct {
+		to   rpc.BlockNumber
+		want uint64
+	}{
+		{rpc.LatestBlockNumber, 10},
+		{rpc.PendingBlockNumber, 10},
+		{rpc.SafeBlockNumber, 5},
+		{rpc.EarliestBlockNumber, 0},
+		{rpc.BlockNumber(7), 7},
+		{rpc.BlockNumber(20), 10},
+	}
+	for _, tt := range tests {
+		backend.to = nil
+		if _, err := api.GetTransactionsByAddress(context.Background(), common.Address{}, nil, &tt.to, nil); err != nil {
+			t.Fatalf("toBlock %v: %v", tt.to, err)
+		}
+		if backend.to == nil || *backend.to != tt.want {
+			t.Errorf("toBlock %v: scan end mismatch, have %v, want %d", tt.to, backend.to, tt.want)
+		}
+	}
+	// Unknown named blocks are reported
+	backend.safe = nil
+	safe := rpc.SafeBlockNumber
+	if _, err := api.GetTransactionsByAddress(context.Background(), common.Address{}, nil, &safe, nil); err == nil {
+		t.Fatal("expected error for missing safe block")
+	}
+}
@@ -24,10 +24,12 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -104,6 +106,56 @@ type Backend interface {
 	NewMatcherBackend() filtermaps.MatcherBackend
 }
 
+// SimulateBackend is the subset of the backend methods needed to simulate a
+// series of blocks on top of a given state.
+type SimulateBackend interface {
+	ChainContextBackend
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	CurrentHeader() *types.Header
+	RPCGasCap() uint64
+	RPCEVMTimeout() time.Duration
+}
+
+// errorRegistryBackend is implemented by backends offering a registry of custom
+// errors to decode revert reasons with.
+type errorRegistryBackend interface {
+	ErrorRegistry() *abi.ErrorRegistry
+}
+
+// errorRegistry returns the custom error registry of the backend, or nil if it
+// has none.
+func errorRegistry(b interface{}) *abi.ErrorRegistry {
+	if b, ok := b.(errorRegistryBackend); ok {
+		return b.ErrorRegistry()
+	}
+	return nil
+}
+
+// revertReasonBackend is implemented by backends able to add the revert reasons
+// of failed transactions to their receipts.
+type revertReasonBackend interface {
+	// ReceiptRevertReasons returns whether revert reasons are added to receipts.
+	ReceiptRevertReasons() bool
+
+	// RevertData returns the data each transaction of a block reverted with,
+	// nil for the successful ones, as recorded when the block was processed.
+	RevertData(ctx context.Context, blockHash common.Hash) ([][]byte, error)
+}
+
+// addressIndexBackend is implemented by backends maintaining an index of the
+// transactions each address took part in.
+type addressIndexBackend interface {
+	// AddressIndexRange returns the range of indexed blocks, false if nothing
+	// has been indexed yet.
+	AddressIndexRange(ctx context.Context) (uint64, uint64, bool, error)
+
+	// AddressTransactions scans at most limit index entries of the transactions
+	// the address took part in, starting at transaction index in block number
+	// and ending with block to. The canonical transactions among them are
+	// returned, along with the entry the next scan resumes at, if any.
+	AddressTransactions(ctx context.Context, address common.Address, number uint64, index uint32, to uint64, limit int) ([]rawdb.AddressIndexEntry, *rawdb.AddressIndexEntry, error)
+}
+
 func GetAPIs(apiBackend Backend) []rpc.API {
 	nonceLock := new(AddrLocker)
 	return []rpc.API{
@@ -0,0 +1,265 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxCallManyCalls is the maximum number of calls that can be executed in a
+// single eth_callMany request.
+const maxCallManyCalls = 1024
+
+// maxCallManyWorkers is the maximum number of workers executing the calls of a
+// single eth_callMany request in parallel.
+const maxCallManyWorkers = 4
+
+// callManyWorkers bounds the extra workers of the eth_callMany requests across
+// the node, as each of them executes the calls on its own copy of the state.
+var callManyWorkers = make(chan struct{}, runtime.NumCPU())
+
+// callManyOpts are the options of eth_callMany.
+type callManyOpts struct {
+	BlockOverrides *override.BlockOverrides `json:"blockOverrides"`
+	CarryState     bool                     `json:"carryState"` // Execute the calls on top of the state changes of the previous ones
+}
+
+// callManyResult is the result of a call executed by eth_callMany.
+type callManyResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+// callManyExecutor executes the calls of an eth_callMany request.
+type callManyExecutor struct {
+	b              Backend
+	header         *types.Header
+	blockOverrides *override.BlockOverrides
+	precompiles    vm.PrecompiledContracts
+	gasCap         uint64
+	timeout        time.Duration
+}
+
+// blockContext creates the block context of the calls executed on the given
+// state. The L1 cost function of the context reads the state, so the contexts
+// can't be shared between states.
+func (exec *callManyExecutor) blockContext(ctx context.Context, state *state.StateDB) (vm.BlockContext, error) {
+	blockCtx := core.NewEVMBlockContext(exec.header, NewChainContext(ctx, exec.b), nil, exec.b.ChainConfig(), state)
+	if exec.blockOverrides != nil {
+		if err := exec.blockOverrides.Apply(&blockCtx); err != nil {
+			return vm.BlockContext{}, err
+		}
+	}
+	return blockCtx, nil
+}
+
+// CallMany executes a list of calls on the state of the given block, opened
+// once for all of them. By default, every call is executed on the state of the
+// block alone, which allows executing them in parallel. With the carryState
+// option, the calls are executed in order, each one on top of the state changes
+// of the previous ones.
+//
+// A failed call doesn't fail the others, the result of each call carries its
+// own error. The whole request shares the eth_call timeout.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to execute and retrieve values.
+func (api *BlockChainAPI) CallMany(ctx context.Context, calls []TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride, opts *callManyOpts) ([]*callManyResult, error) {
+	if len(calls) == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if len(calls) > maxCallManyCalls {
+		return nil, &clientLimitExceededError{message: "too many calls"}
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	if opts == nil {
+		opts = new(callManyOpts)
+	}
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	exec := &callManyExecutor{
+		b:              api.b,
+		header:         header,
+		blockOverrides: opts.BlockOverrides,
+		gasCap:         api.b.RPCGasCap(),
+		timeout:        api.b.RPCEVMTimeout(),
+	}
+	if exec.gasCap == 0 {
+		exec.gasCap = core.DefaultMantleBlockGasLimit
+	}
+	blockCtx, err := exec.blockContext(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	rules := api.b.ChainConfig().Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time)
+	exec.precompiles = vm.ActivePrecompiledContracts(rules)
+	if err := overrides.Apply(state, exec.precompiles); err != nil {
+		return nil, err
+	}
+	// The timeout applies to the whole request rather than to each call.
+	var cancel context.CancelFunc
+	if exec.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, exec.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	if opts.CarryState || len(calls) == 1 {
+		return exec.executeSequential(ctx, state, blockCtx, calls)
+	}
+	return exec.executeParallel(ctx, state, calls)
+}
+
+// executeSequential executes the calls in order on the given state, carrying
+// the state changes of each call over to the next ones.
+func (exec *callManyExecutor) executeSequential(ctx context.Context, state *state.StateDB, blockCtx vm.BlockContext, calls []TransactionArgs) ([]*callManyResult, error) {
+	results := make([]*callManyResult, len(calls))
+	for i := range calls {
+		res, err := exec.call(ctx, state, blockCtx, calls[i])
+		if err != nil {
+			return nil, err
+		}
+		state.Finalise(true)
+		results[i] = res
+	}
+	return results, nil
+}
+
+// executeParallel executes the calls concurrently, each one on the given state
+// alone. Every worker executes its calls on its own copy of the state, reverting
+// the changes of a call before executing the next one. The first worker uses the
+// given state, the others only run while the node has spare workers.
+func (exec *callManyExecutor) executeParallel(ctx context.Context, base *state.StateDB, calls []TransactionArgs) ([]*callManyResult, error) {
+	// Copy the state for the extra workers before any call modifies it.
+	states := []*state.StateDB{base}
+acquire:
+	for len(states) < min(maxCallManyWorkers, len(calls)) {
+		select {
+		case callManyWorkers <- struct{}{}:
+			states = append(states, base.Copy())
+		default:
+			break acquire
+		}
+	}
+	defer func() {
+		for range states[1:] {
+			<-callManyWorkers
+		}
+	}()
+	var (
+		results = make([]*callManyResult, len(calls))
+		next    atomic.Int64
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		failure error
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, state := range states {
+		blockCtx, err := exec.blockContext(ctx, state)
+		if err != nil {
+			cancel()
+			wg.Wait()
+			return nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(calls) || ctx.Err() != nil {
+					return
+				}
+				snapshot := state.Snapshot()
+				res, err := exec.call(ctx, state, blockCtx, calls[i])
+				state.RevertToSnapshot(snapshot)
+				if err != nil {
+					errOnce.Do(func() {
+						failure = err
+						cancel()
+					})
+					return
+				}
+				results[i] = res
+			}
+		}()
+	}
+	wg.Wait()
+	if failure != nil {
+		return nil, failure
+	}
+	// A cancelled request leaves calls unexecuted.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// call executes a single call on the given state. The block context is passed
+// by value, as applyMessage modifies its fees. Failures of the call are reported
+// in its result, the returned error aborts the request.
+func (exec *callManyExecutor) call(ctx context.Context, state *state.StateDB, blockCtx vm.BlockContext, args TransactionArgs) (*callManyResult, error) {
+	// Release the cancellation watcher of the EVM once the call is done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	gp := new(core.GasPool).AddGas(exec.gasCap)
+	result, err := applyMessage(ctx, exec.b, args, state, exec.header, exec.timeout, gp, &blockCtx, &vm.Config{NoBaseFee: true}, exec.precompiles, true, core.EthcallMode)
+	if ctx.Err() != nil {
+		if err == nil {
+			err = ctx.Err()
+		}
+		return nil, err
+	}
+	if err := state.Error(); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		txErr := txValidationError(err)
+		return &callManyResult{Error: &callError{Message: txErr.Message, Code: txErr.Code}}, nil
+	}
+	res := &callManyResult{ReturnValue: result.Return(), GasUsed: hexutil.Uint64(result.UsedGas)}
+	if result.Err != nil {
+		if errors.Is(result.Err, vm.ErrExecutionReverted) {
+			revertErr := newRevertError(result.Revert(), errorRegistry(exec.b))
+			res.Error = &callError{Message: revertErr.Error(), Code: errCodeReverted, Data: revertErr.ErrorData().(string)}
+		} else {
+			res.Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
+		}
+	}
+	return res, nil
+}
@@ -45,10 +45,11 @@ func (e *revertError) ErrorData() interface{} {
 }
 
 // newRevertError creates a revertError instance with the provided revert data.
-func newRevertError(revert []byte) *revertError {
+// Custom errors are decoded if a registry is given.
+func newRevertError(revert []byte, errs *abi.ErrorRegistry) *revertError {
 	err := vm.ErrExecutionReverted
 
-	reason, errUnpack := abi.UnpackRevert(revert)
+	reason, errUnpack := errs.UnpackRevert(revert)
 	if errUnpack == nil {
 		err = fmt.Errorf("%w: %v", vm.ErrExecutionReverted, reason)
 	}
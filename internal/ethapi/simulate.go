@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
@@ -47,11 +48,64 @@ const (
 	timestampIncrement = 12
 )
 
-// simBlock is a batch of calls to be simulated sequentially.
-type simBlock struct {
+// SimBlock is a batch of calls to be simulated sequentially.
+type SimBlock struct {
 	BlockOverrides *override.BlockOverrides
 	StateOverrides *override.StateOverride
 	Calls          []TransactionArgs
+
+	// Deposits holds the deposit fields of the calls, at the index of the
+	// call. Calls without deposit fields are regular transactions.
+	Deposits []*SimDeposit `json:"-"`
+}
+
+// UnmarshalJSON decodes a block of calls, collecting the deposit fields of the
+// calls alongside them.
+func (b *SimBlock) UnmarshalJSON(input []byte) error {
+	type simBlock SimBlock
+	if err := json.Unmarshal(input, (*simBlock)(b)); err != nil {
+		return err
+	}
+	var dec struct {
+		Calls []*SimDeposit `json:"calls"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	b.Deposits = nil
+	for i, deposit := range dec.Calls {
+		if deposit == nil || !deposit.isDeposit() {
+			continue
+		}
+		if b.Deposits == nil {
+			b.Deposits = make([]*SimDeposit, len(dec.Calls))
+		}
+		b.Deposits[i] = deposit
+	}
+	return nil
+}
+
+// deposit returns the deposit fields of the call at the given index, nil for
+// regular calls.
+func (b *SimBlock) deposit(index int) *SimDeposit {
+	if index < len(b.Deposits) {
+		return b.Deposits[index]
+	}
+	return nil
+}
+
+// SimDeposit are the fields turning a simulated call into a deposit transaction
+// relayed from L1. Any of them being set marks the call as a deposit.
+type SimDeposit struct {
+	SourceHash *common.Hash `json:"sourceHash"`
+	Mint       *hexutil.Big `json:"mint"`
+	EthValue   *hexutil.Big `json:"ethValue"`
+	EthTxValue *hexutil.Big `json:"ethTxValue"`
+	IsSystemTx *bool        `json:"isSystemTx"`
+}
+
+func (d *SimDeposit) isDeposit() bool {
+	return d.SourceHash != nil || d.Mint != nil || d.EthValue != nil || d.EthTxValue != nil || d.IsSystemTx != nil
 }
 
 // simCallResult is the result of a simulated call.
@@ -60,6 +114,7 @@ type simCallResult struct {
 	Logs        []*types.Log   `json:"logs"`
 	GasUsed     hexutil.Uint64 `json:"gasUsed"`
 	Status      hexutil.Uint64 `json:"status"`
+	L1Fee       *hexutil.Big   `json:"l1Fee,omitempty"`
 	Error       *callError     `json:"error,omitempty"`
 }
 
@@ -112,28 +167,34 @@ func (r *simBlockResult) MarshalJSON() ([]byte, error) {
 
 // simOpts are the inputs to eth_simulateV1.
 type simOpts struct {
-	BlockStateCalls        []simBlock
+	BlockStateCalls        []SimBlock
 	TraceTransfers         bool
 	Validation             bool
 	ReturnFullTransactions bool
 }
 
+// SimCallTracer creates the tracing hooks attached to a single simulated call.
+// It is invoked before the call is executed with the header of the simulated
+// block, the transaction assembled from the call and its index in the block.
+// The block hash is not known at that point.
+type SimCallTracer func(header *types.Header, tx *types.Transaction, index int) (*tracing.Hooks, error)
+
 // simChainHeadReader implements ChainHeaderReader which is needed as input for FinalizeAndAssemble.
 type simChainHeadReader struct {
 	context.Context
-	Backend
+	SimulateBackend
 }
 
 func (m *simChainHeadReader) Config() *params.ChainConfig {
-	return m.Backend.ChainConfig()
+	return m.SimulateBackend.ChainConfig()
 }
 
 func (m *simChainHeadReader) CurrentHeader() *types.Header {
-	return m.Backend.CurrentHeader()
+	return m.SimulateBackend.CurrentHeader()
 }
 
 func (m *simChainHeadReader) GetHeader(hash common.Hash, number uint64) *types.Header {
-	header, err := m.Backend.HeaderByNumber(m.Context, rpc.BlockNumber(number))
+	header, err := m.SimulateBackend.HeaderByNumber(m.Context, rpc.BlockNumber(number))
 	if err != nil || header == nil {
 		return nil
 	}
@@ -144,7 +205,7 @@ func (m *simChainHeadReader) GetHeader(hash common.Hash, number uint64) *types.H
 }
 
 func (m *simChainHeadReader) GetHeaderByNumber(number uint64) *types.Header {
-	header, err := m.Backend.HeaderByNumber(m.Context, rpc.BlockNumber(number))
+	header, err := m.SimulateBackend.HeaderByNumber(m.Context, rpc.BlockNumber(number))
 	if err != nil {
 		return nil
 	}
@@ -152,7 +213,7 @@ func (m *simChainHeadReader) GetHeaderByNumber(number uint64) *types.Header {
 }
 
 func (m *simChainHeadReader) GetHeaderByHash(hash common.Hash) *types.Header {
-	header, err := m.Backend.HeaderByHash(m.Context, hash)
+	header, err := m.SimulateBackend.HeaderByHash(m.Context, hash)
 	if err != nil {
 		return nil
 	}
@@ -162,7 +223,7 @@ func (m *simChainHeadReader) GetHeaderByHash(hash common.Hash) *types.Header {
 // simulator is a stateful object that simulates a series of blocks.
 // it is not safe for concurrent use.
 type simulator struct {
-	b              Backend
+	b              SimulateBackend
 	state          *state.StateDB
 	base           *types.Header
 	chainConfig    *params.ChainConfig
@@ -170,10 +231,11 @@ type simulator struct {
 	traceTransfers bool
 	validate       bool
 	fullTx         bool
+	callTracer     SimCallTracer // Optional tracer attached to each call
 }
 
 // execute runs the simulation of a series of blocks.
-func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]*simBlockResult, error) {
+func (sim *simulator) execute(ctx context.Context, blocks []SimBlock) ([]*simBlockResult, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -216,7 +278,7 @@ func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]*simBlo
 	return results, nil
 }
 
-func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header, parent *types.Header, headers []*types.Header, timeout time.Duration) (*types.Block, []simCallResult, map[common.Hash]common.Address, error) {
+func (sim *simulator) processBlock(ctx context.Context, block *SimBlock, header, parent *types.Header, headers []*types.Header, timeout time.Duration) (*types.Block, []simCallResult, map[common.Hash]common.Address, error) {
 	// Set header fields that depend only on parent block.
 	// Parent hash is needed for evm.GetHashFn to work.
 	header.ParentHash = parent.Hash()
@@ -285,19 +347,49 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		if err := ctx.Err(); err != nil {
 			return nil, nil, nil, err
 		}
-		if err := sim.sanitizeCall(&call, sim.state, header, blockContext, &gasUsed); err != nil {
-			return nil, nil, nil, err
-		}
 		var (
-			tx     = call.ToTransaction(types.DynamicFeeTxType)
-			txHash = tx.Hash()
+			tx    *types.Transaction
+			msg   *core.Message
+			nonce uint64
 		)
+		if deposit := block.deposit(i); deposit != nil {
+			var err error
+			if tx, msg, err = sim.depositCall(&call, deposit, header, blockContext, gasUsed); err != nil {
+				return nil, nil, nil, err
+			}
+			// Deposit nonces are only recorded from Regolith onwards.
+			if sim.chainConfig.IsOptimismRegolith(header.Time) {
+				nonce = sim.state.GetNonce(msg.From)
+			}
+		} else {
+			if err := sim.sanitizeCall(&call, sim.state, header, blockContext, &gasUsed); err != nil {
+				return nil, nil, nil, err
+			}
+			tx = call.ToTransaction(types.DynamicFeeTxType)
+			// EoA check is always skipped, even in validation mode.
+			msg = call.ToMessage(header.BaseFee, !sim.validate, true, core.EthcallMode, call.GasPrice)
+			nonce = tx.Nonce()
+		}
+		txHash := tx.Hash()
 		txes[i] = tx
 		senders[txHash] = call.from()
 		tracer.reset(txHash, uint(i))
 		sim.state.SetTxContext(txHash, i)
-		// EoA check is always skipped, even in validation mode.
-		msg := call.ToMessage(header.BaseFee, !sim.validate, true, core.EthcallMode, call.GasPrice)
+		l1Fee := sim.l1Fee(tx, header)
+
+		// Swap in the hooks of the call tracer for the duration of the call.
+		var callHooks *tracing.Hooks
+		if sim.callTracer != nil {
+			hooks, err := sim.callTracer(header, tx, i)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			callHooks = hooks
+			evm.Config.Tracer, evm.StateDB = hooks, state.NewHookedState(sim.state, hooks)
+			if hooks.OnTxStart != nil {
+				hooks.OnTxStart(evm.GetVMContext(), tx, msg.From)
+			}
+		}
 		result, err := applyMessageWithEVM(ctx, evm, msg, timeout, sim.gp)
 		if err != nil {
 			txErr := txValidationError(err)
@@ -306,20 +398,30 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		// Update the state with pending changes.
 		var root []byte
 		if sim.chainConfig.IsByzantium(blockContext.BlockNumber) {
-			tracingStateDB.Finalise(true)
+			evm.StateDB.Finalise(true)
 		} else {
 			root = sim.state.IntermediateRoot(sim.chainConfig.IsEIP158(blockContext.BlockNumber)).Bytes()
 		}
 		gasUsed += result.UsedGas
-		receipts[i] = core.MakeReceipt(msg, evm, result, sim.state, blockContext.BlockNumber, common.Hash{}, tx, gasUsed, root, sim.chainConfig, tx.Nonce())
+		receipts[i] = core.MakeReceipt(msg, evm, result, sim.state, blockContext.BlockNumber, common.Hash{}, tx, gasUsed, root, sim.chainConfig, nonce)
 		blobGasUsed += receipts[i].BlobGasUsed
-		logs := tracer.Logs()
-		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		var logs []*types.Log
+		if callHooks != nil {
+			if callHooks.OnTxEnd != nil {
+				callHooks.OnTxEnd(receipts[i], nil)
+			}
+			evm.Config.Tracer, evm.StateDB = vmConfig.Tracer, tracingStateDB
+			// The log tracer was detached during the call, take the logs from the receipt.
+			logs = receipts[i].Logs
+		} else {
+			logs = tracer.Logs()
+		}
+		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas), L1Fee: (*hexutil.Big)(l1Fee)}
 		if result.Failed() {
 			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
 			if errors.Is(result.Err, vm.ErrExecutionReverted) {
 				// If the result contains a revert reason, try to unpack it.
-				revertErr := newRevertError(result.Revert())
+				revertErr := newRevertError(result.Revert(), errorRegistry(sim.b))
 				callRes.Error = &callError{Message: revertErr.Error(), Code: errCodeReverted, Data: revertErr.ErrorData().(string)}
 			} else {
 				callRes.Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
@@ -395,6 +497,69 @@ func (sim *simulator) sanitizeCall(call *TransactionArgs, state vm.StateDB, head
 	return nil
 }
 
+// depositCall assembles the deposit transaction of a call and the message to
+// execute it with, following the rules active at the simulated block.
+func (sim *simulator) depositCall(call *TransactionArgs, deposit *SimDeposit, header *types.Header, blockContext vm.BlockContext, gasUsed uint64) (*types.Transaction, *core.Message, error) {
+	if !sim.chainConfig.IsOptimism() {
+		return nil, nil, errors.New("deposit calls are not supported on this chain")
+	}
+	if call.From == nil {
+		return nil, nil, errors.New("deposit call without sender")
+	}
+	// Let the deposit run wild unless explicitly specified.
+	if call.Gas == nil {
+		remaining := blockContext.GasLimit - gasUsed
+		call.Gas = (*hexutil.Uint64)(&remaining)
+	}
+	if gasUsed+uint64(*call.Gas) > blockContext.GasLimit {
+		return nil, nil, &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, blockContext.GasLimit)}
+	}
+	dep := &types.DepositTx{
+		From:       *call.From,
+		To:         call.To,
+		Mint:       (*big.Int)(deposit.Mint),
+		Value:      new(big.Int),
+		Gas:        uint64(*call.Gas),
+		EthValue:   (*big.Int)(deposit.EthValue),
+		Data:       call.data(),
+		EthTxValue: (*big.Int)(deposit.EthTxValue),
+	}
+	if deposit.SourceHash != nil {
+		dep.SourceHash = *deposit.SourceHash
+	}
+	if deposit.IsSystemTx != nil {
+		dep.IsSystemTransaction = *deposit.IsSystemTx
+	}
+	if call.Value != nil {
+		dep.Value = call.Value.ToInt()
+	}
+	var (
+		tx      = types.NewTx(dep)
+		isMerge = header.Difficulty.Sign() == 0
+		rules   = sim.chainConfig.Rules(header.Number, isMerge, header.Time)
+		signer  = types.MakeSigner(sim.chainConfig, header.Number, header.Time)
+	)
+	msg, err := core.TransactionToMessage(tx, signer, header.BaseFee, &rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, msg, nil
+}
+
+// l1Fee returns the fee for posting the data of a simulated transaction to L1,
+// accounting for the missing signature. Deposits are not charged for their
+// data, nil is returned for them and on chains without L1 fees.
+func (sim *simulator) l1Fee(tx *types.Transaction, header *types.Header) *big.Int {
+	if sim.chainConfig.Optimism == nil || tx.IsDepositTx() {
+		return nil
+	}
+	costData := tx.RollupCostData()
+	costData.Ones += core.RollupSignatureOnes
+
+	l1BaseFee, overhead, scalar, _, tokenRatio := types.DeriveL1GasInfo(sim.state)
+	return types.L1Cost(costData.DataGas(header.Time, sim.chainConfig), l1BaseFee, overhead, scalar, tokenRatio)
+}
+
 func (sim *simulator) activePrecompiles(base *types.Header) vm.PrecompiledContracts {
 	var (
 		isMerge = (base.Difficulty.Sign() == 0)
@@ -407,9 +572,9 @@ func (sim *simulator) activePrecompiles(base *types.Header) vm.PrecompiledContra
 // block numbers and timestamp are strictly increasing, setting default values
 // when necessary. Gaps in block numbers are filled with empty blocks.
 // Note: It modifies the block's override object.
-func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
+func (sim *simulator) sanitizeChain(blocks []SimBlock) ([]SimBlock, error) {
 	var (
-		res           = make([]simBlock, 0, len(blocks))
+		res           = make([]SimBlock, 0, len(blocks))
 		base          = sim.base
 		prevNumber    = base.Number
 		prevTimestamp = base.Time
@@ -439,7 +604,7 @@ func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
 			for i := uint64(0); i < gap.Uint64(); i++ {
 				n := new(big.Int).Add(prevNumber, big.NewInt(int64(i+1)))
 				t := prevTimestamp + timestampIncrement
-				b := simBlock{
+				b := SimBlock{
 					BlockOverrides: &override.BlockOverrides{
 						Number:      (*hexutil.Big)(n),
 						Time:        (*hexutil.Uint64)(&t),
@@ -471,7 +636,7 @@ func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
 // makeHeaders makes header object with preliminary fields based on a simulated block.
 // Some fields have to be filled post-execution.
 // It assumes blocks are in order and numbers have been validated.
-func (sim *simulator) makeHeaders(blocks []simBlock) ([]*types.Header, error) {
+func (sim *simulator) makeHeaders(blocks []SimBlock) ([]*types.Header, error) {
 	var (
 		res    = make([]*types.Header, len(blocks))
 		base   = sim.base
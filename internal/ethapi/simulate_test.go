@@ -17,12 +17,19 @@
 package ethapi
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 func TestSimulateSanitizeBlockOrder(t *testing.T) {
@@ -33,50 +40,50 @@ func TestSimulateSanitizeBlockOrder(t *testing.T) {
 	for i, tc := range []struct {
 		baseNumber    int
 		baseTimestamp uint64
-		blocks        []simBlock
+		blocks        []SimBlock
 		expected      []result
 		err           string
 	}{
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{}, {}, {}},
+			blocks:        []SimBlock{{}, {}, {}},
 			expected:      []result{{number: 11, timestamp: 62}, {number: 12, timestamp: 74}, {number: 13, timestamp: 86}},
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(80)}}, {}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(80)}}, {}},
 			expected:      []result{{number: 11, timestamp: 62}, {number: 12, timestamp: 74}, {number: 13, timestamp: 80}, {number: 14, timestamp: 92}},
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(14)}}, {}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(14)}}, {}},
 			expected:      []result{{number: 11, timestamp: 62}, {number: 12, timestamp: 74}, {number: 13, timestamp: 86}, {number: 14, timestamp: 98}, {number: 15, timestamp: 110}},
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(12)}}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(12)}}},
 			err:           "block numbers must be in order: 12 <= 13",
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(74)}}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(74)}}},
 			err:           "block timestamps must be in order: 74 <= 74",
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11), Time: newUint64(60)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(12), Time: newUint64(55)}}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11), Time: newUint64(60)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(12), Time: newUint64(55)}}},
 			err:           "block timestamps must be in order: 55 <= 60",
 		},
 		{
 			baseNumber:    10,
 			baseTimestamp: 50,
-			blocks:        []simBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11), Time: newUint64(60)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(72)}}},
+			blocks:        []SimBlock{{BlockOverrides: &override.BlockOverrides{Number: newInt(11), Time: newUint64(60)}}, {BlockOverrides: &override.BlockOverrides{Number: newInt(13), Time: newUint64(72)}}},
 			err:           "block timestamps must be in order: 72 <= 72",
 		},
 	} {
@@ -119,3 +126,75 @@ func TestSimulateSanitizeBlockOrder(t *testing.T) {
 func newInt(n int64) *hexutil.Big {
 	return (*hexutil.Big)(big.NewInt(n))
 }
+
+// Tests that deposits are simulated ahead of the calls spending the minted
+// funds, and that regular calls report their L1 fee.
+func TestSimulateDeposits(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config    = *params.TestChainConfig
+		regolith  = uint64(0)
+		depositor = common.HexToAddress("0xdeadbeef")
+		recipient = common.HexToAddress("0xbeef")
+	)
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.BedrockBlock = common.Big0
+	config.RegolithTime = &regolith
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			types.L1BlockAddr: {Balance: common.Big0, Storage: map[common.Hash]common.Hash{
+				types.L1BaseFeeSlot: common.BigToHash(big.NewInt(params.GWei)),
+				types.OverheadSlot:  common.BigToHash(big.NewInt(188)),
+				types.ScalarSlot:    common.BigToHash(big.NewInt(1_000_000)),
+			}},
+			types.GasOracleAddr: {Balance: common.Big0, Storage: map[common.Hash]common.Hash{
+				types.TokenRatioSlot: common.BigToHash(big.NewInt(2)),
+			}},
+		},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 0, genesis, ethash.NewFaker(), nil))
+
+	// The depositor is only funded by the mint of its deposit
+	input := fmt.Sprintf(`{"blockStateCalls": [{"calls": [
+		{"from": "%s", "to": "%s", "mint": "0xde0b6b3a7640000", "sourceHash": "%s"},
+		{"from": "%s", "to": "%s", "value": "0x1", "maxFeePerGas": "0x3b9aca00", "maxPriorityFeePerGas": "0x0"}
+	]}], "validation": true, "returnFullTransactions": true}`, depositor, depositor, common.Hash{0x01}, depositor, recipient)
+	var opts simOpts
+	if err := json.Unmarshal([]byte(input), &opts); err != nil {
+		t.Fatal(err)
+	}
+	if deposit := opts.BlockStateCalls[0].deposit(0); deposit == nil || deposit.Mint.ToInt().Cmp(big.NewInt(params.Ether)) != 0 {
+		t.Fatalf("deposit fields not decoded: %+v", deposit)
+	}
+	if deposit := opts.BlockStateCalls[0].deposit(1); deposit != nil {
+		t.Fatalf("regular call decoded as deposit: %+v", deposit)
+	}
+	results, err := api.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := results[0].Calls
+	for i, call := range calls {
+		if call.Status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+			t.Fatalf("call %d failed: %v", i, call.Error)
+		}
+	}
+	if calls[0].L1Fee != nil {
+		t.Fatalf("deposit charged with L1 fee %v", calls[0].L1Fee)
+	}
+	if calls[1].L1Fee == nil || calls[1].L1Fee.ToInt().Sign() <= 0 {
+		t.Fatalf("missing L1 fee of regular call: %v", calls[1].L1Fee)
+	}
+	txs := results[0].Block.Transactions()
+	if txs[0].Type() != types.DepositTxType || txs[0].SourceHash() != (common.Hash{0x01}) {
+		t.Fatalf("unexpected deposit transaction type %d source %x", txs[0].Type(), txs[0].SourceHash())
+	}
+	// Deposits are rejected on chains without them
+	api = NewBlockChainAPI(newTestBackend(t, 0, &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}, ethash.NewFaker(), nil))
+	if _, err := api.SimulateV1(context.Background(), opts, nil); err == nil {
+		t.Fatal("deposit simulated on a chain without deposits")
+	}
+}
@@ -27,6 +27,7 @@ var Modules = map[string]string{
 	"rpc":    RpcJs,
 	"txpool": TxpoolJs,
 	"dev":    DevJs,
+	"trace":  TraceJs,
 }
 
 const CliqueJs = `
@@ -409,6 +410,12 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCallMany',
+			call: 'debug_traceCallMany',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -420,10 +427,22 @@ web3._extend({
 			call: 'debug_getBadBlocks',
 			params: 0,
 		}),
+		new web3._extend.Method({
+			name: 'getBadPayloads',
+			call: 'debug_getBadPayloads',
+			params: 0,
+		}),
 		new web3._extend.Method({
 			name: 'storageRangeAt',
 			call: 'debug_storageRangeAt',
-			params: 5,
+			params: 6,
+			inputFormatter: [null, null, null, null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getContractStorageDecoded',
+			call: 'debug_getContractStorageDecoded',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
 		}),
 		new web3._extend.Method({
 			name: 'getModifiedAccountsByNumber',
@@ -473,6 +492,12 @@ web3._extend({
 			call: 'debug_getTrieFlushInterval',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'rpcStats',
+			call: 'debug_rpcStats',
+			params: 1,
+			inputFormatter: [null]
+		}),
 	],
 	properties: []
 });
@@ -524,6 +549,12 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getTransactionsByAddress',
+			call: 'eth_getTransactionsByAddress',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'getHeaderByNumber',
 			call: 'eth_getHeaderByNumber',
@@ -595,6 +626,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'callMany',
+			call: 'eth_callMany',
+			params: 4,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null],
+		}),
 		new web3._extend.Method({
 			name: 'getBlockReceipts',
 			call: 'eth_getBlockReceipts',
@@ -703,6 +740,21 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'export',
+			call: 'txpool_export',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'import',
+			call: 'txpool_import',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'explain',
+			call: 'txpool_explain',
+			params: 1,
+		}),
 	]
 });
 `
@@ -725,3 +777,45 @@ web3._extend({
 	],
 });
 `
+
+const TraceJs = `
+web3._extend({
+	property: 'trace',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'replayTransaction',
+			call: 'trace_replayTransaction',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'replayBlockTransactions',
+			call: 'trace_replayBlockTransactions',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'call',
+			call: 'trace_call',
+			params: 3,
+			inputFormatter: [null, null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'transaction',
+			call: 'trace_transaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'block',
+			call: 'trace_block',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'filter',
+			call: 'trace_filter',
+			params: 1
+		}),
+	],
+});
+`
@@ -17,6 +17,7 @@
 package miner
 
 import (
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -197,6 +198,40 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+// senderFilter is a txpool.TxFilter rejecting all transactions of a sender.
+type senderFilter common.Address
+
+func (f senderFilter) FilterTx(tx *types.Transaction, from common.Address) error {
+	if from == common.Address(f) {
+		return txpool.ErrTxFiltered
+	}
+	return nil
+}
+
+// Tests that pooled transactions rejected by the policy filter installed after
+// their admission are not included into payloads.
+func TestBuildPayloadFiltered(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), db, 0)
+
+	b.txPool.SetTxFilter(senderFilter(testBankAddress))
+	if errs := b.txPool.Add(newTxs, true); !errors.Is(errs[0], txpool.ErrTxFiltered) {
+		t.Fatalf("filtered transaction admitted: have %v, want %v", errs[0], txpool.ErrTxFiltered)
+	}
+	args := &BuildPayloadArgs{
+		Parent:    b.chain.CurrentBlock().Hash(),
+		Timestamp: uint64(time.Now().Unix()),
+		BaseFee:   big.NewInt(1e9),
+	}
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	if txs := payload.ResolveFull().ExecutionPayload.Transactions; len(txs) != 0 {
+		t.Fatalf("filtered transactions included: have %d, want 0", len(txs))
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
@@ -508,6 +508,12 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			txs.Pop()
 			continue
 		}
+		// Skip the account if the policy filter rejects the transaction, which
+		// may have changed since the transaction was admitted into the pool
+		if err := miner.txpool.FilterInclusion(tx, from); err != nil {
+			txs.Pop()
+			continue
+		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
@@ -653,6 +659,10 @@ FIFO:
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Hash(), "eip155", miner.chainConfig.EIP155Block)
 			continue
 		}
+		// Skip the transaction if the policy filter rejects it
+		if err := miner.txpool.FilterInclusion(tx, from); err != nil {
+			continue
+		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -43,6 +44,9 @@ func (n *Node) apis() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   &p2pDebugAPI{n},
+		}, {
+			Namespace: "debug",
+			Service:   &rpcDebugAPI{n},
 		}, {
 			Namespace: "web3",
 			Service:   &web3API{n},
@@ -181,6 +185,9 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimits:             api.node.config.RPCRateLimits,
+			callTracker:            api.node.callTracker,
+			authenticator:          api.node.rpcAuth,
 		},
 	}
 	if cors != nil {
@@ -259,6 +266,9 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimits:             api.node.config.RPCRateLimits,
+			callTracker:            api.node.callTracker,
+			authenticator:          api.node.rpcAuth,
 		},
 	}
 	if apis != nil {
@@ -350,3 +360,32 @@ func (s *p2pDebugAPI) DiscoveryV4Table() [][]discover.BucketNode {
 	}
 	return nil
 }
+
+// rpcStatsWindows are the time windows of the RPC call statistics.
+var rpcStatsWindows = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+}
+
+// rpcDebugAPI provides access to the statistics of the RPC calls.
+type rpcDebugAPI struct {
+	stack *Node
+}
+
+// RpcStats returns the statistics of the calls served over HTTP and WebSocket
+// in the last minute, 5 minutes, 15 minutes and hour, with the top clients and
+// methods by total call duration and the slowest calls. The number of entries
+// defaults to 10.
+func (api *rpcDebugAPI) RpcStats(count *int) map[string]*rpc.CallStats {
+	n := 10
+	if count != nil && *count > 0 {
+		n = *count
+	}
+	stats := make(map[string]*rpc.CallStats, len(rpcStatsWindows))
+	for name, window := range rpcStatsWindows {
+		stats[name] = api.stack.callTracker.Stats(window, n)
+	}
+	return stats
+}
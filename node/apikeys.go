@@ -0,0 +1,250 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// apiKeyHeader is the header carrying the API key, alternatively to a bearer
+// token in the Authorization header.
+const apiKeyHeader = "X-Api-Key"
+
+// Authenticator authenticates the requests to the public HTTP and WebSocket
+// endpoints, returning the authorization of the client.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*rpc.ClientAuth, error)
+}
+
+// APIKey is an entry of the API keys file. It is either a static key sent as is
+// by the clients, or a secret signing the JWT tokens sent by the clients.
+type APIKey struct {
+	Name      string        `json:"name"`
+	Key       string        `json:"key,omitempty"`       // Static key
+	JWTSecret hexutil.Bytes `json:"jwtSecret,omitempty"` // HS256 secret of the JWT tokens
+	Methods   []string      `json:"methods,omitempty"`   // Patterns of the allowed methods, all if empty
+	Tier      string        `json:"tier,omitempty"`      // Rate limit tier of the clients
+}
+
+// apiKeyClaims are the claims of the JWT tokens of API keys. The tokens must
+// expire, the methods of a token restrict the methods allowed by its key further.
+type apiKeyClaims struct {
+	jwt.RegisteredClaims
+	Methods []string `json:"methods,omitempty"`
+}
+
+// apiKeySet is a loaded API keys file.
+type apiKeySet struct {
+	static map[[32]byte]*APIKey // Static keys by their hash
+	jwt    []*APIKey            // JWT keys
+}
+
+// loadAPIKeys loads and validates an API keys file.
+func loadAPIKeys(path string) (*apiKeySet, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Keys []*APIKey `json:"keys"`
+	}
+	if err := json.Unmarshal(blob, &file); err != nil {
+		return nil, fmt.Errorf("invalid API keys file: %v", err)
+	}
+	var (
+		set   = &apiKeySet{static: make(map[[32]byte]*APIKey)}
+		names = make(map[string]bool)
+	)
+	for i, key := range file.Keys {
+		switch {
+		case key.Name == "":
+			return nil, fmt.Errorf("API key %d has no name", i)
+		case names[key.Name]:
+			return nil, fmt.Errorf("duplicate API key %q", key.Name)
+		case (key.Key == "") == (len(key.JWTSecret) == 0):
+			return nil, fmt.Errorf("API key %q must have either a key or a JWT secret", key.Name)
+		}
+		names[key.Name] = true
+		if key.Key != "" {
+			set.static[sha256.Sum256([]byte(key.Key))] = key
+		} else {
+			set.jwt = append(set.jwt, key)
+		}
+	}
+	return set, nil
+}
+
+// allowedMethods returns the patterns of the methods allowed by both a key and
+// its token. Token patterns not covered by the key are dropped.
+func allowedMethods(key, token []string) []string {
+	if len(token) == 0 {
+		return key
+	}
+	if len(key) == 0 {
+		return token
+	}
+	var allowed []string
+	for _, pattern := range token {
+		for _, keyPattern := range key {
+			prefix, wildcard := strings.CutSuffix(keyPattern, "*")
+			if pattern == keyPattern || (wildcard && strings.HasPrefix(pattern, prefix)) {
+				allowed = append(allowed, pattern)
+				break
+			}
+		}
+	}
+	return allowed
+}
+
+// authenticate authenticates a client by its static key or JWT token.
+func (set *apiKeySet) authenticate(token string) (*rpc.ClientAuth, error) {
+	if key := set.static[sha256.Sum256([]byte(token))]; key != nil {
+		return &rpc.ClientAuth{ID: "apikey:" + key.Name, Tier: key.Tier, Methods: key.Methods}, nil
+	}
+	if strings.Count(token, ".") != 2 || len(set.jwt) == 0 {
+		return nil, errors.New("invalid API key")
+	}
+	var err error
+	for _, key := range set.jwt {
+		var claims apiKeyClaims
+		_, err = jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+			if kid, ok := t.Header["kid"].(string); ok && kid != key.Name {
+				return nil, errors.New("key id mismatch")
+			}
+			return []byte(key.JWTSecret), nil
+		}, jwt.WithValidMethods([]string{"HS256"}))
+		if err != nil {
+			continue
+		}
+		if claims.ExpiresAt == nil {
+			return nil, errors.New("token has no expiry")
+		}
+		methods := allowedMethods(key.Methods, claims.Methods)
+		if len(methods) == 0 && len(key.Methods)+len(claims.Methods) > 0 {
+			return nil, errors.New("token allows no methods")
+		}
+		id := "jwt:" + key.Name
+		if claims.Subject != "" {
+			id += "/" + claims.Subject
+		}
+		return &rpc.ClientAuth{ID: id, Tier: key.Tier, Methods: methods}, nil
+	}
+	return nil, err
+}
+
+// fileAuthenticator authenticates the clients by the API keys of a file,
+// reloaded on SIGHUP.
+type fileAuthenticator struct {
+	path string
+	keys atomic.Pointer[apiKeySet]
+	log  log.Logger
+}
+
+// newFileAuthenticator loads the API keys file and reloads it on SIGHUP until
+// quit is closed.
+func newFileAuthenticator(path string, logger log.Logger, quit <-chan struct{}) (*fileAuthenticator, error) {
+	a := &fileAuthenticator{path: path, log: logger}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sighup)
+		for {
+			select {
+			case <-sighup:
+				if err := a.reload(); err != nil {
+					a.log.Error("Failed to reload API keys, keeping the previous ones", "path", a.path, "err", err)
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return a, nil
+}
+
+// reload loads the API keys file, replacing the keys if valid.
+func (a *fileAuthenticator) reload() error {
+	set, err := loadAPIKeys(a.path)
+	if err != nil {
+		return err
+	}
+	a.keys.Store(set)
+	a.log.Info("Loaded API keys", "path", a.path, "static", len(set.static), "jwt", len(set.jwt))
+	return nil
+}
+
+// Authenticate implements Authenticator.
+func (a *fileAuthenticator) Authenticate(r *http.Request) (*rpc.ClientAuth, error) {
+	token := r.Header.Get(apiKeyHeader)
+	if auth := r.Header.Get("Authorization"); token == "" && strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if token == "" {
+		return nil, errors.New("missing API key")
+	}
+	return a.keys.Load().authenticate(token)
+}
+
+// authHandler is an http.Handler authenticating the requests before passing
+// them with the authorization of the client to the next handler.
+type authHandler struct {
+	auth Authenticator
+	next http.Handler
+}
+
+// newAuthHandler creates a http.Handler authenticating the requests.
+func newAuthHandler(auth Authenticator, next http.Handler) http.Handler {
+	return &authHandler{auth: auth, next: next}
+}
+
+// ServeHTTP implements http.Handler
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Let the empty health-check requests through, the RPC server answers
+	// them without serving any method. CORS preflight requests carry no
+	// credentials and are answered by the CORS handler.
+	if r.Method == http.MethodGet && r.ContentLength == 0 && r.URL.RawQuery == "" && !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	auth, err := h.auth.Authenticate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r.WithContext(rpc.WithClientAuth(r.Context(), auth)))
+}
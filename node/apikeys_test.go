@@ -0,0 +1,181 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// writeAPIKeys writes an API keys file.
+func writeAPIKeys(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	keysPath := filepath.Join(t.TempDir(), "apikeys.json")
+	writeAPIKeys(t, keysPath, `{"keys": [
+		{"name": "internal", "key": "internal-key"},
+		{"name": "public", "key": "public-key", "methods": ["eth_*"], "tier": "public"},
+		{"name": "dapp", "jwtSecret": "0x0102030405060708", "methods": ["eth_*", "debug_helloWorld"]}
+	]}`)
+	conf := &Config{
+		HTTPHost:    "127.0.0.1",
+		WSHost:      "127.0.0.1",
+		HTTPModules: []string{"eth", "debug"},
+		WSModules:   []string{"eth", "debug"},
+		RPCAPIKeys:  keysPath,
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	defer node.Close()
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "eth", Service: helloRPC("hello eth")},
+		{Namespace: "debug", Service: helloRPC("hello debug")},
+	})
+	node.RegisterHandler("GraphQL", "/graphql", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	call := func(endpoint, method string, headers map[string]string) error {
+		var opts []rpc.ClientOption
+		for k, v := range headers {
+			opts = append(opts, rpc.WithHeader(k, v))
+		}
+		client, err := rpc.DialOptions(context.Background(), endpoint, opts...)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		var result interface{}
+		return client.Call(&result, method)
+	}
+	token := func(claims jwt.MapClaims) string {
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + tok
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name    string
+		headers map[string]string
+		method  string
+		fail    bool
+	}{
+		{"anonymous", nil, "eth_helloWorld", true},
+		{"unknown key", map[string]string{"X-Api-Key": "other-key"}, "eth_helloWorld", true},
+		{"internal key", map[string]string{"X-Api-Key": "internal-key"}, "debug_helloWorld", false},
+		{"bearer key", map[string]string{"Authorization": "Bearer public-key"}, "eth_helloWorld", false},
+		{"restricted key", map[string]string{"X-Api-Key": "public-key"}, "debug_helloWorld", true},
+		{"metadata", map[string]string{"X-Api-Key": "public-key"}, "rpc_modules", false},
+		{"jwt", map[string]string{"Authorization": token(jwt.MapClaims{"sub": "alice", "exp": exp})}, "debug_helloWorld", false},
+		{"jwt claims", map[string]string{"Authorization": token(jwt.MapClaims{"methods": []string{"eth_helloWorld"}, "exp": exp})}, "debug_helloWorld", true},
+		{"jwt claims allowed", map[string]string{"Authorization": token(jwt.MapClaims{"methods": []string{"eth_helloWorld"}, "exp": exp})}, "eth_helloWorld", false},
+		{"jwt claims outside key", map[string]string{"Authorization": token(jwt.MapClaims{"methods": []string{"admin_*"}, "exp": exp})}, "eth_helloWorld", true},
+		{"jwt without expiry", map[string]string{"Authorization": token(jwt.MapClaims{"sub": "alice"})}, "eth_helloWorld", true},
+		{"jwt expired", map[string]string{"Authorization": token(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})}, "eth_helloWorld", true},
+	}
+	for _, endpoint := range []string{node.HTTPEndpoint(), node.WSEndpoint()} {
+		for _, test := range tests {
+			if err := call(endpoint, test.method, test.headers); (err != nil) != test.fail {
+				t.Errorf("%s %s: unexpected result %v", endpoint, test.name, err)
+			}
+		}
+	}
+	// Health checks are answered without a key
+	resp, err := http.Get(node.HTTPEndpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("health check failed: %s", resp.Status)
+	}
+	// Handlers registered on the endpoint require a key too
+	post := func(key string) int {
+		req, _ := http.NewRequest(http.MethodPost, node.HTTPEndpoint()+"/graphql", strings.NewReader(`{"query": "{ syncing { startingBlock } }"}`))
+		if key != "" {
+			req.Header.Set("X-Api-Key", key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := post(""); status != http.StatusUnauthorized {
+		t.Fatalf("graphql request without key: have status %d, want %d", status, http.StatusUnauthorized)
+	}
+	if status := post("internal-key"); status != http.StatusOK {
+		t.Fatalf("graphql request with key: have status %d, want %d", status, http.StatusOK)
+	}
+	// Reloading replaces the keys, invalid files are ignored
+	auth := node.rpcAuth.(*fileAuthenticator)
+	writeAPIKeys(t, keysPath, `{"keys": [{"name": "internal", "key": "rotated-key"}]}`)
+	if err := auth.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(node.HTTPEndpoint(), "eth_helloWorld", map[string]string{"X-Api-Key": "internal-key"}); err == nil {
+		t.Fatal("expected revoked key to fail")
+	}
+	writeAPIKeys(t, keysPath, `{"keys": [{"name": "internal"}]}`)
+	if err := auth.reload(); err == nil {
+		t.Fatal("expected invalid keys file to fail")
+	}
+	if err := call(node.HTTPEndpoint(), "eth_helloWorld", map[string]string{"X-Api-Key": "rotated-key"}); err != nil {
+		t.Fatalf("rotated key failed: %v", err)
+	}
+}
+
+func TestAllowedMethods(t *testing.T) {
+	tests := []struct {
+		key, token, want []string
+	}{
+		{nil, nil, nil},
+		{[]string{"eth_*"}, nil, []string{"eth_*"}},
+		{nil, []string{"eth_call"}, []string{"eth_call"}},
+		{[]string{"eth_*", "debug_traceCall"}, []string{"eth_get*", "debug_*", "debug_traceCall"}, []string{"eth_get*", "debug_traceCall"}},
+		{[]string{"eth_call"}, []string{"eth_*"}, nil},
+	}
+	for i, test := range tests {
+		have := allowedMethods(test.key, test.token)
+		if len(have) != len(test.want) {
+			t.Fatalf("test %d: have %v, want %v", i, have, test.want)
+		}
+		for j := range have {
+			if have[j] != test.want[j] {
+				t.Fatalf("test %d: have %v, want %v", i, have, test.want)
+			}
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -207,6 +208,22 @@ type Config struct {
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
+	// RPCRateLimits are the per-client limits applied to the calls over HTTP and
+	// WebSocket.
+	RPCRateLimits rpc.RateLimitConfig `toml:",omitempty"`
+
+	// RPCSlowQueryThreshold is the minimum duration of the calls over HTTP and
+	// WebSocket logged as slow, zero disables the slow query log.
+	RPCSlowQueryThreshold time.Duration `toml:",omitempty"`
+
+	// RPCAPIKeys is the path to the JSON file of the API keys authenticating the
+	// calls over HTTP and WebSocket. The file is reloaded on SIGHUP.
+	RPCAPIKeys string `toml:",omitempty"`
+
+	// RPCAuthenticator authenticates the calls over HTTP and WebSocket instead
+	// of the API keys file.
+	RPCAuthenticator Authenticator `toml:"-"`
+
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
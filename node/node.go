@@ -57,14 +57,16 @@ type Node struct {
 	state         int           // Tracks state of node lifecycle
 
 	lock          sync.Mutex
-	lifecycles    []Lifecycle // All registered backends, services, and auxiliary services that have a lifecycle
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	httpAuth      *httpServer //
-	wsAuth        *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	lifecycles    []Lifecycle      // All registered backends, services, and auxiliary services that have a lifecycle
+	rpcAPIs       []rpc.API        // List of APIs currently provided by the node
+	http          *httpServer      //
+	ws            *httpServer      //
+	httpAuth      *httpServer      //
+	wsAuth        *httpServer      //
+	ipc           *ipcServer       // Stores information about the ipc http server
+	inprocHandler *rpc.Server      // In-process RPC request handler to process the API requests
+	callTracker   *rpc.CallTracker // Statistics of the calls served over HTTP and WebSocket
+	rpcAuth       Authenticator    // Authenticator of the calls over HTTP and WebSocket, if any
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -108,6 +110,7 @@ func New(conf *Config) (*Node, error) {
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
+		callTracker:   rpc.NewCallTracker(conf.RPCSlowQueryThreshold),
 		eventmux:      new(event.TypeMux),
 		log:           conf.Logger,
 		stop:          make(chan struct{}),
@@ -156,6 +159,15 @@ func New(conf *Config) (*Node, error) {
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
+	// Configure the authentication of the public RPC servers.
+	node.rpcAuth = conf.RPCAuthenticator
+	if node.rpcAuth == nil && conf.RPCAPIKeys != "" {
+		auth, err := newFileAuthenticator(conf.RPCAPIKeys, node.log, node.stop)
+		if err != nil {
+			return nil, err
+		}
+		node.rpcAuth = auth
+	}
 	return node, nil
 }
 
@@ -393,6 +405,9 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimits:             n.config.RPCRateLimits,
+		callTracker:            n.callTracker,
+		authenticator:          n.rpcAuth,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -58,6 +58,17 @@ type rpcEndpointConfig struct {
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	rateLimits             rpc.RateLimitConfig
+	callTracker            *rpc.CallTracker
+	authenticator          Authenticator // optional authenticator of the clients
+}
+
+// wrapAuth wraps the handler with the authentication of the clients, if any.
+func (config *rpcEndpointConfig) wrapAuth(handler http.Handler) http.Handler {
+	if config.authenticator == nil {
+		return handler
+	}
+	return newAuthHandler(config.authenticator, handler)
 }
 
 type rpcHandler struct {
@@ -215,7 +226,8 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		// These are made available when RPC is enabled.
 		muxHandler, pattern := h.mux.Handler(r)
 		if pattern != "" {
-			muxHandler.ServeHTTP(w, r)
+			// The handlers share the authentication of the RPC endpoint.
+			h.httpConfig.wrapAuth(muxHandler).ServeHTTP(w, r)
 			return
 		}
 
@@ -309,12 +321,14 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimits(config.rateLimits)
+	srv.SetCallTracker(config.callTracker)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: NewHTTPHandlerStack(config.wrapAuth(srv), config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
 		server:  srv,
 	})
 	return nil
@@ -344,12 +358,14 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	srv.SetRateLimits(config.rateLimits)
+	srv.SetCallTracker(config.callTracker)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: NewWSHandlerStack(config.wrapAuth(srv.WebsocketHandler(config.Origins)), config.jwtSecret),
 		server:  srv,
 	})
 	return nil
@@ -515,7 +531,9 @@ func (w *gzipResponseWriter) init() {
 	// responses which are flushed out close to the write deadline of the response. For
 	// these cases, we want to avoid chunked transfer encoding and compression because
 	// they require additional output that may not get written in time.
-	passthrough := hdr.Get("transfer-encoding") == "identity"
+	//
+	// Responses already compressed by the RPC server are passed through as well.
+	passthrough := hdr.Get("transfer-encoding") == "identity" || hdr.Get("content-encoding") != ""
 	if !passthrough {
 		w.gz = gzPool.Get().(*gzip.Writer)
 		w.gz.Reset(w.resp)
@@ -561,6 +579,12 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the wrapped writer, giving http.ResponseController access to
+// it.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -519,6 +519,16 @@ func TestGzipHandler(t *testing.T) {
 			status: 205,
 			header: map[string]string{"x-foo": "bar"},
 		},
+		{
+			name: "already-encoded",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-encoding", "zstd")
+				w.Write([]byte("response"))
+			},
+			isGzip: false,
+			status: 200,
+			header: map[string]string{"content-encoding": "zstd"},
+		},
 	}
 
 	for _, test := range tests {
@@ -41,6 +41,22 @@ func (c *TxPoolConfig) IsPreconfTxFrom(from common.Address) bool {
 	return false
 }
 
+// Check if to is in ToPreconfs
+func (c *TxPoolConfig) IsPreconfTxTo(to common.Address) bool {
+	// If AllPreconfs is true, all transactions are considered preconf
+	if c.AllPreconfs {
+		return true
+	}
+
+	// Check if to is in ToPreconfs
+	for _, preconfTo := range c.ToPreconfs {
+		if preconfTo == to {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *TxPoolConfig) IsPreconfTx(from, to *common.Address) bool {
 	// If AllPreconfs is true, all transactions are considered preconf
 	if c.AllPreconfs {
@@ -0,0 +1,287 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	callStatsBucket  = 10 * time.Second // Time span of the call statistics buckets
+	callStatsHistory = time.Hour        // Time span of the tracked call statistics
+	callStatsSlowest = 20               // Number of slowest calls kept per bucket
+	callParamsLimit  = 256              // Maximum length of the logged call parameters
+)
+
+// CallRecord is a served call.
+type CallRecord struct {
+	Time         time.Time `json:"time"`
+	Method       string    `json:"method"`
+	Params       string    `json:"params"`
+	Client       string    `json:"client"`
+	Duration     string    `json:"duration"`
+	ResponseSize int       `json:"responseSize"`
+	DBReads      int       `json:"dbReads"`
+	Failed       bool      `json:"failed"`
+
+	duration time.Duration
+}
+
+// CallAggregate is the cost of the calls of a client or method.
+type CallAggregate struct {
+	Name         string `json:"name"`
+	Calls        uint64 `json:"calls"`
+	Failures     uint64 `json:"failures"`
+	Duration     string `json:"duration"`
+	ResponseSize uint64 `json:"responseSize"`
+	DBReads      uint64 `json:"dbReads"`
+
+	duration time.Duration
+}
+
+// add accounts the cost of a call to the aggregate.
+func (a *CallAggregate) add(call *CallRecord) {
+	a.Calls++
+	if call.Failed {
+		a.Failures++
+	}
+	a.duration += call.duration
+	a.ResponseSize += uint64(call.ResponseSize)
+	a.DBReads += uint64(call.DBReads)
+}
+
+// merge accounts the costs of another aggregate to the aggregate.
+func (a *CallAggregate) merge(other *CallAggregate) {
+	a.Calls += other.Calls
+	a.Failures += other.Failures
+	a.duration += other.duration
+	a.ResponseSize += other.ResponseSize
+	a.DBReads += other.DBReads
+}
+
+// CallStats are the statistics of the calls served in a time window.
+type CallStats struct {
+	Calls   uint64           `json:"calls"`
+	Clients []*CallAggregate `json:"clients"` // Clients with the most total call duration
+	Methods []*CallAggregate `json:"methods"` // Methods with the most total call duration
+	Slowest []*CallRecord    `json:"slowest"` // Slowest calls
+}
+
+// callBucket holds the statistics of the calls served in a time span.
+type callBucket struct {
+	start   time.Time
+	calls   uint64
+	clients map[string]*CallAggregate
+	methods map[string]*CallAggregate
+	slowest []*CallRecord // Sorted by decreasing duration
+}
+
+// CallTracker keeps statistics of the calls served by RPC servers over a
+// sliding time window, and logs the slow ones.
+type CallTracker struct {
+	slowThreshold time.Duration
+
+	lock    sync.Mutex
+	buckets []*callBucket // Ring of buckets, indexed by start time
+}
+
+// NewCallTracker creates a call tracker logging the calls taking at least the
+// given threshold, zero disables the slow call log.
+func NewCallTracker(slowThreshold time.Duration) *CallTracker {
+	return &CallTracker{
+		slowThreshold: slowThreshold,
+		buckets:       make([]*callBucket, callStatsHistory/callStatsBucket),
+	}
+}
+
+// slow returns whether a call of the given duration should be logged.
+func (t *CallTracker) slow(elapsed time.Duration) bool {
+	return t.slowThreshold > 0 && elapsed >= t.slowThreshold
+}
+
+// bucket returns the bucket of the given time, the caller must hold t.lock.
+func (t *CallTracker) bucket(now time.Time) *callBucket {
+	var (
+		start = now.Truncate(callStatsBucket)
+		index = int(start.UnixNano()/int64(callStatsBucket)) % len(t.buckets)
+	)
+	if b := t.buckets[index]; b != nil && b.start.Equal(start) {
+		return b
+	}
+	b := &callBucket{
+		start:   start,
+		clients: make(map[string]*CallAggregate),
+		methods: make(map[string]*CallAggregate),
+	}
+	t.buckets[index] = b
+	return b
+}
+
+// record accounts a served call.
+func (t *CallTracker) record(call *CallRecord) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	b := t.bucket(call.Time)
+	b.calls++
+	addAggregate(b.clients, call.Client, call)
+	addAggregate(b.methods, call.Method, call)
+
+	if len(b.slowest) < callStatsSlowest || call.duration > b.slowest[len(b.slowest)-1].duration {
+		pos, _ := slices.BinarySearchFunc(b.slowest, call.duration, func(c *CallRecord, d time.Duration) int {
+			return cmp.Compare(d, c.duration)
+		})
+		b.slowest = slices.Insert(b.slowest, pos, call)
+		if len(b.slowest) > callStatsSlowest {
+			b.slowest = b.slowest[:callStatsSlowest]
+		}
+	}
+}
+
+// Stats returns the statistics of the calls served within the given window,
+// with the top n clients, methods and slowest calls.
+func (t *CallTracker) Stats(window time.Duration, n int) *CallStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		since   = time.Now().Add(-window)
+		stats   = new(CallStats)
+		clients = make(map[string]*CallAggregate)
+		methods = make(map[string]*CallAggregate)
+	)
+	for _, b := range t.buckets {
+		if b == nil || b.start.Add(callStatsBucket).Before(since) {
+			continue
+		}
+		stats.Calls += b.calls
+		mergeAggregates(clients, b.clients)
+		mergeAggregates(methods, b.methods)
+		stats.Slowest = append(stats.Slowest, b.slowest...)
+	}
+	stats.Clients = topAggregates(clients, n)
+	stats.Methods = topAggregates(methods, n)
+
+	slices.SortFunc(stats.Slowest, func(a, b *CallRecord) int {
+		return cmp.Compare(b.duration, a.duration)
+	})
+	if len(stats.Slowest) > n {
+		stats.Slowest = stats.Slowest[:n]
+	}
+	for i, call := range stats.Slowest {
+		record := *call
+		record.Duration = common.PrettyDuration(call.duration).String()
+		stats.Slowest[i] = &record
+	}
+	return stats
+}
+
+// addAggregate accounts the cost of a call to the named aggregate.
+func addAggregate(aggregates map[string]*CallAggregate, name string, call *CallRecord) {
+	a := aggregates[name]
+	if a == nil {
+		a = &CallAggregate{Name: name}
+		aggregates[name] = a
+	}
+	a.add(call)
+}
+
+// mergeAggregates merges the source aggregates into the target ones.
+func mergeAggregates(target, source map[string]*CallAggregate) {
+	for name, a := range source {
+		merged := target[name]
+		if merged == nil {
+			merged = &CallAggregate{Name: name}
+			target[name] = merged
+		}
+		merged.merge(a)
+	}
+}
+
+// topAggregates returns the n aggregates with the most total call duration.
+func topAggregates(aggregates map[string]*CallAggregate, n int) []*CallAggregate {
+	top := make([]*CallAggregate, 0, len(aggregates))
+	for _, a := range aggregates {
+		a.Duration = common.PrettyDuration(a.duration).String()
+		top = append(top, a)
+	}
+	slices.SortFunc(top, func(a, b *CallAggregate) int {
+		return cmp.Compare(b.duration, a.duration)
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// truncateParams returns the parameters of a call for logging.
+func truncateParams(params json.RawMessage) string {
+	if len(params) > callParamsLimit {
+		return string(params[:callParamsLimit]) + "..."
+	}
+	return string(params)
+}
+
+// dbReadCounters are the database read counters of the calls of a callProc.
+type dbReadCounters struct {
+	lock     sync.Mutex
+	counters []func() int
+	done     bool // set once the calls are done, ignoring counters of leftover goroutines
+}
+
+type dbReadsContextKey struct{}
+
+// TrackDBReads registers a counter of the database reads made by the RPC call of
+// the context, such as the number of state entries loaded by its state. It does
+// nothing if the server doesn't keep call statistics.
+func TrackDBReads(ctx context.Context, counter func() int) {
+	if c, ok := ctx.Value(dbReadsContextKey{}).(*dbReadCounters); ok {
+		c.lock.Lock()
+		if !c.done {
+			c.counters = append(c.counters, counter)
+		}
+		c.lock.Unlock()
+	}
+}
+
+// take returns the reads of the registered counters and resets them.
+func (c *dbReadCounters) take() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var reads int
+	for _, counter := range c.counters {
+		reads += counter()
+	}
+	c.counters = c.counters[:0]
+	return reads
+}
+
+// close drops the registered counters and ignores the later ones.
+func (c *dbReadCounters) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.counters, c.done = nil, true
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readService is a service reporting database reads.
+type readService struct{}
+
+func (s *readService) Read(ctx context.Context, reads int) int {
+	TrackDBReads(ctx, func() int { return reads })
+	return reads
+}
+
+func TestCallTracker(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	if err := server.RegisterName("read", new(readService)); err != nil {
+		t.Fatal(err)
+	}
+	tracker := NewCallTracker(time.Millisecond)
+	server.SetCallTracker(tracker)
+
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 1; i <= 3; i++ {
+		if err := client.Call(nil, "read_read", i*10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.Call(nil, "test_sleep", 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Call(nil, "test_returnError"); err == nil {
+		t.Fatal("expected error")
+	}
+	// Batched calls are accounted separately
+	batch := []BatchElem{
+		{Method: "read_read", Args: []interface{}{5}},
+		{Method: "test_echo", Args: []interface{}{strings.Repeat("x", 1000), 1, &echoArgs{"world"}}, Result: new(echoResult)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	stats := tracker.Stats(time.Minute, 2)
+	if stats.Calls != 7 {
+		t.Fatalf("wrong call count: have %d, want 7", stats.Calls)
+	}
+	if len(stats.Clients) != 1 || stats.Clients[0].Name != "127.0.0.1" || stats.Clients[0].Calls != 7 || stats.Clients[0].Failures != 1 || stats.Clients[0].DBReads != 65 {
+		t.Fatalf("wrong client stats: %+v", stats.Clients)
+	}
+	if len(stats.Methods) != 2 || stats.Methods[0].Name != "test_sleep" {
+		t.Fatalf("wrong method stats: %+v", stats.Methods)
+	}
+	if len(stats.Slowest) != 2 || stats.Slowest[0].Method != "test_sleep" || stats.Slowest[0].Params != "[50000000]" {
+		t.Fatalf("wrong slowest calls: %+v", stats.Slowest)
+	}
+	for _, m := range tracker.Stats(time.Minute, 10).Methods {
+		switch m.Name {
+		case "read_read":
+			if m.Calls != 4 || m.DBReads != 65 {
+				t.Fatalf("wrong read stats: %+v", m)
+			}
+		case "test_echo":
+			if m.ResponseSize < 1000 {
+				t.Fatalf("wrong response size: %+v", m)
+			}
+		}
+	}
+}
+
+func TestTruncateParams(t *testing.T) {
+	params := []byte(`["` + strings.Repeat("a", 2*callParamsLimit) + `"]`)
+	if have := truncateParams(params); len(have) != callParamsLimit+3 || !strings.HasSuffix(have, "...") {
+		t.Fatalf("wrong truncated params %q", have)
+	}
+	if have := truncateParams([]byte(`[1]`)); have != "[1]" {
+		t.Fatalf("wrong params %q", have)
+	}
+}
@@ -0,0 +1,472 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// cborContentType is the media type of the CBOR encoded HTTP responses.
+//
+// CBOR responses carry the same data as the JSON ones, except that the hex
+// strings with a 0x prefix, such as hashes, addresses and the raw RLP encoded
+// blocks and receipts returned by the debug_getRaw* methods, are sent as binary
+// byte strings. Clients opt into it through the Accept header of their requests.
+//
+// There is no RLP encoding of the block and receipt objects: their RPC form has
+// fields outside their consensus encoding. Clients after RLP call the
+// debug_getRaw* methods, whose results are binary in CBOR.
+const cborContentType = "application/cbor"
+
+// maxCBORDepth is the maximum nesting depth of decoded CBOR values.
+const maxCBORDepth = 10000
+
+// CBOR major types and simple values, see RFC 8949.
+const (
+	cborUint   = 0 << 5
+	cborNegInt = 1 << 5
+	cborBytes  = 2 << 5
+	cborText   = 3 << 5
+	cborArray  = 4 << 5
+	cborMap    = 5 << 5
+	cborTag    = 6 << 5
+	cborSimple = 7 << 5
+
+	cborFalse      = cborSimple | 20
+	cborTrue       = cborSimple | 21
+	cborNull       = cborSimple | 22
+	cborFloat16    = cborSimple | 25
+	cborFloat32    = cborSimple | 26
+	cborFloat64    = cborSimple | 27
+	cborBreak      = cborSimple | 31
+	cborIndefinite = 31
+
+	cborTagPosBignum = 2
+	cborTagNegBignum = 3
+)
+
+var errCBORDepth = errors.New("cbor: maximum nesting depth exceeded")
+
+// acceptsCBOR returns whether the Accept header of a request accepts CBOR
+// encoded responses.
+func acceptsCBOR(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil || mt != cborContentType {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// marshalCBOR returns the CBOR encoding of a value, converted from its JSON
+// encoding.
+func marshalCBOR(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToCBOR(data)
+}
+
+// jsonToCBOR converts a JSON document to CBOR. Arrays and objects are encoded
+// with indefinite lengths, which avoids buffering their elements.
+func jsonToCBOR(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	out := make([]byte, 0, len(data)/2)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case json.Delim:
+			switch tok {
+			case '[':
+				out = append(out, cborArray|cborIndefinite)
+			case '{':
+				out = append(out, cborMap|cborIndefinite)
+			default:
+				out = append(out, cborBreak)
+			}
+		case bool:
+			if tok {
+				out = append(out, cborTrue)
+			} else {
+				out = append(out, cborFalse)
+			}
+		case nil:
+			out = append(out, cborNull)
+		case json.Number:
+			if out, err = appendCBORNumber(out, tok); err != nil {
+				return nil, err
+			}
+		case string:
+			out = appendCBORString(out, tok)
+		}
+	}
+}
+
+// appendCBORHead appends the head of a data item of the given major type.
+func appendCBORHead(out []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(out, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(out, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(out, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(out, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, major|27), n)
+	}
+}
+
+// appendCBORNumber appends a JSON number as an integer if it's integral, or as a
+// double precision float otherwise.
+func appendCBORNumber(out []byte, num json.Number) ([]byte, error) {
+	s := num.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return appendCBORHead(out, cborUint, n), nil
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return appendCBORHead(out, cborNegInt, uint64(-(n + 1))), nil
+		}
+		// Integers out of the 64 bit range are encoded as bignums
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("cbor: invalid number %s", s)
+		}
+		if n.Sign() < 0 {
+			out = appendCBORHead(out, cborTag, cborTagNegBignum)
+			n.Not(n)
+		} else {
+			out = appendCBORHead(out, cborTag, cborTagPosBignum)
+		}
+		return append(appendCBORHead(out, cborBytes, uint64(len(n.Bytes()))), n.Bytes()...), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return binary.BigEndian.AppendUint64(append(out, cborFloat64), math.Float64bits(f)), nil
+}
+
+// appendCBORString appends a string as a byte string if it's a lowercase hex
+// string of even length with a 0x prefix, and as a text string otherwise. The
+// byte strings are decoded back into the same hex strings.
+func appendCBORString(out []byte, s string) []byte {
+	if isCBORHex(s) {
+		out = appendCBORHead(out, cborBytes, uint64(len(s)-2)/2)
+		b, _ := hex.AppendDecode(out, []byte(s[2:]))
+		return b
+	}
+	return append(appendCBORHead(out, cborText, uint64(len(s))), s...)
+}
+
+// isCBORHex returns whether a string is encoded as a byte string.
+func isCBORHex(s string) bool {
+	if len(s) < 2 || len(s)%2 != 0 || s[:2] != "0x" {
+		return false
+	}
+	for i := 2; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// cborToJSON converts a CBOR document produced by jsonToCBOR back to JSON.
+func cborToJSON(data []byte) ([]byte, error) {
+	d := &cborDecoder{data: data, out: make([]byte, 0, 2*len(data))}
+	if err := d.value(0); err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, errors.New("cbor: trailing data")
+	}
+	return d.out, nil
+}
+
+// cborDecoder converts CBOR data items to JSON.
+type cborDecoder struct {
+	data []byte
+	pos  int
+	out  []byte
+}
+
+// head reads the head of a data item, returning its major type, additional
+// information and argument.
+func (d *cborDecoder) head() (major, info byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info = b&0xe0, b&0x1f
+	if info < 24 || info == cborIndefinite {
+		return major, info, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	size := 1 << (info - 24)
+	if len(d.data)-d.pos < size {
+		return 0, 0, 0, io.ErrUnexpectedEOF
+	}
+	for _, c := range d.data[d.pos : d.pos+size] {
+		arg = arg<<8 | uint64(c)
+	}
+	d.pos += size
+	return major, info, arg, nil
+}
+
+// bytes reads the payload of a byte or text string.
+func (d *cborDecoder) bytes(info byte, n uint64) ([]byte, error) {
+	if info == cborIndefinite {
+		return nil, errors.New("cbor: indefinite length strings are not supported")
+	}
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// more returns whether the current container has more items, consuming its
+// break code if not.
+func (d *cborDecoder) more(indefinite bool, i, n uint64) (bool, error) {
+	if !indefinite {
+		return i < n, nil
+	}
+	if d.pos >= len(d.data) {
+		return false, io.ErrUnexpectedEOF
+	}
+	if d.data[d.pos] == cborBreak {
+		d.pos++
+		return false, nil
+	}
+	return true, nil
+}
+
+// value converts a data item to JSON.
+func (d *cborDecoder) value(depth int) error {
+	if depth > maxCBORDepth {
+		return errCBORDepth
+	}
+	major, info, arg, err := d.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case cborUint:
+		d.out = strconv.AppendUint(d.out, arg, 10)
+	case cborNegInt:
+		if arg < math.MaxInt64 {
+			d.out = strconv.AppendInt(d.out, -int64(arg)-1, 10)
+		} else {
+			n := new(big.Int).SetUint64(arg)
+			d.out = n.Not(n).Append(d.out, 10)
+		}
+	case cborBytes:
+		b, err := d.bytes(info, arg)
+		if err != nil {
+			return err
+		}
+		d.appendHex(b)
+	case cborText:
+		b, err := d.bytes(info, arg)
+		if err != nil {
+			return err
+		}
+		enc, err := json.Marshal(string(b))
+		if err != nil {
+			return err
+		}
+		d.out = append(d.out, enc...)
+	case cborArray:
+		d.out = append(d.out, '[')
+		for i := uint64(0); ; i++ {
+			more, err := d.more(info == cborIndefinite, i, arg)
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			if i > 0 {
+				d.out = append(d.out, ',')
+			}
+			if err := d.value(depth + 1); err != nil {
+				return err
+			}
+		}
+		d.out = append(d.out, ']')
+	case cborMap:
+		d.out = append(d.out, '{')
+		for i := uint64(0); ; i++ {
+			more, err := d.more(info == cborIndefinite, i, arg)
+			if err != nil {
+				return err
+			}
+			if !more {
+				break
+			}
+			if i > 0 {
+				d.out = append(d.out, ',')
+			}
+			if err := d.key(); err != nil {
+				return err
+			}
+			d.out = append(d.out, ':')
+			if err := d.value(depth + 1); err != nil {
+				return err
+			}
+		}
+		d.out = append(d.out, '}')
+	case cborTag:
+		if arg != cborTagPosBignum && arg != cborTagNegBignum {
+			return fmt.Errorf("cbor: unsupported tag %d", arg)
+		}
+		_, info, size, err := d.head()
+		if err != nil {
+			return err
+		}
+		b, err := d.bytes(info, size)
+		if err != nil {
+			return err
+		}
+		n := new(big.Int).SetBytes(b)
+		if arg == cborTagNegBignum {
+			n.Not(n)
+		}
+		d.out = n.Append(d.out, 10)
+	default:
+		return d.simple(info, arg)
+	}
+	return nil
+}
+
+// key converts a map key, which must be a text or byte string, to JSON.
+func (d *cborDecoder) key() error {
+	major, info, arg, err := d.head()
+	if err != nil {
+		return err
+	}
+	b, err := d.bytes(info, arg)
+	if err != nil {
+		return err
+	}
+	switch major {
+	case cborText:
+		enc, err := json.Marshal(string(b))
+		if err != nil {
+			return err
+		}
+		d.out = append(d.out, enc...)
+	case cborBytes:
+		d.appendHex(b)
+	default:
+		return fmt.Errorf("cbor: unsupported map key type %d", major>>5)
+	}
+	return nil
+}
+
+// simple converts a simple value or a float to JSON.
+func (d *cborDecoder) simple(info byte, arg uint64) error {
+	var f float64
+	switch info {
+	case 20:
+		d.out = append(d.out, "false"...)
+		return nil
+	case 21:
+		d.out = append(d.out, "true"...)
+		return nil
+	case 22, 23:
+		d.out = append(d.out, "null"...)
+		return nil
+	case 25:
+		f = float16ToFloat64(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	default:
+		return fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return errors.New("cbor: unsupported float value")
+	}
+	d.out = strconv.AppendFloat(d.out, f, 'g', -1, 64)
+	return nil
+}
+
+// appendHex appends a byte string as a hex string with a 0x prefix.
+func (d *cborDecoder) appendHex(b []byte) {
+	d.out = append(d.out, `"0x`...)
+	d.out = hex.AppendEncode(d.out, b)
+	d.out = append(d.out, '"')
+}
+
+// float16ToFloat64 converts a half precision float.
+func float16ToFloat64(h uint16) float64 {
+	var (
+		exp  = int(h>>10) & 0x1f
+		mant = float64(h & 0x3ff)
+		f    float64
+	)
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	tests := []string{
+		`null`,
+		`true`,
+		`[false,null,0,1,23,24,255,256,65536,4294967296,18446744073709551615]`,
+		`[-1,-24,-25,-9223372036854775808,-18446744073709551616]`,
+		`[18446744073709551616,-18446744073709551617,123456789012345678901234567890]`,
+		`[1.5,-0.25,1e+100]`,
+		`["","0x","0x0","0xdeadbeef","0xDEADBEEF","0xzz","hello \"world\"\n","é"]`,
+		`{}`,
+		`{"0x01":[],"key":{"nested":[{"a":1}]}}`,
+	}
+	for _, test := range tests {
+		enc, err := jsonToCBOR([]byte(test))
+		if err != nil {
+			t.Fatalf("%s: encoding failed: %v", test, err)
+		}
+		dec, err := cborToJSON(enc)
+		if err != nil {
+			t.Fatalf("%s: decoding failed: %v", test, err)
+		}
+		if string(dec) != test {
+			t.Fatalf("wrong round trip: have %s, want %s", dec, test)
+		}
+	}
+}
+
+func TestCBORHexBytes(t *testing.T) {
+	hash := `"0x` + strings.Repeat("ab", 32) + `"`
+	enc, err := jsonToCBOR([]byte(hash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enc) != 34 || enc[0] != cborBytes|24 || enc[1] != 32 {
+		t.Fatalf("hash not encoded as byte string: %x", enc)
+	}
+}
+
+func TestCBORInvalid(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{cborArray | 2, 1},
+		{cborText | 5, 'a'},
+		{cborArray | cborIndefinite, 1},
+		{cborTag | 5, 1},
+		{1, 2},
+		bytes.Repeat([]byte{cborArray | 1}, maxCBORDepth+2),
+	}
+	for _, test := range tests {
+		if _, err := cborToJSON(test); err == nil {
+			t.Errorf("expected error decoding %x", test)
+		}
+	}
+}
+
+func TestHTTPCBOR(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	c, err := DialOptions(context.Background(), ts.URL, WithBinaryEncoding())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var result echoResult
+	if err := c.Call(&result, "test_echo", "0xdeadbeef", -7, &echoArgs{"world"}); err != nil {
+		t.Fatal(err)
+	}
+	if result.String != "0xdeadbeef" || result.Int != -7 || result.Args.S != "world" {
+		t.Fatalf("wrong result: %+v", result)
+	}
+	if err := c.Call(nil, "test_returnError"); err == nil || err.Error() != (testError{}).Error() {
+		t.Fatalf("wrong error: %v", err)
+	}
+	batch := []BatchElem{
+		{Method: "test_echo", Args: []interface{}{"0x01", 1, &echoArgs{"a"}}, Result: new(echoResult)},
+		{Method: "no_such_method", Result: new(int)},
+	}
+	if err := c.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].Error != nil || batch[0].Result.(*echoResult).String != "0x01" || batch[1].Error == nil {
+		t.Fatalf("wrong batch results: %+v", batch)
+	}
+
+	// Check the content type of the responses
+	for _, accept := range []string{"application/cbor", "application/json", "application/cbor;q=0"} {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`))
+		req.Header.Set("content-type", contentType)
+		req.Header.Set("accept", accept)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		want := contentType
+		if accept == cborContentType {
+			want = cborContentType
+			if body, err = cborToJSON(body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if have := resp.Header.Get("content-type"); have != want {
+			t.Fatalf("%s: wrong content type %q", accept, have)
+		}
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(body, &msg); err != nil || msg.Result == nil {
+			t.Fatalf("%s: invalid response %s", accept, body)
+		}
+	}
+}
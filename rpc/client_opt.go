@@ -29,9 +29,10 @@ type ClientOption interface {
 
 type clientConfig struct {
 	// HTTP settings
-	httpClient  *http.Client
-	httpHeaders http.Header
-	httpAuth    HTTPAuth
+	httpClient     *http.Client
+	httpHeaders    http.Header
+	httpAuth       HTTPAuth
+	binaryEncoding bool
 
 	// WebSocket options
 	wsDialer           *websocket.Dialer
@@ -41,6 +42,8 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	limiter            *rateLimiter
+	tracker            *CallTracker
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -114,6 +117,15 @@ func WithHTTPAuth(a HTTPAuth) ClientOption {
 	})
 }
 
+// WithBinaryEncoding makes the client request HTTP responses in CBOR, which sends
+// hex data such as hashes and raw RLP as binary. Responses are converted back to
+// JSON, and servers that do not support CBOR still answer in JSON.
+func WithBinaryEncoding() ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.binaryEncoding = true
+	})
+}
+
 // A HTTPAuth function is called by the client whenever a HTTP request is sent.
 // The function must be safe for concurrent use.
 //
@@ -0,0 +1,195 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// acceptedEncodings is the Accept-Encoding header sent by the HTTP client.
+const acceptedEncodings = "zstd, gzip"
+
+// compressor is a pooled compressing writer.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressorPools are the compressors of the supported content encodings, in
+// order of preference.
+var compressorPools = []struct {
+	encoding string
+	pool     *sync.Pool
+}{
+	{"zstd", &sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+		return w
+	}}},
+	{"gzip", &sync.Pool{New: func() any {
+		return gzip.NewWriter(io.Discard)
+	}}},
+}
+
+// negotiateEncoding returns the index in compressorPools of the preferred
+// content encoding accepted by the Accept-Encoding header, or -1 if none is.
+func negotiateEncoding(accept string) int {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	for i, c := range compressorPools {
+		if accepted[c.encoding] {
+			return i
+		}
+	}
+	return -1
+}
+
+// compressResponseWriter compresses the response written by the server.
+type compressResponseWriter struct {
+	resp     http.ResponseWriter
+	encoding int // index of the content encoding in compressorPools
+
+	cw     compressor
+	inited bool
+}
+
+// newCompressResponseWriter wraps a response writer, compressing the response in
+// the preferred encoding accepted by the request. It returns the original writer
+// if the request accepts no supported encoding.
+func newCompressResponseWriter(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+	if encoding < 0 {
+		return w, func() {}
+	}
+	cw := &compressResponseWriter{resp: w, encoding: encoding}
+	return cw, cw.close
+}
+
+// init runs just before the response headers are written, and decides whether
+// the response is compressed. Like the gzip handler of package node, it leaves
+// the responses with "Transfer-Encoding: identity" uncompressed, which are the
+// error responses flushed out close to the write deadline.
+func (w *compressResponseWriter) init() {
+	if w.inited {
+		return
+	}
+	w.inited = true
+
+	hdr := w.resp.Header()
+	hdr.Add("vary", "accept-encoding")
+	if hdr.Get("transfer-encoding") == "identity" || hdr.Get("content-encoding") != "" {
+		return
+	}
+	w.cw = compressorPools[w.encoding].pool.Get().(compressor)
+	w.cw.Reset(w.resp)
+	hdr.Del("content-length")
+	hdr.Set("content-encoding", compressorPools[w.encoding].encoding)
+}
+
+func (w *compressResponseWriter) Header() http.Header {
+	return w.resp.Header()
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	w.init()
+	w.resp.WriteHeader(status)
+}
+
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	w.init()
+	if w.cw == nil {
+		return w.resp.Write(b)
+	}
+	return w.cw.Write(b)
+}
+
+func (w *compressResponseWriter) Flush() {
+	if w.cw != nil {
+		w.cw.Flush()
+	}
+	if f, ok := w.resp.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer, giving http.ResponseController access to
+// it.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
+// close finishes the compressed stream and returns the compressor to its pool.
+func (w *compressResponseWriter) close() {
+	if w.cw == nil {
+		return
+	}
+	w.cw.Close()
+	w.cw.Reset(io.Discard)
+	compressorPools[w.encoding].pool.Put(w.cw)
+	w.cw = nil
+}
+
+// decompressedBody is a decompressed response body.
+type decompressedBody struct {
+	io.Reader
+	body  io.ReadCloser
+	close func()
+}
+
+func (b *decompressedBody) Close() error {
+	b.close()
+	return b.body.Close()
+}
+
+// decompressBody returns the body of a response, decompressed according to its
+// content encoding.
+func decompressBody(resp *http.Response) (io.ReadCloser, error) {
+	switch enc := strings.ToLower(resp.Header.Get("content-encoding")); enc {
+	case "", "identity":
+		return resp.Body, nil
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedBody{Reader: gz, body: resp.Body, close: func() { gz.Close() }}, nil
+	case "zstd":
+		zr, err := zstd.NewReader(resp.Body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &decompressedBody{Reader: zr, body: resp.Body, close: zr.Close}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", enc)
+	}
+}
@@ -55,12 +55,15 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
 	_ Error = new(internalServerError)
+	_ Error = new(limitExceededError)
+	_ Error = new(methodNotAllowedError)
 )
 
 const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -91,6 +94,14 @@ func (e *methodNotFoundError) Error() string {
 	return fmt.Sprintf("the method %s does not exist/is not available", e.method)
 }
 
+type methodNotAllowedError struct{ method string }
+
+func (e *methodNotAllowedError) ErrorCode() int { return -32601 }
+
+func (e *methodNotAllowedError) Error() string {
+	return fmt.Sprintf("the method %s is not allowed for this client", e.method)
+}
+
 type notificationsUnsupportedError struct{}
 
 func (e notificationsUnsupportedError) Error() string {
@@ -155,6 +166,13 @@ func (e *invalidParamsError) ErrorCode() int { return -32602 }
 
 func (e *invalidParamsError) Error() string { return e.message }
 
+// limitExceededError is returned for calls refused by the rate limits.
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string { return e.message }
+
 // internalServerError is used for server errors during request processing.
 type internalServerError struct {
 	code    int
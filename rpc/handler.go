@@ -28,6 +28,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -65,6 +66,8 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	limiter              *rateLimiter // per-client call limits, nil if unlimited
+	tracker              *CallTracker // call statistics, nil if not tracked
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -73,6 +76,7 @@ type handler struct {
 type callProc struct {
 	ctx       context.Context
 	notifiers []*Notifier
+	reads     *dbReadCounters // database reads of the calls, nil if not tracked
 }
 
 func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, batchRequestLimit, batchResponseMaxSize int) *handler {
@@ -387,7 +391,13 @@ func (h *handler) startCallProc(fn func(*callProc)) {
 		ctx, cancel := context.WithCancel(h.rootCtx)
 		defer h.callWG.Done()
 		defer cancel()
-		fn(&callProc{ctx: ctx})
+		cp := &callProc{ctx: ctx}
+		if h.tracker != nil {
+			cp.reads = new(dbReadCounters)
+			cp.ctx = context.WithValue(ctx, dbReadsContextKey{}, cp.reads)
+			defer cp.reads.close()
+		}
+		fn(cp)
 	}()
 }
 
@@ -466,6 +476,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	switch {
 	case msg.isNotification():
 		h.handleCall(ctx, msg)
+		if ctx.reads != nil {
+			ctx.reads.take() // notifications are not tracked
+		}
 		h.log.Debug("Served "+msg.Method, "duration", time.Since(start))
 		return nil
 
@@ -482,6 +495,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 		} else {
 			h.log.Debug("Served "+msg.Method, logctx...)
 		}
+		if h.tracker != nil {
+			h.trackCall(ctx, msg, resp, start)
+		}
 		return resp
 
 	case msg.hasValidID():
@@ -492,8 +508,37 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	}
 }
 
+// trackCall accounts a served call to the call statistics, and logs it if slow.
+func (h *handler) trackCall(cp *callProc, msg, resp *jsonrpcMessage, start time.Time) {
+	call := &CallRecord{
+		Time:         start,
+		Method:       msg.Method,
+		Params:       truncateParams(msg.Params),
+		Client:       clientAddr(PeerInfoFromContext(cp.ctx)),
+		ResponseSize: len(resp.Result),
+		DBReads:      cp.reads.take(),
+		Failed:       resp.Error != nil,
+		duration:     time.Since(start),
+	}
+	h.tracker.record(call)
+	if h.tracker.slow(call.duration) {
+		h.log.Warn("Slow RPC call", "method", call.Method, "params", call.Params, "duration", common.PrettyDuration(call.duration),
+			"size", call.ResponseSize, "client", call.Client, "dbreads", call.DBReads, "failed", call.Failed)
+	}
+}
+
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if auth := PeerInfoFromContext(cp.ctx).Auth; !msg.isUnsubscribe() && !auth.allowed(msg.Method) {
+		return msg.errorResponse(&methodNotAllowedError{method: msg.Method})
+	}
+	if h.limiter != nil && !msg.isUnsubscribe() {
+		release, err := h.limiter.acquire(cp.ctx, msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
+		defer release()
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
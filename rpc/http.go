@@ -143,8 +143,12 @@ func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
 }
 
 func newClientTransportHTTP(endpoint string, cfg *clientConfig) reconnectFunc {
-	headers := make(http.Header, 2+len(cfg.httpHeaders))
+	headers := make(http.Header, 3+len(cfg.httpHeaders))
 	headers.Set("accept", contentType)
+	if cfg.binaryEncoding {
+		headers.Set("accept", cborContentType+", "+contentType)
+	}
+	headers.Set("accept-encoding", acceptedEncodings)
 	headers.Set("content-type", contentType)
 	for key, values := range cfg.httpHeaders {
 		headers[key] = values
@@ -230,20 +234,40 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	if err != nil {
 		return nil, err
 	}
+	respBody, err := decompressBody(resp)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if err != nil {
+			respBody = resp.Body
+		}
 		var buf bytes.Buffer
 		var body []byte
-		if _, err := buf.ReadFrom(resp.Body); err == nil {
+		if _, err := buf.ReadFrom(respBody); err == nil {
 			body = buf.Bytes()
 		}
-		resp.Body.Close()
+		respBody.Close()
 		return nil, HTTPError{
 			Status:     resp.Status,
 			StatusCode: resp.StatusCode,
 			Body:       body,
 		}
 	}
-	return resp.Body, nil
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	// Convert CBOR responses back to JSON for the response decoder.
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("content-type")); mt == cborContentType {
+		defer respBody.Close()
+		data, err := io.ReadAll(respBody)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = cborToJSON(data); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return respBody, nil
 }
 
 // httpServerConn turns a HTTP connection into a Conn.
@@ -253,12 +277,24 @@ type httpServerConn struct {
 	r *http.Request
 }
 
-func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) ServerCodec {
+func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter, binary bool) ServerCodec {
 	body := io.LimitReader(r.Body, int64(s.httpBodyLimit))
 	conn := &httpServerConn{Reader: body, Writer: w, r: r}
 
+	marshal := json.Marshal
+	if binary {
+		marshal = marshalCBOR
+	}
 	encoder := func(v any, isErrorResponse bool) error {
 		if !isErrorResponse {
+			if binary {
+				encdata, err := marshalCBOR(v)
+				if err != nil {
+					return err
+				}
+				_, err = conn.Write(encdata)
+				return err
+			}
 			return json.NewEncoder(conn).Encode(v)
 		}
 
@@ -268,7 +304,7 @@ func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) Serve
 		// server's write timeout occurs. So we need to flush the response. The
 		// Content-Length header also needs to be set to ensure the client knows
 		// when it has the full response.
-		encdata, err := json.Marshal(v)
+		encdata, err := marshal(v)
 		if err != nil {
 			return err
 		}
@@ -318,7 +354,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create request-scoped context.
-	connInfo := PeerInfo{Transport: "http", RemoteAddr: r.RemoteAddr}
+	connInfo := PeerInfo{Transport: "http", RemoteAddr: r.RemoteAddr, Auth: s.clientAuth(r)}
 	connInfo.HTTP.Version = r.Proto
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
@@ -328,9 +364,17 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// All checks passed, create a codec that reads directly from the request body
 	// until EOF, writes the response to w, and orders the server to process a
-	// single request.
-	w.Header().Set("content-type", contentType)
-	codec := s.newHTTPServerConn(r, w)
+	// single request. The response is compressed and encoded in CBOR if accepted
+	// by the client.
+	binary := acceptsCBOR(r.Header.Get("accept"))
+	if binary {
+		w.Header().Set("content-type", cborContentType)
+	} else {
+		w.Header().Set("content-type", contentType)
+	}
+	w, closeWriter := newCompressResponseWriter(w, r)
+	defer closeWriter()
+	codec := s.newHTTPServerConn(r, w, binary)
 	defer codec.close()
 	s.serveSingleRequest(ctx, codec)
 }
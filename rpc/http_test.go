@@ -263,3 +263,48 @@ func TestNewContextWithHeaders(t *testing.T) {
 		t.Error("call failed:", err)
 	}
 }
+
+func TestHTTPCompression(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	for _, encoding := range []string{"zstd", "gzip", "identity"} {
+		c, err := DialOptions(context.Background(), ts.URL, WithHeader("Accept-Encoding", encoding))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result echoResult
+		arg := strings.Repeat("x", 1000)
+		if err := c.Call(&result, "test_echo", arg, 1, &echoArgs{"world"}); err != nil {
+			t.Fatalf("%q: %v", encoding, err)
+		}
+		if result.String != arg {
+			t.Fatalf("%q: wrong result %q", encoding, result.String)
+		}
+		c.Close()
+
+		// Check the encoding of the response
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`))
+		req.Header.Set("content-type", contentType)
+		req.Header.Set("accept-encoding", encoding)
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		want := encoding
+		if encoding == "identity" {
+			want = ""
+		}
+		if have := resp.Header.Get("content-encoding"); have != want {
+			t.Fatalf("wrong content encoding %q, want %q", have, want)
+		}
+	}
+	if have := negotiateEncoding("gzip, zstd;q=0"); have < 0 || compressorPools[have].encoding != "gzip" {
+		t.Fatalf("wrong negotiated encoding %d", have)
+	}
+}
@@ -32,6 +32,12 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	rateLimitedMeter        = metrics.NewRegisteredMeter("rpc/ratelimit/rate", nil)
+	concurrencyLimitedMeter = metrics.NewRegisteredMeter("rpc/ratelimit/concurrency", nil)
+
+	// rateLimitedName is the prefix of the per-method limited call counters.
+	rateLimitedName = "rpc/ratelimit/limited"
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -48,3 +54,8 @@ func updateServeTimeHistogram(method string, success bool, elapsed time.Duration
 	}
 	metrics.GetOrRegisterHistogramLazy(h, nil, sampler).Update(elapsed.Nanoseconds())
 }
+
+// updateRateLimitedCounter tracks a call of the method refused by the rate limits.
+func updateRateLimitedCounter(method string) {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("%s/%s", rateLimitedName, method), nil).Inc(1)
+}
@@ -0,0 +1,322 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"golang.org/x/time/rate"
+)
+
+// defaultRateLimitClients is the number of clients tracked by default.
+const defaultRateLimitClients = 10000
+
+// RateLimit is a limit applied separately to every client on the calls of the
+// methods matching a pattern. Patterns are either a method name, a prefix ending
+// with '*' such as "debug_trace*", or "*" for all methods.
+type RateLimit struct {
+	Methods       string  // Pattern of the limited methods
+	Rate          float64 // Tokens added to the bucket per second, zero for no rate limit
+	Burst         int     // Size of the token bucket, defaults to the rate
+	MaxConcurrent int     // Maximum number of calls in progress, zero for no limit
+}
+
+// RateLimitConfig configures the per-client limits of a server.
+type RateLimitConfig struct {
+	// Limits are the limits on the calls, all limits matching a method apply.
+	Limits []RateLimit `toml:",omitempty"`
+
+	// Costs are the tokens taken by the calls of the methods matching a pattern,
+	// one by default. The most specific pattern matching a method applies. Calls
+	// costing more than the burst of a limit take the full bucket.
+	Costs map[string]int `toml:",omitempty"`
+
+	// ClientHeader is the HTTP header identifying the clients, such as an API
+	// key. Clients without an identity are limited by IP address. The header
+	// is not verified, so the clients it identifies are limited by IP address
+	// too.
+	ClientHeader string `toml:",omitempty"`
+
+	// MaxClients is the number of clients whose limits are tracked, the least
+	// recently seen ones are forgotten beyond it.
+	MaxClients int `toml:",omitempty"`
+
+	// Tiers are the limits of the clients of the named tiers, replacing the
+	// default ones. Clients are assigned a tier by the handlers authenticating
+	// them, the default limits apply to the clients of unknown tiers.
+	Tiers map[string]RateLimitTier `toml:",omitempty"`
+}
+
+// RateLimitTier are the limits of the clients of a tier.
+type RateLimitTier struct {
+	Limits []RateLimit    `toml:",omitempty"`
+	Costs  map[string]int `toml:",omitempty"`
+}
+
+// cost returns the tokens taken by a call of the method.
+func (tier *RateLimitTier) cost(method string) int {
+	if cost, ok := tier.Costs[method]; ok {
+		return cost
+	}
+	var (
+		cost   = 1
+		length = -1
+	)
+	for pattern, c := range tier.Costs {
+		if matchMethod(pattern, method) && len(pattern) > length {
+			cost, length = c, len(pattern)
+		}
+	}
+	return cost
+}
+
+// matchMethod reports whether a method matches a limit pattern.
+func matchMethod(pattern, method string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(method, prefix)
+	}
+	return pattern == method
+}
+
+// rateLimiter applies the limits of a RateLimitConfig to the clients of a server.
+type rateLimiter struct {
+	tiers map[string]*RateLimitTier // Limits of the tiers, the default ones with no name
+
+	lock    sync.Mutex
+	clients lru.BasicLRU[string, *clientLimits]
+}
+
+// clientLimits is the state of the limits of a client.
+type clientLimits struct {
+	buckets []*rate.Limiter // Token buckets of the limits, nil without rate limit
+	active  []int           // Calls in progress of the limits
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	clients := config.MaxClients
+	if clients <= 0 {
+		clients = defaultRateLimitClients
+	}
+	tiers := map[string]*RateLimitTier{
+		"": {Limits: config.Limits, Costs: config.Costs},
+	}
+	for name, tier := range config.Tiers {
+		if name != "" {
+			tiers[name] = &tier
+		}
+	}
+	return &rateLimiter{
+		tiers:   tiers,
+		clients: lru.NewBasicLRU[string, *clientLimits](clients),
+	}
+}
+
+// client returns the limits state of a client, the caller must hold l.lock.
+func (l *rateLimiter) client(key string, tier *RateLimitTier) *clientLimits {
+	if c, ok := l.clients.Get(key); ok {
+		return c
+	}
+	c := &clientLimits{
+		buckets: make([]*rate.Limiter, len(tier.Limits)),
+		active:  make([]int, len(tier.Limits)),
+	}
+	for i, limit := range tier.Limits {
+		if limit.Rate > 0 {
+			burst := limit.Burst
+			if burst <= 0 {
+				burst = max(1, int(math.Ceil(limit.Rate)))
+			}
+			c.buckets[i] = rate.NewLimiter(rate.Limit(limit.Rate), burst)
+		}
+	}
+	l.clients.Add(key, c)
+	return c
+}
+
+// acquire admits a call of the method by the client of the context, returning
+// the function to call once it is done.
+func (l *rateLimiter) acquire(ctx context.Context, method string) (func(), error) {
+	info := PeerInfoFromContext(ctx)
+	tier, ok := l.tiers[info.Auth.Tier]
+	if !ok {
+		tier = l.tiers[""]
+	}
+	var matched []int
+	for i, limit := range tier.Limits {
+		if matchMethod(limit.Methods, method) {
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == 0 {
+		return func() {}, nil
+	}
+	var (
+		now  = time.Now()
+		cost = tier.cost(method)
+		keys = []string{info.Auth.Tier + "/" + clientKey(info)}
+	)
+	// Unverified identities don't lift the limits of the address, which are
+	// checked first so that made-up identities can't flood the tracked clients.
+	if info.Auth.unverified {
+		keys = append([]string{info.Auth.Tier + "/ip:" + clientAddr(info)}, keys...)
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var (
+		admitted     []*clientLimits
+		reservations []*rate.Reservation
+	)
+	for _, key := range keys {
+		c := l.client(key, tier)
+		rs, err := c.reserve(tier, matched, method, cost, now)
+		if err != nil {
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			return nil, err
+		}
+		admitted, reservations = append(admitted, c), append(reservations, rs...)
+	}
+	for _, c := range admitted {
+		for _, i := range matched {
+			c.active[i]++
+		}
+	}
+	return func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+
+		for _, c := range admitted {
+			for _, i := range matched {
+				c.active[i]--
+			}
+		}
+	}, nil
+}
+
+// reserve checks the concurrency limits of the client and reserves the tokens
+// of a call in its buckets, returning the reservations. The caller must hold the
+// lock of the rate limiter.
+func (c *clientLimits) reserve(tier *RateLimitTier, matched []int, method string, cost int, now time.Time) ([]*rate.Reservation, error) {
+	for _, i := range matched {
+		if n := tier.Limits[i].MaxConcurrent; n > 0 && c.active[i] >= n {
+			concurrencyLimitedMeter.Mark(1)
+			updateRateLimitedCounter(method)
+			return nil, &limitExceededError{fmt.Sprintf("too many concurrent requests for %s", method)}
+		}
+	}
+	reservations := make([]*rate.Reservation, 0, len(matched))
+	for _, i := range matched {
+		bucket := c.buckets[i]
+		if bucket == nil {
+			continue
+		}
+		r := bucket.ReserveN(now, min(cost, bucket.Burst()))
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			r.CancelAt(now)
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			rateLimitedMeter.Mark(1)
+			updateRateLimitedCounter(method)
+			return nil, &limitExceededError{fmt.Sprintf("rate limit exceeded for %s", method)}
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, nil
+}
+
+// clientKey returns the key of the limits of a client, its identity if known
+// or its IP address otherwise.
+func clientKey(info PeerInfo) string {
+	if info.Auth.ID != "" {
+		return "id:" + info.Auth.ID
+	}
+	return "ip:" + clientAddr(info)
+}
+
+// clientAddr returns the IP address of a client.
+func clientAddr(info PeerInfo) string {
+	host, _, err := net.SplitHostPort(info.RemoteAddr)
+	if err != nil {
+		return info.RemoteAddr
+	}
+	return host
+}
+
+// ClientAuth is the authorization of a client, set by the handlers authenticating
+// the HTTP requests to a server.
+type ClientAuth struct {
+	ID      string   // Identity of the client, the limits apply per identity
+	Tier    string   // Rate limit tier of the client, the default limits apply if empty
+	Methods []string // Patterns of the methods the client may call, all if empty
+
+	unverified bool // Identity taken from the client header, limited per address too
+}
+
+// allowed returns whether the client may call the method. The methods of the
+// rpc namespace are always allowed.
+func (auth *ClientAuth) allowed(method string) bool {
+	if len(auth.Methods) == 0 || strings.HasPrefix(method, MetadataApi+serviceMethodSeparator) {
+		return true
+	}
+	for _, pattern := range auth.Methods {
+		if matchMethod(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+type clientAuthContextKey struct{}
+
+// WithClientAuth returns a context carrying the authorization of the client
+// making an HTTP request. Handlers authenticating the requests to a server set
+// it for the server to restrict the methods of the client and to apply the
+// limits of its tier per identity instead of per address.
+func WithClientAuth(ctx context.Context, auth *ClientAuth) context.Context {
+	return context.WithValue(ctx, clientAuthContextKey{}, auth)
+}
+
+// WithClientIdentity returns a context carrying the identity of the client
+// making an HTTP request, such as the subject of its authentication token.
+func WithClientIdentity(ctx context.Context, id string) context.Context {
+	return WithClientAuth(ctx, &ClientAuth{ID: id})
+}
+
+// clientAuth returns the authorization of the client making an HTTP request,
+// set by an authentication handler or identified by the client header.
+func (s *Server) clientAuth(r *http.Request) ClientAuth {
+	if auth, _ := r.Context().Value(clientAuthContextKey{}).(*ClientAuth); auth != nil {
+		return *auth
+	}
+	if s.clientHeader != "" {
+		if key := r.Header.Get(s.clientHeader); key != "" {
+			return ClientAuth{ID: "key:" + key, unverified: true}
+		}
+	}
+	return ClientAuth{}
+}
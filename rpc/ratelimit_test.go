@@ -0,0 +1,167 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// expectLimited checks whether a call failed because of the rate limits.
+func expectLimited(t *testing.T, err error, limited bool) {
+	t.Helper()
+
+	var rpcErr Error
+	switch {
+	case !limited && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case limited && (!errors.As(err, &rpcErr) || rpcErr.ErrorCode() != errcodeLimitExceeded):
+		t.Fatalf("expected limit exceeded error, have %v", err)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Limits: []RateLimit{
+			{Methods: "test_echo", Rate: 0.001, Burst: 3},
+			{Methods: "test_*", MaxConcurrent: 1},
+		},
+		Costs:        map[string]int{"test_*": 1, "test_echo": 2},
+		ClientHeader: "X-Api-Key",
+	})
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	dial := func(key string) *Client {
+		var opts []ClientOption
+		if key != "" {
+			opts = append(opts, WithHeader("X-Api-Key", key))
+		}
+		client, err := DialOptions(context.Background(), httpsrv.URL, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(client.Close)
+		return client
+	}
+	echo := func(client *Client) error {
+		var result echoResult
+		return client.Call(&result, "test_echo", "hello", 10, &echoArgs{"world"})
+	}
+	// A call costs two tokens out of three, the second one is refused
+	alice := dial("alice")
+	expectLimited(t, echo(alice), false)
+	expectLimited(t, echo(alice), true)
+
+	// Methods out of the pattern are not limited
+	for i := 0; i < 3; i++ {
+		expectLimited(t, alice.Call(nil, "nftest_echo", 1), false)
+	}
+	// Clients identified by the unverified API key header are limited by their
+	// address too, so other keys and anonymous calls from it are refused
+	bob, anonymous := dial("bob"), dial("")
+	expectLimited(t, echo(bob), true)
+	expectLimited(t, echo(anonymous), true)
+
+	// Concurrent calls beyond the cap are refused until the running one is done
+	done := make(chan error)
+	go func() {
+		done <- bob.Call(nil, "test_sleep", 300*time.Millisecond)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	expectLimited(t, bob.Call(nil, "test_null"), true)
+	expectLimited(t, alice.Call(nil, "test_null"), true)
+	expectLimited(t, <-done, false)
+	expectLimited(t, bob.Call(nil, "test_null"), false)
+}
+
+func TestRateLimitClientIdentity(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Limits: []RateLimit{{Methods: "*", Rate: 1}},
+	})
+	req := httptest.NewRequest("POST", "/", strings.NewReader(""))
+	if id := server.clientAuth(req).ID; id != "" {
+		t.Fatalf("unexpected identity %q", id)
+	}
+	req = req.WithContext(WithClientIdentity(req.Context(), "jwt:alice"))
+	if id := server.clientAuth(req).ID; id != "jwt:alice" {
+		t.Fatalf("wrong identity %q", id)
+	}
+	if key := clientKey(PeerInfo{RemoteAddr: "10.0.0.1:30303"}); key != "ip:10.0.0.1" {
+		t.Fatalf("wrong client key %q", key)
+	}
+}
+
+func TestRateLimitTiers(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	server.SetRateLimits(RateLimitConfig{
+		Limits: []RateLimit{{Methods: "*", Rate: 0.001, Burst: 1}},
+		Tiers: map[string]RateLimitTier{
+			"premium": {Limits: []RateLimit{{Methods: "*", Rate: 0.001, Burst: 3}}},
+		},
+	})
+	// Authenticate the clients by their tier header, restricting the basic ones
+	httpsrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tier := r.Header.Get("X-Tier")
+		auth := &ClientAuth{ID: tier, Tier: tier}
+		if tier == "basic" {
+			auth.Methods = []string{"test_echo"}
+		}
+		server.ServeHTTP(w, r.WithContext(WithClientAuth(r.Context(), auth)))
+	}))
+	defer httpsrv.Close()
+
+	call := func(tier, method string) error {
+		client, err := DialOptions(context.Background(), httpsrv.URL, WithHeader("X-Tier", tier))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		return client.Call(nil, method)
+	}
+	for i := 0; i < 3; i++ {
+		expectLimited(t, call("premium", "test_null"), false)
+	}
+	expectLimited(t, call("premium", "test_null"), true)
+
+	// Unknown tiers get the default limits
+	expectLimited(t, call("other", "test_null"), false)
+	expectLimited(t, call("other", "test_null"), true)
+
+	// Methods out of the allowed ones are refused before the limits
+	var rpcErr Error
+	if err := call("basic", "test_null"); !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32601 {
+		t.Fatalf("expected method not allowed error, have %v", err)
+	}
+	expectLimited(t, call("basic", "rpc_modules"), false)
+}
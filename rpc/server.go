@@ -54,6 +54,9 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	limiter            *rateLimiter
+	clientHeader       string
+	tracker            *CallTracker
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -89,6 +92,28 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetRateLimits sets the limits applied to the calls of every client. Clients
+// are identified by the identity set through WithClientAuth, the configured
+// client header or their IP address.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimits(config RateLimitConfig) {
+	s.limiter = nil
+	if len(config.Limits) > 0 || len(config.Tiers) > 0 {
+		s.limiter = newRateLimiter(config)
+	}
+	s.clientHeader = config.ClientHeader
+}
+
+// SetCallTracker sets the tracker accounting the calls served by the server.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetCallTracker(tracker *CallTracker) {
+	s.tracker = tracker
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -114,6 +139,8 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		limiter:            s.limiter,
+		tracker:            s.tracker,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -149,6 +176,8 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.limiter = s.limiter
+	h.tracker = s.tracker
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -226,6 +255,9 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// Authorization of the client, empty for anonymous clients.
+	Auth ClientAuth
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
@@ -61,6 +61,7 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, wsDefaultReadLimit)
+		codec.(*websocketCodec).info.Auth = s.clientAuth(r)
 		s.ServeCodec(codec, 0)
 	})
 }